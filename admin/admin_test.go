@@ -0,0 +1,229 @@
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/christiangda/grpc-go-course/admin"
+	"github.com/christiangda/grpc-go-course/admin/adminpb"
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/calculator/calculatorservice"
+	"github.com/christiangda/grpc-go-course/fault"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/greetservice"
+	"github.com/christiangda/grpc-go-course/logging"
+)
+
+const testToken = "test-token"
+
+// logBuffer collects the standard logger output, written by the server
+// goroutines
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// take returns the output written since the last call
+func (b *logBuffer) take() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.buf.Reset()
+	return b.buf.String()
+}
+
+// captureLogs redirects the standard logger until the test ends, restoring
+// the log level too
+func captureLogs(t *testing.T) *logBuffer {
+	t.Helper()
+	var b logBuffer
+	log.SetOutput(&b)
+	level := logging.GetLevel()
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		logging.SetLevel(level)
+	})
+	return &b
+}
+
+// serve starts s on an in-process listener and returns a connection to it
+func serve(t *testing.T, s *grpc.Server, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 16)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	dial := func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}
+	opts = append(opts, grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	cc, err := grpc.Dial("bufconn", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return cc
+}
+
+func TestSetLogLevelSuppressesServiceLogs(t *testing.T) {
+	logs := captureLogs(t)
+	logging.SetLevel(logging.Info)
+
+	greetServer, err := greetservice.New(greetservice.DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { greetServer.Close() })
+	calculatorServer, err := calculatorservice.New(calculatorservice.DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	greetpb.RegisterGreetServiceServer(s, greetServer)
+	calculatorpb.RegisterCalculatorServiceServer(s, calculatorServer)
+	cc := serve(t, s)
+	greet := greetpb.NewGreetServiceClient(cc)
+	calculator := calculatorpb.NewCalculatorServiceClient(cc)
+
+	adminServer, err := admin.NewServer(admin.Options{Token: testToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminClient := adminpb.NewAdminServiceClient(serve(t, adminServer, grpc.WithPerRPCCredentials(admin.InsecureTokenCredentials(testToken))))
+
+	// call makes a call to each service and returns their logs
+	call := func() string {
+		ctx := context.Background()
+		if _, err := greet.Greet(ctx, &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Ada"}}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := calculator.Sum(ctx, &calculatorpb.SumRequest{FirstNumber: 3, SecondNumber: 10}); err != nil {
+			t.Fatal(err)
+		}
+		return logs.take()
+	}
+	setLevel := func(level adminpb.LogLevel) {
+		if _, err := adminClient.SetLogLevel(context.Background(), &adminpb.SetLogLevelRequest{Level: level}); err != nil {
+			t.Fatal(err)
+		}
+		logs.take()
+	}
+
+	infoLogs := []string{"INFO: Greet function was invoked", "INFO: Received Sum RPC"}
+	out := call()
+	for _, want := range infoLogs {
+		if !strings.Contains(out, want) {
+			t.Errorf("the info logs %q miss %q", out, want)
+		}
+	}
+
+	setLevel(adminpb.LogLevel_ERROR)
+	if out := call(); out != "" {
+		t.Errorf("logged %q at the error level", out)
+	}
+
+	setLevel(adminpb.LogLevel_INFO)
+	out = call()
+	for _, want := range infoLogs {
+		if !strings.Contains(out, want) {
+			t.Errorf("the info logs %q miss %q once restored", out, want)
+		}
+	}
+}
+
+func TestTokenCredentialsRequireTLS(t *testing.T) {
+	_, err := grpc.Dial("bufconn", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithPerRPCCredentials(admin.TokenCredentials(testToken)))
+	if err == nil || !strings.Contains(err.Error(), "transport level security") {
+		t.Errorf("got %v, want the token refused without TLS", err)
+	}
+}
+
+func TestFaultsChangeAtRuntime(t *testing.T) {
+	captureLogs(t)
+	faults, err := fault.New(fault.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calculatorServer, err := calculatorservice.New(calculatorservice.DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(grpc.UnaryInterceptor(faults.UnaryServerInterceptor()))
+	calculatorpb.RegisterCalculatorServiceServer(s, calculatorServer)
+	calculator := calculatorpb.NewCalculatorServiceClient(serve(t, s))
+
+	adminServer, err := admin.NewServer(admin.Options{Token: testToken, Faults: faults})
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminClient := adminpb.NewAdminServiceClient(serve(t, adminServer, grpc.WithPerRPCCredentials(admin.InsecureTokenCredentials(testToken))))
+
+	sum := func() codes.Code {
+		_, err := calculator.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: 3, SecondNumber: 10})
+		return status.Code(err)
+	}
+	ctx := context.Background()
+	rule := &adminpb.FaultRule{Methods: []string{"calculator.CalculatorService/Sum"}, Code: "UNAVAILABLE"}
+
+	tests := []struct {
+		name   string
+		update func() (*adminpb.Faults, error)
+		// enabled is the state returned by the update
+		enabled bool
+		code    codes.Code
+	}{
+		{"set disabled", func() (*adminpb.Faults, error) {
+			return adminClient.SetFaults(ctx, &adminpb.SetFaultsRequest{Faults: &adminpb.Faults{Rules: []*adminpb.FaultRule{rule}}})
+		}, false, codes.OK},
+		{"enable", func() (*adminpb.Faults, error) {
+			return adminClient.EnableFaults(ctx, &adminpb.EnableFaultsRequest{Enabled: true})
+		}, true, codes.Unavailable},
+		{"disable", func() (*adminpb.Faults, error) {
+			return adminClient.EnableFaults(ctx, &adminpb.EnableFaultsRequest{Enabled: false})
+		}, false, codes.OK},
+		{"set enabled", func() (*adminpb.Faults, error) {
+			return adminClient.SetFaults(ctx, &adminpb.SetFaultsRequest{Faults: &adminpb.Faults{Enabled: true, Rules: []*adminpb.FaultRule{{Methods: rule.Methods, Code: "ABORTED"}}}})
+		}, true, codes.Aborted},
+		{"set none", func() (*adminpb.Faults, error) {
+			return adminClient.SetFaults(ctx, &adminpb.SetFaultsRequest{Faults: &adminpb.Faults{Enabled: true}})
+		}, true, codes.OK},
+	}
+	for _, tt := range tests {
+		res, err := tt.update()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if res.GetEnabled() != tt.enabled {
+			t.Errorf("%s: got enabled %v", tt.name, res.GetEnabled())
+		}
+		if code := sum(); code != tt.code {
+			t.Errorf("%s: Sum got %v, want %v", tt.name, code, tt.code)
+		}
+	}
+
+	// a bad rule leaves the rules in effect
+	_, err = adminClient.SetFaults(ctx, &adminpb.SetFaultsRequest{Faults: &adminpb.Faults{Enabled: true, Rules: []*adminpb.FaultRule{{Code: "BROKEN"}}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got %v from a bad rule, want InvalidArgument", err)
+	}
+	if res, err := adminClient.GetFaults(ctx, &adminpb.GetFaultsRequest{}); err != nil || !res.GetEnabled() || len(res.GetRules()) != 0 {
+		t.Errorf("got %v, %v after a bad rule", res, err)
+	}
+}
@@ -0,0 +1,1673 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin/adminpb/admin.proto
+
+package adminpb // import "github.com/christiangda/grpc-go-course/admin/adminpb"
+
+/*
+Runtime introspection of a server, served on the admin port next to
+channelz.
+*/
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import durationpb "google.golang.org/protobuf/types/known/durationpb"
+import structpb "google.golang.org/protobuf/types/known/structpb"
+import timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type LogLevel int32
+
+const (
+	LogLevel_LOG_LEVEL_UNSPECIFIED LogLevel = 0
+	LogLevel_DEBUG                 LogLevel = 1
+	LogLevel_INFO                  LogLevel = 2
+	LogLevel_WARNING               LogLevel = 3
+	LogLevel_ERROR                 LogLevel = 4
+)
+
+var LogLevel_name = map[int32]string{
+	0: "LOG_LEVEL_UNSPECIFIED",
+	1: "DEBUG",
+	2: "INFO",
+	3: "WARNING",
+	4: "ERROR",
+}
+var LogLevel_value = map[string]int32{
+	"LOG_LEVEL_UNSPECIFIED": 0,
+	"DEBUG":                 1,
+	"INFO":                  2,
+	"WARNING":               3,
+	"ERROR":                 4,
+}
+
+func (x LogLevel) String() string {
+	return proto.EnumName(LogLevel_name, int32(x))
+}
+func (LogLevel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{0}
+}
+
+type ListMethodsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMethodsRequest) Reset()         { *m = ListMethodsRequest{} }
+func (m *ListMethodsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMethodsRequest) ProtoMessage()    {}
+func (*ListMethodsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{0}
+}
+func (m *ListMethodsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMethodsRequest.Unmarshal(m, b)
+}
+func (m *ListMethodsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMethodsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListMethodsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMethodsRequest.Merge(dst, src)
+}
+func (m *ListMethodsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMethodsRequest.Size(m)
+}
+func (m *ListMethodsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMethodsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMethodsRequest proto.InternalMessageInfo
+
+type MethodStats struct {
+	// full method name, e.g. "/greet.GreetService/GreetEveryone"
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// calls and streams in progress
+	ActiveCalls  int64 `protobuf:"varint,2,opt,name=active_calls,json=activeCalls,proto3" json:"active_calls,omitempty"`
+	StartedCalls int64 `protobuf:"varint,3,opt,name=started_calls,json=startedCalls,proto3" json:"started_calls,omitempty"`
+	// calls that ended with a status other than OK
+	FailedCalls          int64    `protobuf:"varint,4,opt,name=failed_calls,json=failedCalls,proto3" json:"failed_calls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MethodStats) Reset()         { *m = MethodStats{} }
+func (m *MethodStats) String() string { return proto.CompactTextString(m) }
+func (*MethodStats) ProtoMessage()    {}
+func (*MethodStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{1}
+}
+func (m *MethodStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MethodStats.Unmarshal(m, b)
+}
+func (m *MethodStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MethodStats.Marshal(b, m, deterministic)
+}
+func (dst *MethodStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MethodStats.Merge(dst, src)
+}
+func (m *MethodStats) XXX_Size() int {
+	return xxx_messageInfo_MethodStats.Size(m)
+}
+func (m *MethodStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_MethodStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MethodStats proto.InternalMessageInfo
+
+func (m *MethodStats) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *MethodStats) GetActiveCalls() int64 {
+	if m != nil {
+		return m.ActiveCalls
+	}
+	return 0
+}
+
+func (m *MethodStats) GetStartedCalls() int64 {
+	if m != nil {
+		return m.StartedCalls
+	}
+	return 0
+}
+
+func (m *MethodStats) GetFailedCalls() int64 {
+	if m != nil {
+		return m.FailedCalls
+	}
+	return 0
+}
+
+type ListMethodsResponse struct {
+	Methods              []*MethodStats `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListMethodsResponse) Reset()         { *m = ListMethodsResponse{} }
+func (m *ListMethodsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMethodsResponse) ProtoMessage()    {}
+func (*ListMethodsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{2}
+}
+func (m *ListMethodsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMethodsResponse.Unmarshal(m, b)
+}
+func (m *ListMethodsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMethodsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListMethodsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMethodsResponse.Merge(dst, src)
+}
+func (m *ListMethodsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMethodsResponse.Size(m)
+}
+func (m *ListMethodsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMethodsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMethodsResponse proto.InternalMessageInfo
+
+func (m *ListMethodsResponse) GetMethods() []*MethodStats {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+type ListPeersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPeersRequest) Reset()         { *m = ListPeersRequest{} }
+func (m *ListPeersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPeersRequest) ProtoMessage()    {}
+func (*ListPeersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{3}
+}
+func (m *ListPeersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPeersRequest.Unmarshal(m, b)
+}
+func (m *ListPeersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPeersRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListPeersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPeersRequest.Merge(dst, src)
+}
+func (m *ListPeersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPeersRequest.Size(m)
+}
+func (m *ListPeersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPeersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPeersRequest proto.InternalMessageInfo
+
+type Peer struct {
+	RemoteAddress        string                 `protobuf:"bytes,1,opt,name=remote_address,json=remoteAddress,proto3" json:"remote_address,omitempty"`
+	LocalAddress         string                 `protobuf:"bytes,2,opt,name=local_address,json=localAddress,proto3" json:"local_address,omitempty"`
+	ActiveCalls          int64                  `protobuf:"varint,3,opt,name=active_calls,json=activeCalls,proto3" json:"active_calls,omitempty"`
+	ConnectTime          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=connect_time,json=connectTime,proto3" json:"connect_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *Peer) Reset()         { *m = Peer{} }
+func (m *Peer) String() string { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()    {}
+func (*Peer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{4}
+}
+func (m *Peer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Peer.Unmarshal(m, b)
+}
+func (m *Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Peer.Marshal(b, m, deterministic)
+}
+func (dst *Peer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Peer.Merge(dst, src)
+}
+func (m *Peer) XXX_Size() int {
+	return xxx_messageInfo_Peer.Size(m)
+}
+func (m *Peer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Peer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Peer proto.InternalMessageInfo
+
+func (m *Peer) GetRemoteAddress() string {
+	if m != nil {
+		return m.RemoteAddress
+	}
+	return ""
+}
+
+func (m *Peer) GetLocalAddress() string {
+	if m != nil {
+		return m.LocalAddress
+	}
+	return ""
+}
+
+func (m *Peer) GetActiveCalls() int64 {
+	if m != nil {
+		return m.ActiveCalls
+	}
+	return 0
+}
+
+func (m *Peer) GetConnectTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.ConnectTime
+	}
+	return nil
+}
+
+type ListPeersResponse struct {
+	Peers                []*Peer  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPeersResponse) Reset()         { *m = ListPeersResponse{} }
+func (m *ListPeersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPeersResponse) ProtoMessage()    {}
+func (*ListPeersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{5}
+}
+func (m *ListPeersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPeersResponse.Unmarshal(m, b)
+}
+func (m *ListPeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPeersResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListPeersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPeersResponse.Merge(dst, src)
+}
+func (m *ListPeersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPeersResponse.Size(m)
+}
+func (m *ListPeersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPeersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPeersResponse proto.InternalMessageInfo
+
+func (m *ListPeersResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type GetBuildInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBuildInfoRequest) Reset()         { *m = GetBuildInfoRequest{} }
+func (m *GetBuildInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBuildInfoRequest) ProtoMessage()    {}
+func (*GetBuildInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{6}
+}
+func (m *GetBuildInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBuildInfoRequest.Unmarshal(m, b)
+}
+func (m *GetBuildInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBuildInfoRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBuildInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBuildInfoRequest.Merge(dst, src)
+}
+func (m *GetBuildInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBuildInfoRequest.Size(m)
+}
+func (m *GetBuildInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBuildInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBuildInfoRequest proto.InternalMessageInfo
+
+type BuildInfo struct {
+	Version    string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	MainModule string `protobuf:"bytes,2,opt,name=main_module,json=mainModule,proto3" json:"main_module,omitempty"`
+	GoVersion  string `protobuf:"bytes,3,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	// vcs revision the binary was built from
+	Revision     string                 `protobuf:"bytes,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	RevisionTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=revision_time,json=revisionTime,proto3" json:"revision_time,omitempty"`
+	// the working tree had local changes
+	Modified             bool     `protobuf:"varint,6,opt,name=modified,proto3" json:"modified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BuildInfo) Reset()         { *m = BuildInfo{} }
+func (m *BuildInfo) String() string { return proto.CompactTextString(m) }
+func (*BuildInfo) ProtoMessage()    {}
+func (*BuildInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{7}
+}
+func (m *BuildInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuildInfo.Unmarshal(m, b)
+}
+func (m *BuildInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuildInfo.Marshal(b, m, deterministic)
+}
+func (dst *BuildInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuildInfo.Merge(dst, src)
+}
+func (m *BuildInfo) XXX_Size() int {
+	return xxx_messageInfo_BuildInfo.Size(m)
+}
+func (m *BuildInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuildInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuildInfo proto.InternalMessageInfo
+
+func (m *BuildInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *BuildInfo) GetMainModule() string {
+	if m != nil {
+		return m.MainModule
+	}
+	return ""
+}
+
+func (m *BuildInfo) GetGoVersion() string {
+	if m != nil {
+		return m.GoVersion
+	}
+	return ""
+}
+
+func (m *BuildInfo) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *BuildInfo) GetRevisionTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.RevisionTime
+	}
+	return nil
+}
+
+func (m *BuildInfo) GetModified() bool {
+	if m != nil {
+		return m.Modified
+	}
+	return false
+}
+
+type GetConfigRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigRequest) Reset()         { *m = GetConfigRequest{} }
+func (m *GetConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConfigRequest) ProtoMessage()    {}
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{8}
+}
+func (m *GetConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigRequest.Unmarshal(m, b)
+}
+func (m *GetConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigRequest.Merge(dst, src)
+}
+func (m *GetConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConfigRequest.Size(m)
+}
+func (m *GetConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigRequest proto.InternalMessageInfo
+
+type GetConfigResponse struct {
+	// configuration in effect, secrets are replaced by "REDACTED"
+	Config               *structpb.Struct `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetConfigResponse) Reset()         { *m = GetConfigResponse{} }
+func (m *GetConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConfigResponse) ProtoMessage()    {}
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{9}
+}
+func (m *GetConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigResponse.Unmarshal(m, b)
+}
+func (m *GetConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigResponse.Merge(dst, src)
+}
+func (m *GetConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConfigResponse.Size(m)
+}
+func (m *GetConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigResponse proto.InternalMessageInfo
+
+func (m *GetConfigResponse) GetConfig() *structpb.Struct {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type GetLogLevelRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLogLevelRequest) Reset()         { *m = GetLogLevelRequest{} }
+func (m *GetLogLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLogLevelRequest) ProtoMessage()    {}
+func (*GetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{10}
+}
+func (m *GetLogLevelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogLevelRequest.Unmarshal(m, b)
+}
+func (m *GetLogLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogLevelRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetLogLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogLevelRequest.Merge(dst, src)
+}
+func (m *GetLogLevelRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLogLevelRequest.Size(m)
+}
+func (m *GetLogLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogLevelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogLevelRequest proto.InternalMessageInfo
+
+type GetLogLevelResponse struct {
+	Level                LogLevel `protobuf:"varint,1,opt,name=level,proto3,enum=admin.LogLevel" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLogLevelResponse) Reset()         { *m = GetLogLevelResponse{} }
+func (m *GetLogLevelResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLogLevelResponse) ProtoMessage()    {}
+func (*GetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{11}
+}
+func (m *GetLogLevelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogLevelResponse.Unmarshal(m, b)
+}
+func (m *GetLogLevelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogLevelResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetLogLevelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogLevelResponse.Merge(dst, src)
+}
+func (m *GetLogLevelResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLogLevelResponse.Size(m)
+}
+func (m *GetLogLevelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogLevelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogLevelResponse proto.InternalMessageInfo
+
+func (m *GetLogLevelResponse) GetLevel() LogLevel {
+	if m != nil {
+		return m.Level
+	}
+	return LogLevel_LOG_LEVEL_UNSPECIFIED
+}
+
+type SetLogLevelRequest struct {
+	Level                LogLevel `protobuf:"varint,1,opt,name=level,proto3,enum=admin.LogLevel" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelRequest) Reset()         { *m = SetLogLevelRequest{} }
+func (m *SetLogLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelRequest) ProtoMessage()    {}
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{12}
+}
+func (m *SetLogLevelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelRequest.Unmarshal(m, b)
+}
+func (m *SetLogLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetLogLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelRequest.Merge(dst, src)
+}
+func (m *SetLogLevelRequest) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelRequest.Size(m)
+}
+func (m *SetLogLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelRequest proto.InternalMessageInfo
+
+func (m *SetLogLevelRequest) GetLevel() LogLevel {
+	if m != nil {
+		return m.Level
+	}
+	return LogLevel_LOG_LEVEL_UNSPECIFIED
+}
+
+type SetLogLevelResponse struct {
+	PreviousLevel        LogLevel `protobuf:"varint,1,opt,name=previous_level,json=previousLevel,proto3,enum=admin.LogLevel" json:"previous_level,omitempty"`
+	Level                LogLevel `protobuf:"varint,2,opt,name=level,proto3,enum=admin.LogLevel" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelResponse) Reset()         { *m = SetLogLevelResponse{} }
+func (m *SetLogLevelResponse) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelResponse) ProtoMessage()    {}
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{13}
+}
+func (m *SetLogLevelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelResponse.Unmarshal(m, b)
+}
+func (m *SetLogLevelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelResponse.Marshal(b, m, deterministic)
+}
+func (dst *SetLogLevelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelResponse.Merge(dst, src)
+}
+func (m *SetLogLevelResponse) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelResponse.Size(m)
+}
+func (m *SetLogLevelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelResponse proto.InternalMessageInfo
+
+func (m *SetLogLevelResponse) GetPreviousLevel() LogLevel {
+	if m != nil {
+		return m.PreviousLevel
+	}
+	return LogLevel_LOG_LEVEL_UNSPECIFIED
+}
+
+func (m *SetLogLevelResponse) GetLevel() LogLevel {
+	if m != nil {
+		return m.Level
+	}
+	return LogLevel_LOG_LEVEL_UNSPECIFIED
+}
+
+// FaultRule selects calls and the faults injected into them, the first rule
+// matching a call applies
+type FaultRule struct {
+	// full method names, as greet.GreetService/Greet, or greet.GreetService/*
+	// for every method of a service, empty matches every method
+	Methods []string `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	// share of the matching calls affected, 0 means every one
+	Percentage float64 `protobuf:"fixed64,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// metadata the calls must carry, an empty value matches any value
+	Headers map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// added before the call runs
+	Delay *durationpb.Duration `protobuf:"bytes,4,opt,name=delay,proto3" json:"delay,omitempty"`
+	// status the call fails with, by name as UNAVAILABLE, empty for none
+	Code    string `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// share of the messages a stream sends that are discarded
+	DropPercentage float64 `protobuf:"fixed64,7,opt,name=drop_percentage,json=dropPercentage,proto3" json:"drop_percentage,omitempty"`
+	// fails streams once they sent this many messages, or received this many
+	// for client streams, 0 for never
+	AbortAfter int32 `protobuf:"varint,8,opt,name=abort_after,json=abortAfter,proto3" json:"abort_after,omitempty"`
+	// how long the calls failed with RESOURCE_EXHAUSTED are told to wait
+	// before retrying, 1s when unset
+	RetryDelay           *durationpb.Duration `protobuf:"bytes,9,opt,name=retry_delay,json=retryDelay,proto3" json:"retry_delay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *FaultRule) Reset()         { *m = FaultRule{} }
+func (m *FaultRule) String() string { return proto.CompactTextString(m) }
+func (*FaultRule) ProtoMessage()    {}
+func (*FaultRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{14}
+}
+func (m *FaultRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FaultRule.Unmarshal(m, b)
+}
+func (m *FaultRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FaultRule.Marshal(b, m, deterministic)
+}
+func (dst *FaultRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FaultRule.Merge(dst, src)
+}
+func (m *FaultRule) XXX_Size() int {
+	return xxx_messageInfo_FaultRule.Size(m)
+}
+func (m *FaultRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_FaultRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FaultRule proto.InternalMessageInfo
+
+func (m *FaultRule) GetMethods() []string {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+func (m *FaultRule) GetPercentage() float64 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
+}
+
+func (m *FaultRule) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *FaultRule) GetDelay() *durationpb.Duration {
+	if m != nil {
+		return m.Delay
+	}
+	return nil
+}
+
+func (m *FaultRule) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *FaultRule) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *FaultRule) GetDropPercentage() float64 {
+	if m != nil {
+		return m.DropPercentage
+	}
+	return 0
+}
+
+func (m *FaultRule) GetAbortAfter() int32 {
+	if m != nil {
+		return m.AbortAfter
+	}
+	return 0
+}
+
+func (m *FaultRule) GetRetryDelay() *durationpb.Duration {
+	if m != nil {
+		return m.RetryDelay
+	}
+	return nil
+}
+
+type Faults struct {
+	Enabled              bool         `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Rules                []*FaultRule `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Faults) Reset()         { *m = Faults{} }
+func (m *Faults) String() string { return proto.CompactTextString(m) }
+func (*Faults) ProtoMessage()    {}
+func (*Faults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{15}
+}
+func (m *Faults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Faults.Unmarshal(m, b)
+}
+func (m *Faults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Faults.Marshal(b, m, deterministic)
+}
+func (dst *Faults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Faults.Merge(dst, src)
+}
+func (m *Faults) XXX_Size() int {
+	return xxx_messageInfo_Faults.Size(m)
+}
+func (m *Faults) XXX_DiscardUnknown() {
+	xxx_messageInfo_Faults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Faults proto.InternalMessageInfo
+
+func (m *Faults) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *Faults) GetRules() []*FaultRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type GetFaultsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFaultsRequest) Reset()         { *m = GetFaultsRequest{} }
+func (m *GetFaultsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFaultsRequest) ProtoMessage()    {}
+func (*GetFaultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{16}
+}
+func (m *GetFaultsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFaultsRequest.Unmarshal(m, b)
+}
+func (m *GetFaultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFaultsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetFaultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFaultsRequest.Merge(dst, src)
+}
+func (m *GetFaultsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFaultsRequest.Size(m)
+}
+func (m *GetFaultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFaultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFaultsRequest proto.InternalMessageInfo
+
+type SetFaultsRequest struct {
+	Faults               *Faults  `protobuf:"bytes,1,opt,name=faults,proto3" json:"faults,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetFaultsRequest) Reset()         { *m = SetFaultsRequest{} }
+func (m *SetFaultsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetFaultsRequest) ProtoMessage()    {}
+func (*SetFaultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{17}
+}
+func (m *SetFaultsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetFaultsRequest.Unmarshal(m, b)
+}
+func (m *SetFaultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetFaultsRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetFaultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFaultsRequest.Merge(dst, src)
+}
+func (m *SetFaultsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetFaultsRequest.Size(m)
+}
+func (m *SetFaultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFaultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFaultsRequest proto.InternalMessageInfo
+
+func (m *SetFaultsRequest) GetFaults() *Faults {
+	if m != nil {
+		return m.Faults
+	}
+	return nil
+}
+
+type EnableFaultsRequest struct {
+	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnableFaultsRequest) Reset()         { *m = EnableFaultsRequest{} }
+func (m *EnableFaultsRequest) String() string { return proto.CompactTextString(m) }
+func (*EnableFaultsRequest) ProtoMessage()    {}
+func (*EnableFaultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{18}
+}
+func (m *EnableFaultsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnableFaultsRequest.Unmarshal(m, b)
+}
+func (m *EnableFaultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnableFaultsRequest.Marshal(b, m, deterministic)
+}
+func (dst *EnableFaultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnableFaultsRequest.Merge(dst, src)
+}
+func (m *EnableFaultsRequest) XXX_Size() int {
+	return xxx_messageInfo_EnableFaultsRequest.Size(m)
+}
+func (m *EnableFaultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnableFaultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnableFaultsRequest proto.InternalMessageInfo
+
+func (m *EnableFaultsRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type GetCacheStatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCacheStatsRequest) Reset()         { *m = GetCacheStatsRequest{} }
+func (m *GetCacheStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCacheStatsRequest) ProtoMessage()    {}
+func (*GetCacheStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{19}
+}
+func (m *GetCacheStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCacheStatsRequest.Unmarshal(m, b)
+}
+func (m *GetCacheStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCacheStatsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetCacheStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCacheStatsRequest.Merge(dst, src)
+}
+func (m *GetCacheStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCacheStatsRequest.Size(m)
+}
+func (m *GetCacheStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCacheStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCacheStatsRequest proto.InternalMessageInfo
+
+type CacheStats struct {
+	// full method name, e.g. "calculator.CalculatorService/Sum"
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Hits   int64  `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses int64  `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	// results dropped to make room for newer ones
+	Evictions int64 `protobuf:"varint,4,opt,name=evictions,proto3" json:"evictions,omitempty"`
+	// results cached now
+	Entries              int64    `protobuf:"varint,5,opt,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CacheStats) Reset()         { *m = CacheStats{} }
+func (m *CacheStats) String() string { return proto.CompactTextString(m) }
+func (*CacheStats) ProtoMessage()    {}
+func (*CacheStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{20}
+}
+func (m *CacheStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheStats.Unmarshal(m, b)
+}
+func (m *CacheStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheStats.Marshal(b, m, deterministic)
+}
+func (dst *CacheStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheStats.Merge(dst, src)
+}
+func (m *CacheStats) XXX_Size() int {
+	return xxx_messageInfo_CacheStats.Size(m)
+}
+func (m *CacheStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheStats proto.InternalMessageInfo
+
+func (m *CacheStats) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *CacheStats) GetHits() int64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *CacheStats) GetMisses() int64 {
+	if m != nil {
+		return m.Misses
+	}
+	return 0
+}
+
+func (m *CacheStats) GetEvictions() int64 {
+	if m != nil {
+		return m.Evictions
+	}
+	return 0
+}
+
+func (m *CacheStats) GetEntries() int64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+type GetCacheStatsResponse struct {
+	Methods              []*CacheStats `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetCacheStatsResponse) Reset()         { *m = GetCacheStatsResponse{} }
+func (m *GetCacheStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetCacheStatsResponse) ProtoMessage()    {}
+func (*GetCacheStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{21}
+}
+func (m *GetCacheStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCacheStatsResponse.Unmarshal(m, b)
+}
+func (m *GetCacheStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCacheStatsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetCacheStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCacheStatsResponse.Merge(dst, src)
+}
+func (m *GetCacheStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetCacheStatsResponse.Size(m)
+}
+func (m *GetCacheStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCacheStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCacheStatsResponse proto.InternalMessageInfo
+
+func (m *GetCacheStatsResponse) GetMethods() []*CacheStats {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+type PurgeCacheRequest struct {
+	// the method whose results are removed, all of them when empty
+	Method               string   `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeCacheRequest) Reset()         { *m = PurgeCacheRequest{} }
+func (m *PurgeCacheRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeCacheRequest) ProtoMessage()    {}
+func (*PurgeCacheRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{22}
+}
+func (m *PurgeCacheRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeCacheRequest.Unmarshal(m, b)
+}
+func (m *PurgeCacheRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeCacheRequest.Marshal(b, m, deterministic)
+}
+func (dst *PurgeCacheRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeCacheRequest.Merge(dst, src)
+}
+func (m *PurgeCacheRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeCacheRequest.Size(m)
+}
+func (m *PurgeCacheRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeCacheRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeCacheRequest proto.InternalMessageInfo
+
+func (m *PurgeCacheRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+type PurgeCacheResponse struct {
+	Removed              int64    `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeCacheResponse) Reset()         { *m = PurgeCacheResponse{} }
+func (m *PurgeCacheResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeCacheResponse) ProtoMessage()    {}
+func (*PurgeCacheResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_951b600369081846, []int{23}
+}
+func (m *PurgeCacheResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeCacheResponse.Unmarshal(m, b)
+}
+func (m *PurgeCacheResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeCacheResponse.Marshal(b, m, deterministic)
+}
+func (dst *PurgeCacheResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeCacheResponse.Merge(dst, src)
+}
+func (m *PurgeCacheResponse) XXX_Size() int {
+	return xxx_messageInfo_PurgeCacheResponse.Size(m)
+}
+func (m *PurgeCacheResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeCacheResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeCacheResponse proto.InternalMessageInfo
+
+func (m *PurgeCacheResponse) GetRemoved() int64 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ListMethodsRequest)(nil), "admin.ListMethodsRequest")
+	proto.RegisterType((*MethodStats)(nil), "admin.MethodStats")
+	proto.RegisterType((*ListMethodsResponse)(nil), "admin.ListMethodsResponse")
+	proto.RegisterType((*ListPeersRequest)(nil), "admin.ListPeersRequest")
+	proto.RegisterType((*Peer)(nil), "admin.Peer")
+	proto.RegisterType((*ListPeersResponse)(nil), "admin.ListPeersResponse")
+	proto.RegisterType((*GetBuildInfoRequest)(nil), "admin.GetBuildInfoRequest")
+	proto.RegisterType((*BuildInfo)(nil), "admin.BuildInfo")
+	proto.RegisterType((*GetConfigRequest)(nil), "admin.GetConfigRequest")
+	proto.RegisterType((*GetConfigResponse)(nil), "admin.GetConfigResponse")
+	proto.RegisterType((*GetLogLevelRequest)(nil), "admin.GetLogLevelRequest")
+	proto.RegisterType((*GetLogLevelResponse)(nil), "admin.GetLogLevelResponse")
+	proto.RegisterType((*SetLogLevelRequest)(nil), "admin.SetLogLevelRequest")
+	proto.RegisterType((*SetLogLevelResponse)(nil), "admin.SetLogLevelResponse")
+	proto.RegisterType((*FaultRule)(nil), "admin.FaultRule")
+	proto.RegisterMapType((map[string]string)(nil), "admin.FaultRule.HeadersEntry")
+	proto.RegisterType((*Faults)(nil), "admin.Faults")
+	proto.RegisterType((*GetFaultsRequest)(nil), "admin.GetFaultsRequest")
+	proto.RegisterType((*SetFaultsRequest)(nil), "admin.SetFaultsRequest")
+	proto.RegisterType((*EnableFaultsRequest)(nil), "admin.EnableFaultsRequest")
+	proto.RegisterType((*GetCacheStatsRequest)(nil), "admin.GetCacheStatsRequest")
+	proto.RegisterType((*CacheStats)(nil), "admin.CacheStats")
+	proto.RegisterType((*GetCacheStatsResponse)(nil), "admin.GetCacheStatsResponse")
+	proto.RegisterType((*PurgeCacheRequest)(nil), "admin.PurgeCacheRequest")
+	proto.RegisterType((*PurgeCacheResponse)(nil), "admin.PurgeCacheResponse")
+	proto.RegisterEnum("admin.LogLevel", LogLevel_name, LogLevel_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// calls in progress and totals per method
+	ListMethods(ctx context.Context, in *ListMethodsRequest, opts ...grpc.CallOption) (*ListMethodsResponse, error)
+	// connected clients
+	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	GetBuildInfo(ctx context.Context, in *GetBuildInfoRequest, opts ...grpc.CallOption) (*BuildInfo, error)
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	GetLogLevel(ctx context.Context, in *GetLogLevelRequest, opts ...grpc.CallOption) (*GetLogLevelResponse, error)
+	// changes the log level of the running process
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// fault injection rules in effect
+	GetFaults(ctx context.Context, in *GetFaultsRequest, opts ...grpc.CallOption) (*Faults, error)
+	// replaces the fault injection rules, for the calls starting from now
+	SetFaults(ctx context.Context, in *SetFaultsRequest, opts ...grpc.CallOption) (*Faults, error)
+	// turns fault injection on or off, keeping the rules
+	EnableFaults(ctx context.Context, in *EnableFaultsRequest, opts ...grpc.CallOption) (*Faults, error)
+	// hits and misses of the response cache per method
+	GetCacheStats(ctx context.Context, in *GetCacheStatsRequest, opts ...grpc.CallOption) (*GetCacheStatsResponse, error)
+	// removes cached results, e.g. after the data behind them changed
+	PurgeCache(ctx context.Context, in *PurgeCacheRequest, opts ...grpc.CallOption) (*PurgeCacheResponse, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ListMethods(ctx context.Context, in *ListMethodsRequest, opts ...grpc.CallOption) (*ListMethodsResponse, error) {
+	out := new(ListMethodsResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ListMethods", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	out := new(ListPeersResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ListPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetBuildInfo(ctx context.Context, in *GetBuildInfoRequest, opts ...grpc.CallOption) (*BuildInfo, error) {
+	out := new(BuildInfo)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/GetBuildInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLogLevel(ctx context.Context, in *GetLogLevelRequest, opts ...grpc.CallOption) (*GetLogLevelResponse, error) {
+	out := new(GetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/GetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetFaults(ctx context.Context, in *GetFaultsRequest, opts ...grpc.CallOption) (*Faults, error) {
+	out := new(Faults)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/GetFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetFaults(ctx context.Context, in *SetFaultsRequest, opts ...grpc.CallOption) (*Faults, error) {
+	out := new(Faults)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/SetFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) EnableFaults(ctx context.Context, in *EnableFaultsRequest, opts ...grpc.CallOption) (*Faults, error) {
+	out := new(Faults)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/EnableFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetCacheStats(ctx context.Context, in *GetCacheStatsRequest, opts ...grpc.CallOption) (*GetCacheStatsResponse, error) {
+	out := new(GetCacheStatsResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/GetCacheStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PurgeCache(ctx context.Context, in *PurgeCacheRequest, opts ...grpc.CallOption) (*PurgeCacheResponse, error) {
+	out := new(PurgeCacheResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/PurgeCache", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	// calls in progress and totals per method
+	ListMethods(context.Context, *ListMethodsRequest) (*ListMethodsResponse, error)
+	// connected clients
+	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	GetBuildInfo(context.Context, *GetBuildInfoRequest) (*BuildInfo, error)
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	GetLogLevel(context.Context, *GetLogLevelRequest) (*GetLogLevelResponse, error)
+	// changes the log level of the running process
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// fault injection rules in effect
+	GetFaults(context.Context, *GetFaultsRequest) (*Faults, error)
+	// replaces the fault injection rules, for the calls starting from now
+	SetFaults(context.Context, *SetFaultsRequest) (*Faults, error)
+	// turns fault injection on or off, keeping the rules
+	EnableFaults(context.Context, *EnableFaultsRequest) (*Faults, error)
+	// hits and misses of the response cache per method
+	GetCacheStats(context.Context, *GetCacheStatsRequest) (*GetCacheStatsResponse, error)
+	// removes cached results, e.g. after the data behind them changed
+	PurgeCache(context.Context, *PurgeCacheRequest) (*PurgeCacheResponse, error)
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_ListMethods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMethodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListMethods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ListMethods",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListMethods(ctx, req.(*ListMethodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ListPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetBuildInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuildInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetBuildInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/GetBuildInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetBuildInfo(ctx, req.(*GetBuildInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/GetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLogLevel(ctx, req.(*GetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/GetFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetFaults(ctx, req.(*GetFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/SetFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetFaults(ctx, req.(*SetFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_EnableFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).EnableFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/EnableFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).EnableFaults(ctx, req.(*EnableFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCacheStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetCacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/GetCacheStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetCacheStats(ctx, req.(*GetCacheStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PurgeCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PurgeCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/PurgeCache",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PurgeCache(ctx, req.(*PurgeCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMethods",
+			Handler:    _AdminService_ListMethods_Handler,
+		},
+		{
+			MethodName: "ListPeers",
+			Handler:    _AdminService_ListPeers_Handler,
+		},
+		{
+			MethodName: "GetBuildInfo",
+			Handler:    _AdminService_GetBuildInfo_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _AdminService_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetLogLevel",
+			Handler:    _AdminService_GetLogLevel_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "GetFaults",
+			Handler:    _AdminService_GetFaults_Handler,
+		},
+		{
+			MethodName: "SetFaults",
+			Handler:    _AdminService_SetFaults_Handler,
+		},
+		{
+			MethodName: "EnableFaults",
+			Handler:    _AdminService_EnableFaults_Handler,
+		},
+		{
+			MethodName: "GetCacheStats",
+			Handler:    _AdminService_GetCacheStats_Handler,
+		},
+		{
+			MethodName: "PurgeCache",
+			Handler:    _AdminService_PurgeCache_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin/adminpb/admin.proto",
+}
+
+func init() { proto.RegisterFile("admin/adminpb/admin.proto", fileDescriptor_admin_951b600369081846) }
+
+var fileDescriptor_admin_951b600369081846 = []byte{
+	// 1217 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0xfe, 0x69, 0x1d, 0x6c, 0x0d, 0x65, 0x47, 0x5e, 0xe7, 0x20, 0xf3, 0xcf, 0xc1, 0x61, 0x91,
+	0xd6, 0x68, 0x1a, 0x09, 0x70, 0x8a, 0xb4, 0x49, 0xd3, 0x14, 0x8e, 0xa5, 0xb8, 0x0e, 0x14, 0xc7,
+	0x58, 0x35, 0x29, 0xd0, 0x1b, 0x81, 0x22, 0x57, 0x14, 0x51, 0x8a, 0xab, 0x72, 0x97, 0x02, 0xfc,
+	0x06, 0xbd, 0xec, 0x1b, 0xf4, 0x25, 0xfa, 0x24, 0x7d, 0x80, 0x3e, 0x4b, 0xb1, 0x27, 0x8a, 0x3a,
+	0x38, 0xee, 0x8d, 0xcd, 0xf9, 0xe6, 0xdb, 0xd9, 0x99, 0x6f, 0x77, 0x76, 0x04, 0xfb, 0x5e, 0x30,
+	0x89, 0x92, 0xb6, 0xfc, 0x3b, 0x1d, 0xaa, 0xff, 0xad, 0x69, 0x4a, 0x39, 0x45, 0x15, 0x69, 0x38,
+	0xf7, 0x43, 0x4a, 0xc3, 0x98, 0xb4, 0x25, 0x38, 0xcc, 0x46, 0xed, 0x20, 0x4b, 0x3d, 0x1e, 0x51,
+	0x4d, 0x73, 0xee, 0x2e, 0xfb, 0x19, 0x4f, 0x33, 0x9f, 0x6b, 0xef, 0x83, 0x65, 0x2f, 0x8f, 0x26,
+	0x84, 0x71, 0x6f, 0x32, 0x55, 0x04, 0xf7, 0x26, 0xa0, 0x5e, 0xc4, 0xf8, 0x3b, 0xc2, 0xc7, 0x34,
+	0x60, 0x98, 0xfc, 0x96, 0x11, 0xc6, 0xdd, 0x3f, 0x2c, 0xb0, 0x15, 0xd4, 0xe7, 0x1e, 0x67, 0xe8,
+	0x36, 0x54, 0x27, 0xd2, 0x6c, 0x5a, 0x07, 0xd6, 0x61, 0x0d, 0x6b, 0x0b, 0x3d, 0x84, 0xba, 0xe7,
+	0xf3, 0x68, 0x46, 0x06, 0xbe, 0x17, 0xc7, 0xac, 0xb9, 0x71, 0x60, 0x1d, 0x96, 0xb0, 0xad, 0xb0,
+	0x13, 0x01, 0xa1, 0xcf, 0x60, 0x9b, 0x71, 0x2f, 0xe5, 0x24, 0xd0, 0x9c, 0x92, 0xe4, 0xd4, 0x35,
+	0xa8, 0x48, 0x0f, 0xa1, 0x3e, 0xf2, 0xa2, 0x38, 0xe7, 0x94, 0x55, 0x1c, 0x85, 0x49, 0x8a, 0x7b,
+	0x02, 0x7b, 0x0b, 0x89, 0xb2, 0x29, 0x4d, 0x18, 0x41, 0x5f, 0xc1, 0xa6, 0xca, 0x85, 0x35, 0xad,
+	0x83, 0xd2, 0xa1, 0x7d, 0x84, 0x5a, 0x4a, 0xc4, 0x42, 0xfa, 0xd8, 0x50, 0x5c, 0x04, 0x0d, 0x11,
+	0xe4, 0x82, 0x90, 0x34, 0xaf, 0xf5, 0x2f, 0x0b, 0xca, 0x02, 0x40, 0x8f, 0x60, 0x27, 0x25, 0x13,
+	0xca, 0xc9, 0xc0, 0x0b, 0x82, 0x94, 0x30, 0xa6, 0x8b, 0xdd, 0x56, 0xe8, 0xb1, 0x02, 0x45, 0x41,
+	0x31, 0xf5, 0xbd, 0x38, 0x67, 0x6d, 0x48, 0x56, 0x5d, 0x82, 0x86, 0xb4, 0x2c, 0x4c, 0x69, 0x55,
+	0x98, 0xef, 0xa1, 0xee, 0xd3, 0x24, 0x21, 0x3e, 0x1f, 0x88, 0x43, 0x91, 0x35, 0xdb, 0x47, 0x4e,
+	0x4b, 0x9d, 0x58, 0xcb, 0x9c, 0x58, 0xeb, 0x27, 0x73, 0x62, 0xd8, 0xd6, 0x7c, 0x81, 0xb8, 0xcf,
+	0x60, 0xb7, 0x50, 0x8a, 0x56, 0xe3, 0x21, 0x54, 0xa6, 0x02, 0xd0, 0x5a, 0xd8, 0x5a, 0x0b, 0x41,
+	0xc2, 0xca, 0xe3, 0xde, 0x82, 0xbd, 0x53, 0xc2, 0x5f, 0x67, 0x51, 0x1c, 0x9c, 0x25, 0x23, 0x6a,
+	0x54, 0xf8, 0xc7, 0x82, 0x5a, 0x0e, 0xa2, 0x26, 0x6c, 0xce, 0x48, 0xca, 0x22, 0x9a, 0x68, 0x0d,
+	0x8c, 0x89, 0x1e, 0x80, 0x3d, 0xf1, 0xa2, 0x64, 0x30, 0xa1, 0x41, 0x16, 0x13, 0x5d, 0x3b, 0x08,
+	0xe8, 0x9d, 0x44, 0xd0, 0x3d, 0x80, 0x90, 0x0e, 0xcc, 0xea, 0x92, 0xf4, 0xd7, 0x42, 0xfa, 0x51,
+	0xaf, 0x77, 0x60, 0x2b, 0x25, 0xb3, 0x48, 0x3a, 0xcb, 0xd2, 0x99, 0xdb, 0xe8, 0x07, 0xd8, 0x36,
+	0xdf, 0x4a, 0x92, 0xca, 0xb5, 0x92, 0xd4, 0xcd, 0x02, 0x01, 0x89, 0xe0, 0x13, 0x1a, 0x44, 0xa3,
+	0x88, 0x04, 0xcd, 0xea, 0x81, 0x75, 0xb8, 0x85, 0x73, 0x5b, 0x1c, 0xfd, 0x29, 0xe1, 0x27, 0x34,
+	0x19, 0x45, 0xa1, 0x29, 0xba, 0x03, 0xbb, 0x05, 0x4c, 0x6b, 0xd8, 0x86, 0xaa, 0x2f, 0x11, 0x59,
+	0xba, 0x7d, 0x74, 0x67, 0x65, 0xfb, 0xbe, 0xec, 0x30, 0xac, 0x69, 0xa2, 0x85, 0x4e, 0x09, 0xef,
+	0xd1, 0xb0, 0x47, 0x66, 0x24, 0x36, 0xb1, 0x5f, 0xc2, 0xde, 0x02, 0xaa, 0xa3, 0x3f, 0x82, 0x4a,
+	0x2c, 0x00, 0x19, 0x7c, 0xe7, 0xe8, 0x86, 0x3e, 0xa1, 0x9c, 0xa7, 0xbc, 0xee, 0x77, 0x80, 0xfa,
+	0x2b, 0x31, 0xff, 0xeb, 0x62, 0x0e, 0x7b, 0xfd, 0x35, 0x5b, 0x3f, 0x83, 0x9d, 0xa9, 0x90, 0x8b,
+	0x66, 0x6c, 0xf0, 0xc9, 0x30, 0xdb, 0x86, 0x26, 0xcd, 0xf9, 0xae, 0x1b, 0x9f, 0xdc, 0xf5, 0xcf,
+	0x12, 0xd4, 0xde, 0x78, 0x59, 0xcc, 0xb1, 0xb8, 0x06, 0xcd, 0xc5, 0xbe, 0xac, 0xe5, 0x3d, 0x88,
+	0xee, 0x03, 0x4c, 0x49, 0xea, 0x93, 0x84, 0x7b, 0xa1, 0xba, 0x40, 0x16, 0x2e, 0x20, 0xe8, 0x1b,
+	0xd8, 0x1c, 0x13, 0x2f, 0x10, 0xb7, 0xb8, 0x24, 0x6f, 0xf1, 0x3d, 0xbd, 0x61, 0x1e, 0xbc, 0xf5,
+	0xa3, 0xf2, 0x77, 0x13, 0x9e, 0x5e, 0x62, 0xc3, 0x46, 0x6d, 0xa8, 0x04, 0x24, 0xf6, 0x2e, 0x75,
+	0x27, 0xed, 0xaf, 0x9c, 0x5b, 0x47, 0xbf, 0x9c, 0x58, 0xf1, 0x10, 0x82, 0xb2, 0x4f, 0x03, 0x75,
+	0xcd, 0x6a, 0x58, 0x7e, 0xab, 0xbc, 0x19, 0x13, 0xa9, 0x55, 0xd5, 0xcd, 0xd7, 0x26, 0xfa, 0x02,
+	0x6e, 0x04, 0x29, 0x9d, 0x0e, 0x0a, 0xc9, 0x6f, 0xca, 0xe4, 0x77, 0x04, 0x7c, 0x31, 0x2f, 0xe0,
+	0x01, 0xd8, 0xde, 0x90, 0xa6, 0x7c, 0xe0, 0x8d, 0x38, 0x49, 0x9b, 0x5b, 0x07, 0xd6, 0x61, 0x05,
+	0x83, 0x84, 0x8e, 0x05, 0x82, 0x5e, 0x80, 0x9d, 0x12, 0x9e, 0x5e, 0x0e, 0x54, 0xba, 0xb5, 0xeb,
+	0xd2, 0x05, 0xc9, 0xee, 0x08, 0xb2, 0xf3, 0x02, 0xea, 0xc5, 0xea, 0x51, 0x03, 0x4a, 0xbf, 0x92,
+	0x4b, 0xdd, 0xa5, 0xe2, 0x13, 0xdd, 0x84, 0xca, 0xcc, 0x8b, 0x33, 0xd3, 0x9b, 0xca, 0x78, 0xb1,
+	0xf1, 0xad, 0xe5, 0xbe, 0x85, 0xaa, 0xd4, 0x90, 0x89, 0x2a, 0x49, 0xe2, 0x0d, 0x63, 0xa2, 0x1e,
+	0xf4, 0x2d, 0x6c, 0x4c, 0xf4, 0x39, 0x54, 0xd2, 0x2c, 0x26, 0xe2, 0x55, 0x13, 0xda, 0x37, 0x96,
+	0xb5, 0xc7, 0xca, 0xad, 0xdb, 0x49, 0x85, 0x33, 0x57, 0xfe, 0x39, 0x34, 0xfa, 0x4b, 0x18, 0x7a,
+	0x04, 0xd5, 0x91, 0x04, 0x74, 0x37, 0x6d, 0x17, 0x03, 0x32, 0xac, 0x9d, 0x6e, 0x1b, 0xf6, 0xba,
+	0x32, 0x83, 0xc5, 0xd5, 0x57, 0xe6, 0xe9, 0xde, 0x86, 0x9b, 0xa2, 0x75, 0x3d, 0x7f, 0x4c, 0xd4,
+	0x1b, 0xaf, 0x73, 0xf8, 0xdd, 0x02, 0x98, 0xa3, 0x57, 0x0e, 0x2e, 0x04, 0xe5, 0x71, 0xc4, 0xcd,
+	0xc0, 0x92, 0xdf, 0x92, 0x1b, 0x31, 0x46, 0xcc, 0x6b, 0xad, 0x2d, 0x74, 0x17, 0x6a, 0x64, 0x16,
+	0xf9, 0xe2, 0x28, 0xcc, 0x64, 0x9a, 0x03, 0x2a, 0x45, 0x9e, 0x46, 0x84, 0xc9, 0x7b, 0x54, 0xc2,
+	0xc6, 0x74, 0x3b, 0x70, 0x6b, 0x29, 0x45, 0xdd, 0x88, 0x8f, 0x97, 0x67, 0xd6, 0xae, 0x16, 0xa5,
+	0xc0, 0xcd, 0x47, 0xd6, 0x63, 0xd8, 0xbd, 0xc8, 0xd2, 0x90, 0x48, 0x9f, 0xd1, 0xe5, 0x8a, 0xb2,
+	0xdc, 0x16, 0xa0, 0x22, 0x59, 0xef, 0xd7, 0x84, 0x4d, 0x31, 0xc2, 0x66, 0x5a, 0xc5, 0x12, 0x36,
+	0xe6, 0x97, 0x18, 0xb6, 0x4c, 0x1b, 0xa3, 0x7d, 0xb8, 0xd5, 0x7b, 0x7f, 0x3a, 0xe8, 0x75, 0x3f,
+	0x76, 0x7b, 0x83, 0x0f, 0xe7, 0xfd, 0x8b, 0xee, 0xc9, 0xd9, 0x9b, 0xb3, 0x6e, 0xa7, 0xf1, 0x3f,
+	0x54, 0x83, 0x4a, 0xa7, 0xfb, 0xfa, 0xc3, 0x69, 0xc3, 0x42, 0x5b, 0x50, 0x3e, 0x3b, 0x7f, 0xf3,
+	0xbe, 0xb1, 0x81, 0x6c, 0xd8, 0xfc, 0xf9, 0x18, 0x9f, 0x9f, 0x9d, 0x9f, 0x36, 0x4a, 0x82, 0xd1,
+	0xc5, 0xf8, 0x3d, 0x6e, 0x94, 0x8f, 0xfe, 0xae, 0x40, 0xfd, 0x58, 0x94, 0xd3, 0x27, 0xe9, 0x2c,
+	0xf2, 0x09, 0xea, 0x80, 0x5d, 0x98, 0xdc, 0x68, 0xdf, 0xbc, 0x1f, 0x2b, 0x3f, 0x3b, 0x1c, 0x67,
+	0x9d, 0x4b, 0x17, 0xf1, 0x0a, 0x6a, 0xf9, 0xbc, 0x43, 0x77, 0x0a, 0xc4, 0xe2, 0x30, 0x77, 0x9a,
+	0xab, 0x0e, 0xbd, 0xfe, 0x25, 0xd4, 0x8b, 0x73, 0x0f, 0x99, 0xbd, 0xd6, 0x0c, 0x43, 0xc7, 0xdc,
+	0xfa, 0x39, 0xfb, 0x15, 0xd4, 0xf2, 0x49, 0x91, 0xef, 0xbe, 0x3c, 0x4f, 0x9c, 0xe6, 0xaa, 0x43,
+	0xef, 0xde, 0x01, 0xbb, 0x30, 0x0d, 0x72, 0x0d, 0x56, 0xe7, 0x86, 0xe3, 0xac, 0x73, 0xcd, 0xa3,
+	0xf4, 0xd7, 0x44, 0xe9, 0x5f, 0x1d, 0x65, 0xdd, 0x1c, 0x78, 0x2a, 0x6b, 0xd1, 0x2f, 0x41, 0xa1,
+	0x96, 0x85, 0xd6, 0x73, 0x16, 0x1b, 0x55, 0x2c, 0xea, 0xaf, 0x2c, 0xea, 0x5f, 0xb3, 0xe8, 0x39,
+	0xd4, 0x8b, 0x5d, 0x9d, 0x6b, 0xbe, 0xa6, 0xd5, 0x97, 0x97, 0xbe, 0x85, 0xed, 0x85, 0xe6, 0x41,
+	0xff, 0x2f, 0x68, 0xbb, 0xdc, 0xf5, 0xce, 0xdd, 0xf5, 0x4e, 0x5d, 0xf0, 0x31, 0xc0, 0xbc, 0x2b,
+	0x90, 0x39, 0xa4, 0x95, 0xae, 0x72, 0xf6, 0xd7, 0x78, 0x54, 0x88, 0xd7, 0xcf, 0x7e, 0xf9, 0x3a,
+	0x8c, 0xf8, 0x38, 0x1b, 0xb6, 0x7c, 0x3a, 0x69, 0xfb, 0xe3, 0x34, 0x62, 0x3c, 0xf2, 0x92, 0x30,
+	0xf0, 0xda, 0x61, 0x3a, 0xf5, 0x9f, 0x84, 0xf4, 0x89, 0x4f, 0xb3, 0x94, 0x91, 0xf6, 0xc2, 0x0f,
+	0xfa, 0x61, 0x55, 0xbe, 0xe6, 0x4f, 0xff, 0x1d, 0x00, 0x81, 0x42, 0x26, 0x34, 0xe8, 0x0b, 0x00,
+	0x00,
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenAuth requires "authorization: Bearer <token>" on every call
+type tokenAuth struct {
+	token string
+}
+
+func (a tokenAuth) check(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(v, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "a valid admin bearer token is required")
+}
+
+func (a tokenAuth) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.check(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a tokenAuth) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.check(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// TokenCredentials sends token as the admin bearer token, for clients of the
+// admin port. The connection must use TLS, see InsecureTokenCredentials.
+type TokenCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (t TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials
+func (t TokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// InsecureTokenCredentials sends token as TokenCredentials does, also over
+// connections without TLS, for an admin port on a loopback address or a
+// unix socket
+type InsecureTokenCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (t InsecureTokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return TokenCredentials(t).GetRequestMetadata(ctx, uri...)
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials
+func (t InsecureTokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
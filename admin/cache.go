@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/admin/adminpb"
+	"github.com/christiangda/grpc-go-course/logging"
+)
+
+func (s *server) GetCacheStats(ctx context.Context, req *adminpb.GetCacheStatsRequest) (*adminpb.GetCacheStatsResponse, error) {
+	if s.cache == nil {
+		return nil, errNoCache
+	}
+	res := &adminpb.GetCacheStatsResponse{}
+	for _, m := range s.cache.Stats() {
+		res.Methods = append(res.Methods, &adminpb.CacheStats{
+			Method:    m.Method,
+			Hits:      m.Hits,
+			Misses:    m.Misses,
+			Evictions: m.Evictions,
+			Entries:   int64(m.Entries),
+		})
+	}
+	return res, nil
+}
+
+func (s *server) PurgeCache(ctx context.Context, req *adminpb.PurgeCacheRequest) (*adminpb.PurgeCacheResponse, error) {
+	if s.cache == nil {
+		return nil, errNoCache
+	}
+	removed := s.cache.Purge(req.GetMethod())
+	logging.Warningf("Purged %d cached results of %q", removed, req.GetMethod())
+	return &adminpb.PurgeCacheResponse{Removed: int64(removed)}, nil
+}
+
+var errNoCache = status.Error(codes.FailedPrecondition, "the server has no response cache")
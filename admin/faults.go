@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/christiangda/grpc-go-course/admin/adminpb"
+	"github.com/christiangda/grpc-go-course/fault"
+	"github.com/christiangda/grpc-go-course/logging"
+)
+
+func (s *server) GetFaults(ctx context.Context, req *adminpb.GetFaultsRequest) (*adminpb.Faults, error) {
+	if s.faults == nil {
+		return nil, errNoFaults
+	}
+	return faultsToProto(s.faults.Config()), nil
+}
+
+func (s *server) SetFaults(ctx context.Context, req *adminpb.SetFaultsRequest) (*adminpb.Faults, error) {
+	if s.faults == nil {
+		return nil, errNoFaults
+	}
+	if err := s.faults.Set(faultsFromProto(req.GetFaults())); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cfg := s.faults.Config()
+	logging.Warningf("Fault injection rules replaced, %d rules, enabled: %v", len(cfg.Rules), cfg.Enabled)
+	return faultsToProto(cfg), nil
+}
+
+func (s *server) EnableFaults(ctx context.Context, req *adminpb.EnableFaultsRequest) (*adminpb.Faults, error) {
+	if s.faults == nil {
+		return nil, errNoFaults
+	}
+	s.faults.Enable(req.GetEnabled())
+	logging.Warningf("Fault injection enabled: %v", req.GetEnabled())
+	return faultsToProto(s.faults.Config()), nil
+}
+
+var errNoFaults = status.Error(codes.FailedPrecondition, "the server has no fault injection")
+
+func faultsToProto(cfg fault.Config) *adminpb.Faults {
+	res := &adminpb.Faults{Enabled: cfg.Enabled}
+	for _, r := range cfg.Rules {
+		rule := &adminpb.FaultRule{
+			Methods:        r.Methods,
+			Percentage:     r.Percentage,
+			Headers:        r.Headers,
+			Code:           r.Code,
+			Message:        r.Message,
+			DropPercentage: r.DropPercentage,
+			AbortAfter:     int32(r.AbortAfter),
+		}
+		if r.Delay > 0 {
+			rule.Delay = durationpb.New(r.Delay)
+		}
+		if r.RetryDelay > 0 {
+			rule.RetryDelay = durationpb.New(r.RetryDelay)
+		}
+		res.Rules = append(res.Rules, rule)
+	}
+	return res
+}
+
+func faultsFromProto(faults *adminpb.Faults) fault.Config {
+	cfg := fault.Config{Enabled: faults.GetEnabled()}
+	for _, r := range faults.GetRules() {
+		cfg.Rules = append(cfg.Rules, fault.Rule{
+			Methods:        r.GetMethods(),
+			Percentage:     r.GetPercentage(),
+			Headers:        r.GetHeaders(),
+			Delay:          r.GetDelay().AsDuration(),
+			Code:           r.GetCode(),
+			Message:        r.GetMessage(),
+			DropPercentage: r.GetDropPercentage(),
+			AbortAfter:     int(r.GetAbortAfter()),
+			RetryDelay:     r.GetRetryDelay().AsDuration(),
+		})
+	}
+	return cfg
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces the values of secret configuration keys
+const Redacted = "REDACTED"
+
+// secretKeys are the words marking a configuration key as a secret
+var secretKeys = []string{"token", "secret", "password", "credential"}
+
+// redact converts cfg to plain maps and lists through its YAML form, replacing
+// the non empty values of secret keys
+func redact(cfg interface{}) (map[string]interface{}, error) {
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	redactValue(m)
+	return m, nil
+}
+
+func redactValue(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if isSecret(k) && item != nil && item != "" {
+				v[k] = Redacted
+				continue
+			}
+			redactValue(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactValue(item)
+		}
+	}
+}
+
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range secretKeys {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
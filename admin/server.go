@@ -0,0 +1,176 @@
+// Package admin serves the introspection of a running server on a separate,
+// authenticated port: channelz and the AdminService of adminpb.
+package admin
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/christiangda/grpc-go-course/admin/adminpb"
+	"github.com/christiangda/grpc-go-course/cache"
+	"github.com/christiangda/grpc-go-course/fault"
+	"github.com/christiangda/grpc-go-course/logging"
+)
+
+// Version is reported by GetBuildInfo, set it at build time with
+// -ldflags "-X github.com/christiangda/grpc-go-course/admin.Version=v1.0.0"
+var Version = "dev"
+
+// Options configures the admin server
+type Options struct {
+	// Token is the bearer token every call must carry
+	Token string
+	// Tracker counts the calls of the served servers
+	Tracker *Tracker
+	// Config is the configuration in effect, returned with its secrets
+	// redacted
+	Config interface{}
+	// Creds secures the admin port, nil serves it in plain text
+	Creds credentials.TransportCredentials
+	// Faults is the fault injector of the served servers, controlled with
+	// GetFaults, SetFaults and EnableFaults
+	Faults *fault.Injector
+	// Cache is the response cache of the served servers, whose counters
+	// GetCacheStats returns
+	Cache *cache.Cache
+}
+
+// NewServer returns a server with channelz, reflection and the AdminService,
+// all behind the token
+func NewServer(opts Options) (*grpc.Server, error) {
+	if opts.Token == "" {
+		return nil, errors.New("the admin port needs a token")
+	}
+
+	auth := tokenAuth{token: opts.Token}
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(auth.unary),
+		grpc.ChainStreamInterceptor(auth.stream),
+	}
+	if opts.Creds != nil {
+		serverOpts = append(serverOpts, grpc.Creds(opts.Creds))
+	}
+	s := grpc.NewServer(serverOpts...)
+	channelz.RegisterChannelzServiceToServer(s)
+	adminpb.RegisterAdminServiceServer(s, &server{tracker: opts.Tracker, config: opts.Config, faults: opts.Faults, cache: opts.Cache})
+	reflection.Register(s)
+	return s, nil
+}
+
+// Attach returns the admin server of opts, with a new Tracker when it has
+// none, and adds the stats handler feeding the tracker to serverOpts, the
+// options of the gRPC server it reports on
+func Attach(opts Options, serverOpts *[]grpc.ServerOption) (*grpc.Server, error) {
+	if opts.Tracker == nil {
+		opts.Tracker = NewTracker()
+	}
+	s, err := NewServer(opts)
+	if err != nil {
+		return nil, err
+	}
+	*serverOpts = append(*serverOpts, grpc.StatsHandler(opts.Tracker))
+	return s, nil
+}
+
+type server struct {
+	tracker *Tracker
+	config  interface{}
+	faults  *fault.Injector
+	cache   *cache.Cache
+}
+
+func (s *server) ListMethods(ctx context.Context, req *adminpb.ListMethodsRequest) (*adminpb.ListMethodsResponse, error) {
+	res := &adminpb.ListMethodsResponse{}
+	if s.tracker == nil {
+		return res, nil
+	}
+	for _, m := range s.tracker.Methods() {
+		res.Methods = append(res.Methods, &adminpb.MethodStats{
+			Method:       m.Method,
+			ActiveCalls:  m.Active,
+			StartedCalls: m.Started,
+			FailedCalls:  m.Failed,
+		})
+	}
+	return res, nil
+}
+
+func (s *server) ListPeers(ctx context.Context, req *adminpb.ListPeersRequest) (*adminpb.ListPeersResponse, error) {
+	res := &adminpb.ListPeersResponse{}
+	if s.tracker == nil {
+		return res, nil
+	}
+	for _, p := range s.tracker.Peers() {
+		res.Peers = append(res.Peers, &adminpb.Peer{
+			RemoteAddress: p.Remote.String(),
+			LocalAddress:  p.Local.String(),
+			ActiveCalls:   p.Active,
+			ConnectTime:   timestamppb.New(p.Since),
+		})
+	}
+	return res, nil
+}
+
+func (s *server) GetBuildInfo(ctx context.Context, req *adminpb.GetBuildInfoRequest) (*adminpb.BuildInfo, error) {
+	res := &adminpb.BuildInfo{Version: Version}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return res, nil
+	}
+	res.MainModule = info.Main.Path
+	res.GoVersion = info.GoVersion
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			res.Revision = setting.Value
+		case "vcs.time":
+			if t, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+				res.RevisionTime = timestamppb.New(t)
+			}
+		case "vcs.modified":
+			res.Modified = setting.Value == "true"
+		}
+	}
+	return res, nil
+}
+
+func (s *server) GetConfig(ctx context.Context, req *adminpb.GetConfigRequest) (*adminpb.GetConfigResponse, error) {
+	m, err := redact(s.config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed encoding the configuration: %v", err)
+	}
+	cfg, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed encoding the configuration: %v", err)
+	}
+	return &adminpb.GetConfigResponse{Config: cfg}, nil
+}
+
+func (s *server) GetLogLevel(ctx context.Context, req *adminpb.GetLogLevelRequest) (*adminpb.GetLogLevelResponse, error) {
+	return &adminpb.GetLogLevelResponse{Level: adminpb.LogLevel(logging.GetLevel())}, nil
+}
+
+func (s *server) SetLogLevel(ctx context.Context, req *adminpb.SetLogLevelRequest) (*adminpb.SetLogLevelResponse, error) {
+	// the enum values match the logging levels
+	level := logging.Level(req.GetLevel())
+	if level < logging.Debug || level > logging.Error {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown log level %v", req.GetLevel())
+	}
+	previous := logging.SetLevel(level)
+	logging.Warningf("Log level changed from %v to %v", previous, level)
+	return &adminpb.SetLogLevelResponse{
+		PreviousLevel: adminpb.LogLevel(previous),
+		Level:         req.GetLevel(),
+	}, nil
+}
@@ -0,0 +1,159 @@
+package admin
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/stats"
+)
+
+// Tracker counts the calls per method and the connected peers of the servers
+// it is installed on with grpc.StatsHandler
+type Tracker struct {
+	mu      sync.Mutex
+	methods map[string]*methodStats
+	conns   map[*conn]bool
+}
+
+type methodStats struct {
+	active, started, failed int64
+}
+
+type conn struct {
+	remote, local net.Addr
+	since         time.Time
+	active        int64
+}
+
+type connKey struct{}
+
+type rpcTag struct {
+	method *methodStats
+	conn   *conn
+}
+
+type rpcKey struct{}
+
+// NewTracker returns a Tracker without calls
+func NewTracker() *Tracker {
+	return &Tracker{
+		methods: map[string]*methodStats{},
+		conns:   map[*conn]bool{},
+	}
+}
+
+// TagConn implements stats.Handler
+func (t *Tracker) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connKey{}, &conn{remote: info.RemoteAddr, local: info.LocalAddr})
+}
+
+// HandleConn implements stats.Handler
+func (t *Tracker) HandleConn(ctx context.Context, s stats.ConnStats) {
+	c, ok := ctx.Value(connKey{}).(*conn)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch s.(type) {
+	case *stats.ConnBegin:
+		c.since = time.Now()
+		t.conns[c] = true
+	case *stats.ConnEnd:
+		delete(t.conns, c)
+	}
+}
+
+// TagRPC implements stats.Handler
+func (t *Tracker) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	t.mu.Lock()
+	m, ok := t.methods[info.FullMethodName]
+	if !ok {
+		m = &methodStats{}
+		t.methods[info.FullMethodName] = m
+	}
+	t.mu.Unlock()
+
+	// calls served through net/http, as in the web mode, have no conn
+	c, _ := ctx.Value(connKey{}).(*conn)
+	return context.WithValue(ctx, rpcKey{}, &rpcTag{method: m, conn: c})
+}
+
+// HandleRPC implements stats.Handler
+func (t *Tracker) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	tag, ok := ctx.Value(rpcKey{}).(*rpcTag)
+	if !ok {
+		return
+	}
+
+	switch s := s.(type) {
+	case *stats.Begin:
+		atomic.AddInt64(&tag.method.active, 1)
+		atomic.AddInt64(&tag.method.started, 1)
+		if tag.conn != nil {
+			atomic.AddInt64(&tag.conn.active, 1)
+		}
+	case *stats.End:
+		atomic.AddInt64(&tag.method.active, -1)
+		if s.Error != nil {
+			atomic.AddInt64(&tag.method.failed, 1)
+		}
+		if tag.conn != nil {
+			atomic.AddInt64(&tag.conn.active, -1)
+		}
+	}
+}
+
+// MethodSnapshot is the counters of a method at a point in time
+type MethodSnapshot struct {
+	Method                  string
+	Active, Started, Failed int64
+}
+
+// Methods returns the counters of every method called so far, by name
+func (t *Tracker) Methods() []MethodSnapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snaps := make([]MethodSnapshot, 0, len(t.methods))
+	for name, m := range t.methods {
+		snaps = append(snaps, MethodSnapshot{
+			Method:  name,
+			Active:  atomic.LoadInt64(&m.active),
+			Started: atomic.LoadInt64(&m.started),
+			Failed:  atomic.LoadInt64(&m.failed),
+		})
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Method < snaps[j].Method })
+	return snaps
+}
+
+// PeerSnapshot is a connected client at a point in time
+type PeerSnapshot struct {
+	Remote, Local net.Addr
+	Since         time.Time
+	Active        int64
+}
+
+// Peers returns the connected clients, oldest first
+func (t *Tracker) Peers() []PeerSnapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snaps := make([]PeerSnapshot, 0, len(t.conns))
+	for c := range t.conns {
+		snaps = append(snaps, PeerSnapshot{
+			Remote: c.remote,
+			Local:  c.local,
+			Since:  c.since,
+			Active: atomic.LoadInt64(&c.active),
+		})
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Since.Before(snaps[j].Since) })
+	return snaps
+}
@@ -0,0 +1,125 @@
+// Package bridge serves the gRPC-Web and Connect protocols next to native
+// gRPC on the same port, by translating their requests into gRPC requests
+// handled in-process by a *grpc.Server.
+package bridge
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// Options of the bridge handler
+type Options struct {
+	// AllowedOrigins are the origins allowed by CORS, "*" allows any origin.
+	// CORS is disabled when empty.
+	AllowedOrigins []string
+	// AllowedHeaders are request headers allowed by CORS in addition to the
+	// ones used by the gRPC-Web and Connect protocols
+	AllowedHeaders []string
+	// MaxAge is how long browsers may cache the result of a preflight request
+	MaxAge time.Duration
+}
+
+// protocolHeaders are the request headers used by the gRPC-Web and Connect
+// protocols, always allowed by CORS
+var protocolHeaders = []string{
+	"Content-Type", "X-Grpc-Web", "X-User-Agent", "Grpc-Timeout",
+	"Connect-Protocol-Version", "Connect-Timeout-Ms",
+	"Accept-Language", "Authorization", "Idempotency-Key",
+}
+
+// exposedHeaders are the response headers browsers let clients read
+var exposedHeaders = []string{
+	"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin", "Grpc-Encoding",
+	"Idempotency-Replayed",
+}
+
+type handler struct {
+	srv  *grpc.Server
+	opts Options
+}
+
+// Handler returns an http.Handler serving native gRPC over HTTP/2, gRPC-Web
+// and Connect requests with srv
+func Handler(srv *grpc.Server, opts Options) http.Handler {
+	return &handler{srv: srv, opts: opts}
+}
+
+// NewServer returns an *http.Server serving Handler(srv, opts). It accepts
+// cleartext HTTP/2 so native gRPC clients work without TLS too.
+func NewServer(srv *grpc.Server, opts Options, h2 *http2.Server) *http.Server {
+	if h2 == nil {
+		h2 = &http2.Server{}
+	}
+	hs := &http.Server{Handler: h2c.NewHandler(Handler(srv, opts), h2)}
+	http2.ConfigureServer(hs, h2)
+	return hs
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.cors(w, r) {
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "application/grpc-web"):
+		h.serveGRPCWeb(w, r)
+	case strings.HasPrefix(contentType, "application/grpc"):
+		h.srv.ServeHTTP(w, r)
+	case isConnect(contentType):
+		h.serveConnect(w, r)
+	default:
+		http.Error(w, "unsupported content type "+contentType, http.StatusUnsupportedMediaType)
+	}
+}
+
+// cors sets the CORS headers of the response and reports whether r was a
+// preflight request, which needs no further handling
+func (h *handler) cors(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || !h.allowedOrigin(origin) {
+		return false
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Add("Vary", "Origin")
+	w.Header().Set("Access-Control-Expose-Headers", strings.Join(exposedHeaders, ", "))
+
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+		return false
+	}
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(append(protocolHeaders, h.opts.AllowedHeaders...), ", "))
+	if h.opts.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(h.opts.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+func (h *handler) allowedOrigin(origin string) bool {
+	for _, o := range h.opts.AllowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// grpcRequest returns a copy of r as the HTTP/2 gRPC request expected by
+// grpc.Server.ServeHTTP
+func grpcRequest(r *http.Request) *http.Request {
+	req := r.Clone(r.Context())
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2", 2, 0
+	req.Header.Set("Content-Type", "application/grpc+proto")
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	return req
+}
@@ -0,0 +1,299 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/calculator/calculatorservice"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/greetservice"
+)
+
+// newTestServer serves both services through the bridge to an HTTP client
+func newTestServer(t *testing.T, opts Options) *httptest.Server {
+	t.Helper()
+	greetServer, err := greetservice.New(greetservice.DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { greetServer.Close() })
+	calculatorServer, err := calculatorservice.New(calculatorservice.DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := grpc.NewServer()
+	greetpb.RegisterGreetServiceServer(s, greetServer)
+	calculatorpb.RegisterCalculatorServiceServer(s, calculatorServer)
+	hs := httptest.NewServer(Handler(s, opts))
+	t.Cleanup(hs.Close)
+	return hs
+}
+
+func post(t *testing.T, url, contentType string, body []byte) *http.Response {
+	t.Helper()
+	res, err := http.Post(url, contentType, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func marshal(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+	b, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+type testFrame struct {
+	flags byte
+	msg   []byte
+}
+
+// readFrames splits a gRPC-Web or Connect body into its frames
+func readFrames(t *testing.T, body []byte) []testFrame {
+	t.Helper()
+	var frames []testFrame
+	for len(body) > 0 {
+		if len(body) < frameHeaderLen {
+			t.Fatalf("truncated frame header %q", body)
+		}
+		n := int(binary.BigEndian.Uint32(body[1:frameHeaderLen]))
+		if len(body) < frameHeaderLen+n {
+			t.Fatalf("truncated frame of %d bytes", n)
+		}
+		frames = append(frames, testFrame{flags: body[0], msg: body[frameHeaderLen : frameHeaderLen+n]})
+		body = body[frameHeaderLen+n:]
+	}
+	return frames
+}
+
+// webTrailers parses the trailer frame of a gRPC-Web response
+func webTrailers(t *testing.T, f testFrame) map[string]string {
+	t.Helper()
+	if f.flags&grpcWebTrailerFlag == 0 {
+		t.Fatalf("last frame has flags %#x, not the trailers", f.flags)
+	}
+	trailers := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(f.msg)), "\r\n") {
+		k, v, _ := strings.Cut(line, ": ")
+		trailers[k] = v
+	}
+	return trailers
+}
+
+func TestGRPCWebUnary(t *testing.T) {
+	hs := newTestServer(t, Options{})
+	req := &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Ada"}}
+	res := post(t, hs.URL+"/greet.GreetService/Greet", "application/grpc-web+proto", frame(0, marshal(t, req)))
+
+	if ct := res.Header.Get("Content-Type"); ct != "application/grpc-web+proto" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body, _ := io.ReadAll(res.Body)
+	frames := readFrames(t, body)
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, want a message and the trailers", len(frames))
+	}
+	var greeting greetpb.GreetResponse
+	if err := proto.Unmarshal(frames[0].msg, &greeting); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(greeting.GetResult(), "Ada") {
+		t.Errorf("result = %q", greeting.GetResult())
+	}
+	if st := webTrailers(t, frames[1])["grpc-status"]; st != "0" {
+		t.Errorf("grpc-status = %q, want 0", st)
+	}
+}
+
+func TestGRPCWebTextServerStreaming(t *testing.T) {
+	hs := newTestServer(t, Options{})
+	req := &greetpb.GreetManyTimesRequest{Greeting: &greetpb.Greeting{FirstName: "Ada"}, Count: 3, Interval: durationpb.New(time.Millisecond)}
+	body := base64.StdEncoding.EncodeToString(frame(0, marshal(t, req)))
+	res := post(t, hs.URL+"/greet.GreetService/GreetManyTimes", "application/grpc-web-text", []byte(body))
+
+	encoded, _ := io.ReadAll(res.Body)
+	decoded, err := decodeWebText(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames := readFrames(t, decoded)
+	if len(frames) != 4 {
+		t.Fatalf("got %d frames, want 3 greetings and the trailers", len(frames))
+	}
+	for _, f := range frames[:3] {
+		var greeting greetpb.GreetManyTimesResponse
+		if err := proto.Unmarshal(f.msg, &greeting); err != nil {
+			t.Fatal(err)
+		}
+		if greeting.GetResumeToken() == "" {
+			t.Errorf("greeting %q has no resume token", greeting.GetResult())
+		}
+	}
+	if st := webTrailers(t, frames[3])["grpc-status"]; st != "0" {
+		t.Errorf("grpc-status = %q, want 0", st)
+	}
+}
+
+func TestGRPCWebErrorTrailers(t *testing.T) {
+	hs := newTestServer(t, Options{})
+	req := &calculatorpb.SquareRootRequest{Number: -4}
+	res := post(t, hs.URL+"/calculator.CalculatorService/SquareRoot", "application/grpc-web+proto", frame(0, marshal(t, req)))
+
+	body, _ := io.ReadAll(res.Body)
+	frames := readFrames(t, body)
+	if len(frames) != 1 {
+		t.Fatalf("got %d frames, want only the trailers", len(frames))
+	}
+	trailers := webTrailers(t, frames[0])
+	if st := trailers["grpc-status"]; st != "3" {
+		t.Errorf("grpc-status = %q, want 3", st)
+	}
+	if trailers["grpc-status-details-bin"] == "" {
+		t.Error("the trailers have no grpc-status-details-bin")
+	}
+}
+
+func TestConnectUnaryJSON(t *testing.T) {
+	hs := newTestServer(t, Options{})
+	res := post(t, hs.URL+"/greet.GreetService/Greet", "application/json", []byte(`{"greeting":{"firstName":"Ada"}}`))
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %v", res.Status)
+	}
+	var greeting struct{ Result string }
+	if err := json.NewDecoder(res.Body).Decode(&greeting); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(greeting.Result, "Ada") {
+		t.Errorf("result = %q", greeting.Result)
+	}
+}
+
+func TestConnectUnaryError(t *testing.T) {
+	hs := newTestServer(t, Options{})
+	res := post(t, hs.URL+"/calculator.CalculatorService/SquareRoot", "application/json", []byte(`{"number":-4}`))
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %v, want 400", res.Status)
+	}
+	var ce connectError
+	if err := json.NewDecoder(res.Body).Decode(&ce); err != nil {
+		t.Fatal(err)
+	}
+	if ce.Code != "invalid_argument" {
+		t.Errorf("code = %q, want invalid_argument", ce.Code)
+	}
+	found := false
+	for _, d := range ce.Details {
+		found = found || d.Type == "google.rpc.BadRequest"
+	}
+	if !found {
+		t.Errorf("details %v have no google.rpc.BadRequest", ce.Details)
+	}
+}
+
+func TestConnectServerStreaming(t *testing.T) {
+	hs := newTestServer(t, Options{})
+	body := frame(0, []byte(`{"greeting":{"firstName":"Ada"},"count":2,"interval":"0.001s"}`))
+	res := post(t, hs.URL+"/greet.GreetService/GreetManyTimes", "application/connect+json", body)
+
+	b, _ := io.ReadAll(res.Body)
+	frames := readFrames(t, b)
+	if len(frames) != 3 {
+		t.Fatalf("got %d frames, want 2 greetings and the end of the stream", len(frames))
+	}
+	for _, f := range frames[:2] {
+		var greeting struct{ Result string }
+		if err := json.Unmarshal(f.msg, &greeting); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(greeting.Result, "Ada") {
+			t.Errorf("result = %q", greeting.Result)
+		}
+	}
+	end := frames[2]
+	if end.flags&connectEndStreamFlag == 0 {
+		t.Fatalf("last frame has flags %#x, not the end of the stream", end.flags)
+	}
+	var es connectEndStream
+	if err := json.Unmarshal(end.msg, &es); err != nil {
+		t.Fatal(err)
+	}
+	if es.Error != nil {
+		t.Errorf("stream ended with %+v", es.Error)
+	}
+}
+
+func TestConnectStreamingError(t *testing.T) {
+	hs := newTestServer(t, Options{})
+	body := frame(0, []byte(`{"greeting":{"firstName":"Ada"},"count":100000}`))
+	res := post(t, hs.URL+"/greet.GreetService/GreetManyTimes", "application/connect+json", body)
+
+	b, _ := io.ReadAll(res.Body)
+	frames := readFrames(t, b)
+	if len(frames) != 1 {
+		t.Fatalf("got %d frames, want only the end of the stream", len(frames))
+	}
+	var es connectEndStream
+	if err := json.Unmarshal(frames[0].msg, &es); err != nil {
+		t.Fatal(err)
+	}
+	if es.Error == nil || es.Error.Code != "invalid_argument" {
+		t.Errorf("stream ended with %+v, want invalid_argument", es.Error)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	hs := newTestServer(t, Options{AllowedOrigins: []string{"https://app.example.com"}, AllowedHeaders: []string{"X-Request-Id"}})
+
+	preflight := func(origin string) *http.Response {
+		req, _ := http.NewRequest(http.MethodOptions, hs.URL+"/greet.GreetService/Greet", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res
+	}
+
+	res := preflight("https://app.example.com")
+	if res.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %v, want 204", res.Status)
+	}
+	if o := res.Header.Get("Access-Control-Allow-Origin"); o != "https://app.example.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q", o)
+	}
+	allowed := res.Header.Get("Access-Control-Allow-Headers")
+	for _, h := range []string{"X-Grpc-Web", "Connect-Protocol-Version", "Idempotency-Key", "X-Request-Id"} {
+		if !strings.Contains(allowed, h) {
+			t.Errorf("Access-Control-Allow-Headers %q lacks %v", allowed, h)
+		}
+	}
+
+	res = preflight("https://evil.example.com")
+	if o := res.Header.Get("Access-Control-Allow-Origin"); o != "" {
+		t.Errorf("a foreign origin got Access-Control-Allow-Origin %q", o)
+	}
+}
@@ -0,0 +1,366 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// connectEndStreamFlag marks the last message of a Connect stream, carrying
+// its error and trailers as JSON
+const connectEndStreamFlag = 0x02
+
+// connectCodes are the names of the gRPC codes in the Connect protocol
+var connectCodes = map[codes.Code]string{
+	codes.Canceled:           "canceled",
+	codes.Unknown:            "unknown",
+	codes.InvalidArgument:    "invalid_argument",
+	codes.DeadlineExceeded:   "deadline_exceeded",
+	codes.NotFound:           "not_found",
+	codes.AlreadyExists:      "already_exists",
+	codes.PermissionDenied:   "permission_denied",
+	codes.ResourceExhausted:  "resource_exhausted",
+	codes.FailedPrecondition: "failed_precondition",
+	codes.Aborted:            "aborted",
+	codes.OutOfRange:         "out_of_range",
+	codes.Unimplemented:      "unimplemented",
+	codes.Internal:           "internal",
+	codes.Unavailable:        "unavailable",
+	codes.DataLoss:           "data_loss",
+	codes.Unauthenticated:    "unauthenticated",
+}
+
+// connectHTTPStatus are the HTTP statuses of unary Connect errors
+var connectHTTPStatus = map[codes.Code]int{
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+type connectError struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Details []connectDetail `json:"details,omitempty"`
+}
+
+type connectDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type connectEndStream struct {
+	Error    *connectError       `json:"error,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+}
+
+func isConnect(contentType string) bool {
+	for _, prefix := range []string{"application/proto", "application/json", "application/connect+"} {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// serveConnect serves a Connect unary or streaming request, with the proto
+// or JSON codec
+func (h *handler) serveConnect(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	streaming := strings.HasPrefix(contentType, "application/connect+")
+	codec := strings.TrimPrefix(strings.TrimPrefix(contentType, "application/connect+"), "application/")
+	codec = strings.TrimSpace(strings.Split(codec, ";")[0])
+
+	var md protoreflect.MethodDescriptor
+	if codec == "json" {
+		var err error
+		if md, err = methodOf(r.URL.Path); err != nil {
+			writeConnectError(w, status.New(codes.Unimplemented, err.Error()))
+			return
+		}
+	} else if codec != "proto" {
+		writeConnectError(w, status.Newf(codes.Unimplemented, "unsupported codec %q", codec))
+		return
+	}
+
+	encodingHeader := "Content-Encoding"
+	if streaming {
+		encodingHeader = "Connect-Content-Encoding"
+	}
+	if enc := r.Header.Get(encodingHeader); enc != "" && enc != "identity" {
+		writeConnectError(w, status.Newf(codes.Unimplemented, "unsupported compression %q", enc))
+		return
+	}
+
+	req := grpcRequest(r)
+	req.Header.Del("Connect-Protocol-Version")
+	req.Header.Del(encodingHeader)
+	if ms := r.Header.Get("Connect-Timeout-Ms"); ms != "" {
+		req.Header.Del("Connect-Timeout-Ms")
+		if _, err := strconv.ParseUint(ms, 10, 64); err == nil {
+			req.Header.Set("Grpc-Timeout", ms+"m")
+		}
+	}
+
+	if streaming {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(copyConnectRequest(pw, r.Body, md))
+		}()
+		req.Body = pr
+		h.serveConnectStream(w, req, contentType, md)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err == nil && md != nil {
+		body, err = fromJSON(md.Input(), body)
+	}
+	if err != nil {
+		writeConnectError(w, status.New(codes.InvalidArgument, err.Error()))
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(frame(0, body)))
+	h.serveConnectUnary(w, req, contentType, md)
+}
+
+func (h *handler) serveConnectUnary(w http.ResponseWriter, req *http.Request, contentType string, md protoreflect.MethodDescriptor) {
+	var header http.Header
+	var body []byte
+	gw := newGRPCWriter(
+		func(h http.Header) { header = h },
+		func(_ byte, msg []byte) { body = append(body, msg...) },
+	)
+	h.srv.ServeHTTP(gw, req)
+
+	trailers := gw.trailers()
+	if st := statusOf(trailers); st.Code() != codes.OK {
+		copyHeaders(w.Header(), header)
+		writeConnectError(w, st)
+		return
+	}
+
+	if md != nil {
+		var err error
+		if body, err = toJSON(md.Output(), body); err != nil {
+			writeConnectError(w, status.New(codes.Internal, err.Error()))
+			return
+		}
+	}
+	copyHeaders(w.Header(), header)
+	for k, vv := range trailers {
+		if !isGRPCStatusHeader(k) {
+			w.Header()["Trailer-"+k] = vv
+		}
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (h *handler) serveConnectStream(w http.ResponseWriter, req *http.Request, contentType string, md protoreflect.MethodDescriptor) {
+	flusher, _ := w.(http.Flusher)
+	write := func(b []byte) {
+		w.Write(b)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	failed := false
+	gw := newGRPCWriter(
+		func(header http.Header) {
+			copyHeaders(w.Header(), header)
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusOK)
+		},
+		func(flags byte, msg []byte) {
+			if failed {
+				return
+			}
+			if md != nil {
+				var err error
+				if msg, err = toJSON(md.Output(), msg); err != nil {
+					failed = true
+					b, _ := json.Marshal(connectEndStream{Error: toConnectError(status.New(codes.Internal, err.Error()))})
+					write(frame(connectEndStreamFlag, b))
+					return
+				}
+			}
+			write(frame(0, msg))
+		},
+	)
+	h.srv.ServeHTTP(gw, req)
+	if failed {
+		return
+	}
+
+	gw.WriteHeader(http.StatusOK)
+	end := connectEndStream{Metadata: map[string][]string{}}
+	trailers := gw.trailers()
+	if st := statusOf(trailers); st.Code() != codes.OK {
+		end.Error = toConnectError(st)
+	}
+	for k, vv := range trailers {
+		if !isGRPCStatusHeader(k) {
+			end.Metadata[k] = vv
+		}
+	}
+	b, _ := json.Marshal(end)
+	write(frame(connectEndStreamFlag, b))
+}
+
+// copyConnectRequest copies the messages of a Connect stream to a gRPC
+// stream, converting them from JSON when md is set
+func copyConnectRequest(dst io.Writer, src io.Reader, md protoreflect.MethodDescriptor) error {
+	prefix := make([]byte, frameHeaderLen)
+	for {
+		if _, err := io.ReadFull(src, prefix); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		msg := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+		if _, err := io.ReadFull(src, msg); err != nil {
+			return err
+		}
+		if prefix[0]&connectEndStreamFlag != 0 {
+			return nil
+		}
+		if md != nil {
+			var err error
+			if msg, err = fromJSON(md.Input(), msg); err != nil {
+				return err
+			}
+		}
+		if _, err := dst.Write(frame(0, msg)); err != nil {
+			return err
+		}
+	}
+}
+
+// methodOf returns the descriptor of the method of a "/pkg.Service/Method" path
+func methodOf(path string) (protoreflect.MethodDescriptor, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed method name %q", path)
+	}
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(parts[0]))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service %v", parts[0])
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("unknown service %v", parts[0])
+	}
+	md := sd.Methods().ByName(protoreflect.Name(parts[1]))
+	if md == nil {
+		return nil, fmt.Errorf("unknown method %v", parts[1])
+	}
+	return md, nil
+}
+
+func fromJSON(desc protoreflect.MessageDescriptor, b []byte) ([]byte, error) {
+	msg := dynamicpb.NewMessage(desc)
+	if err := protojson.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+func toJSON(desc protoreflect.MessageDescriptor, b []byte) ([]byte, error) {
+	msg := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(msg)
+}
+
+func isGRPCStatusHeader(k string) bool {
+	return k == "Grpc-Status" || k == "Grpc-Message" || k == "Grpc-Status-Details-Bin"
+}
+
+// statusOf returns the status carried by the trailers of a gRPC response
+func statusOf(trailers http.Header) *status.Status {
+	if bin := trailers.Get("Grpc-Status-Details-Bin"); bin != "" {
+		if b, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(bin, "=")); err == nil {
+			st := &spb.Status{}
+			if proto.Unmarshal(b, st) == nil {
+				return status.FromProto(st)
+			}
+		}
+	}
+	code, err := strconv.Atoi(trailers.Get("Grpc-Status"))
+	if err != nil {
+		return status.New(codes.Unknown, "missing grpc-status")
+	}
+	return status.New(codes.Code(code), decodeGRPCMessage(trailers.Get("Grpc-Message")))
+}
+
+// decodeGRPCMessage undoes the percent-encoding of grpc-message
+func decodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		if msg[i] == '%' && i+2 < len(msg) {
+			if n, err := strconv.ParseUint(msg[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(msg[i])
+	}
+	return b.String()
+}
+
+func toConnectError(st *status.Status) *connectError {
+	ce := &connectError{Code: connectCodes[st.Code()], Message: st.Message()}
+	if ce.Code == "" {
+		ce.Code = connectCodes[codes.Unknown]
+	}
+	for _, d := range st.Proto().GetDetails() {
+		ce.Details = append(ce.Details, connectDetail{
+			Type:  strings.TrimPrefix(d.GetTypeUrl(), "type.googleapis.com/"),
+			Value: base64.RawStdEncoding.EncodeToString(d.GetValue()),
+		})
+	}
+	return ce
+}
+
+func writeConnectError(w http.ResponseWriter, st *status.Status) {
+	code, ok := connectHTTPStatus[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	b, _ := json.Marshal(toConnectError(st))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(b)
+}
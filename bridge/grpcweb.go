@@ -0,0 +1,102 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// grpcWebTrailerFlag marks the frame carrying the trailers of a gRPC-Web
+// response
+const grpcWebTrailerFlag = 0x80
+
+// serveGRPCWeb serves a gRPC-Web request, binary or base64 encoded text
+func (h *handler) serveGRPCWeb(w http.ResponseWriter, r *http.Request) {
+	text := strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc-web-text")
+	contentType := "application/grpc-web+proto"
+	if text {
+		contentType = "application/grpc-web-text+proto"
+	}
+
+	req := grpcRequest(r)
+	if text {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		decoded, err := decodeWebText(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Body = io.NopCloser(bytes.NewReader(decoded))
+	}
+
+	write := func(b []byte) {
+		if text {
+			b = []byte(base64.StdEncoding.EncodeToString(b))
+		}
+		w.Write(b)
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	gw := newGRPCWriter(
+		func(header http.Header) {
+			copyHeaders(w.Header(), header)
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusOK)
+		},
+		func(flags byte, msg []byte) {
+			write(frame(flags, msg))
+		},
+	)
+	h.srv.ServeHTTP(gw, req)
+
+	// the trailers travel in the body, browsers can't read HTTP trailers
+	gw.WriteHeader(http.StatusOK)
+	var trailers bytes.Buffer
+	for k, vv := range gw.trailers() {
+		for _, v := range vv {
+			fmt.Fprintf(&trailers, "%v: %v\r\n", strings.ToLower(k), v)
+		}
+	}
+	write(frame(grpcWebTrailerFlag, trailers.Bytes()))
+}
+
+// decodeWebText decodes a gRPC-Web text body. Each message is encoded on its
+// own, so padding may appear in the middle of the body.
+func decodeWebText(body []byte) ([]byte, error) {
+	body = bytes.Join(bytes.Fields(body), nil)
+	if len(body)%4 != 0 {
+		return nil, fmt.Errorf("invalid grpc-web-text body length %v", len(body))
+	}
+
+	decoded := make([]byte, 0, base64.StdEncoding.DecodedLen(len(body)))
+	chunk := make([]byte, 3)
+	for i := 0; i < len(body); i += 4 {
+		n, err := base64.StdEncoding.Decode(chunk, body[i:i+4])
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, chunk[:n]...)
+	}
+	return decoded, nil
+}
+
+// copyHeaders copies the headers of a gRPC response to a response of
+// another protocol, leaving out the ones tied to the gRPC framing
+func copyHeaders(dst, src http.Header) {
+	for k, vv := range src {
+		switch k {
+		case "Content-Type", "Content-Length", "Trailer":
+			continue
+		}
+		dst[k] = vv
+	}
+}
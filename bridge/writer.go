@@ -0,0 +1,94 @@
+package bridge
+
+import (
+	"encoding/binary"
+	"net/http"
+	"strings"
+)
+
+// frameHeaderLen is the length of the flags and length prefix of a message
+// in gRPC, gRPC-Web and Connect streams
+const frameHeaderLen = 5
+
+// grpcWriter is the http.ResponseWriter given to grpc.Server.ServeHTTP. It
+// splits the gRPC response into headers, messages and trailers so they can
+// be written in another protocol.
+type grpcWriter struct {
+	header  http.Header
+	sent    http.Header
+	buf     []byte
+	onStart func(header http.Header)
+	onFrame func(flags byte, msg []byte)
+}
+
+func newGRPCWriter(onStart func(http.Header), onFrame func(byte, []byte)) *grpcWriter {
+	return &grpcWriter{
+		header:  http.Header{},
+		onStart: onStart,
+		onFrame: onFrame,
+	}
+}
+
+func (gw *grpcWriter) Header() http.Header {
+	return gw.header
+}
+
+func (gw *grpcWriter) WriteHeader(int) {
+	if gw.sent != nil {
+		return
+	}
+	gw.sent = gw.header.Clone()
+	delete(gw.sent, "Trailer")
+	if gw.onStart != nil {
+		gw.onStart(gw.sent)
+	}
+}
+
+func (gw *grpcWriter) Write(p []byte) (int, error) {
+	gw.WriteHeader(http.StatusOK)
+
+	gw.buf = append(gw.buf, p...)
+	for len(gw.buf) >= frameHeaderLen {
+		n := int(binary.BigEndian.Uint32(gw.buf[1:frameHeaderLen]))
+		if len(gw.buf) < frameHeaderLen+n {
+			break
+		}
+		flags, msg := gw.buf[0], gw.buf[frameHeaderLen:frameHeaderLen+n]
+		gw.onFrame(flags, msg)
+		gw.buf = gw.buf[frameHeaderLen+n:]
+	}
+	return len(p), nil
+}
+
+func (gw *grpcWriter) Flush() {
+	gw.WriteHeader(http.StatusOK)
+}
+
+// started reports whether the headers were sent before the trailers
+func (gw *grpcWriter) started() bool {
+	return gw.sent != nil
+}
+
+// trailers returns the headers set after the response headers were sent,
+// which is where grpc.Server.ServeHTTP puts the trailers
+func (gw *grpcWriter) trailers() http.Header {
+	trailers := http.Header{}
+	for k, vv := range gw.header {
+		if _, ok := gw.sent[k]; ok || k == "Trailer" || len(vv) == 0 {
+			continue
+		}
+		if strings.HasPrefix(k, http.TrailerPrefix) {
+			k = http.CanonicalHeaderKey(strings.TrimPrefix(k, http.TrailerPrefix))
+		}
+		trailers[k] = vv
+	}
+	return trailers
+}
+
+func frame(flags byte, msg []byte) []byte {
+	b := make([]byte, frameHeaderLen+len(msg))
+	b[0] = flags
+	binary.BigEndian.PutUint32(b[1:frameHeaderLen], uint32(len(msg)))
+	copy(b[frameHeaderLen:], msg)
+	return b
+}
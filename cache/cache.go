@@ -0,0 +1,381 @@
+// Package cache keeps the responses of deterministic RPCs, so repeated
+// requests are answered without running their handler again. Unary responses
+// and the whole result of server streams are kept in an LRU, for a TTL.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Deterministic are the methods whose responses only depend on their request
+// and the VaryHeaders, the only ones cached. The greeting history RPCs change
+// with every greeting, the Greet calls answered from the cache are not
+// recorded in it.
+var Deterministic = []string{
+	"calculator.CalculatorService/Sum",
+	"calculator.CalculatorService/SquareRoot",
+	"calculator.CalculatorService/PrimeNumberDecomposition",
+	"calculator.CalculatorService/Batch",
+	"greet.GreetService/Greet",
+}
+
+// Config selects the methods cached, caching is off without any
+type Config struct {
+	// Methods are full method names of Deterministic, as
+	// calculator.CalculatorService/Sum, or calculator.CalculatorService/*
+	// for every method of a service in Deterministic
+	Methods []string `yaml:"methods"`
+	// MaxEntries is the number of results kept, the least recently used
+	// one is evicted past it
+	MaxEntries int `yaml:"max_entries"`
+	// TTL is how long a result is served from the cache
+	TTL time.Duration `yaml:"ttl"`
+	// VaryHeaders are metadata keys whose values are part of the cache key,
+	// for the responses depending on them as the locale of Greet
+	VaryHeaders []string `yaml:"vary_headers"`
+	// MaxStreamMessages is the longest server stream cached, 0 caches none
+	MaxStreamMessages int `yaml:"max_stream_messages"`
+}
+
+// DefaultConfig caches no method
+var DefaultConfig = Config{
+	MaxEntries:        10000,
+	TTL:               5 * time.Minute,
+	VaryHeaders:       []string{"accept-language"},
+	MaxStreamMessages: 1000,
+}
+
+// RegisterFlags binds the fields of c to command line flags
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Func("cache", "comma separated methods whose responses are cached, as calculator.CalculatorService/Sum or calculator.CalculatorService/* for all the deterministic ones", func(s string) error {
+		c.Methods = splitList(s)
+		return c.Validate()
+	})
+	fs.IntVar(&c.MaxEntries, "cache-max-entries", c.MaxEntries, "responses kept in the cache")
+	fs.DurationVar(&c.TTL, "cache-ttl", c.TTL, "how long a response is served from the cache")
+	fs.Func("cache-vary-headers", "comma separated metadata keys whose values are part of the cache key, empty for none (default "+strings.Join(c.VaryHeaders, ",")+")", func(s string) error {
+		c.VaryHeaders = splitList(s)
+		return nil
+	})
+	fs.IntVar(&c.MaxStreamMessages, "cache-max-stream-messages", c.MaxStreamMessages, "longest server stream cached, 0 to cache none")
+}
+
+// splitList splits a comma separated flag value, dropping the empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate checks the fields of c
+func (c Config) Validate() error {
+	for _, m := range c.Methods {
+		if strings.Count(strings.TrimPrefix(m, "/"), "/") != 1 {
+			return fmt.Errorf("cached method %q is not package.Service/Method", m)
+		}
+		if len(expand(m)) == 0 {
+			return fmt.Errorf("cached method %q is not deterministic, the ones cached are %s", m, strings.Join(Deterministic, ", "))
+		}
+	}
+	if len(c.Methods) == 0 {
+		return nil
+	}
+	switch {
+	case c.MaxEntries < 1:
+		return fmt.Errorf("cache max_entries %d is not positive", c.MaxEntries)
+	case c.TTL <= 0:
+		return fmt.Errorf("cache ttl %v is not positive", c.TTL)
+	case c.MaxStreamMessages < 0:
+		return fmt.Errorf("negative cache max_stream_messages %d", c.MaxStreamMessages)
+	}
+	return nil
+}
+
+// expand returns the methods of Deterministic matched by pattern
+func expand(pattern string) []string {
+	pattern = strings.TrimPrefix(pattern, "/")
+	service, all := strings.CutSuffix(pattern, "/*")
+	var methods []string
+	for _, m := range Deterministic {
+		if m == pattern || all && strings.HasPrefix(m, service+"/") {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// MethodStats are the cache counters of a method
+type MethodStats struct {
+	Method    string
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Entries   int
+}
+
+// Cache serves the cached responses of the configured methods
+type Cache struct {
+	cfg     Config
+	vary    []string
+	methods map[string]bool
+
+	mu    sync.Mutex
+	lru   *lru
+	stats map[string]*MethodStats
+	now   func() time.Time
+}
+
+// New returns a cache applying cfg
+func New(cfg Config) (*Cache, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	c := &Cache{
+		cfg:     cfg,
+		lru:     newLRU(cfg.MaxEntries),
+		stats:   map[string]*MethodStats{},
+		methods: map[string]bool{},
+		now:     time.Now,
+	}
+	for _, pattern := range cfg.Methods {
+		for _, m := range expand(pattern) {
+			c.methods[m] = true
+		}
+	}
+	for _, k := range cfg.VaryHeaders {
+		c.vary = append(c.vary, strings.ToLower(k))
+	}
+	sort.Strings(c.vary)
+	return c, nil
+}
+
+// caches tells whether the responses of method, without its leading slash,
+// are cached
+func (c *Cache) caches(method string) bool {
+	return c.methods[method]
+}
+
+// key returns the cache key of req, false when req is not a message
+func (c *Cache) key(ctx context.Context, method string, req interface{}) (key, bool) {
+	m, ok := req.(protov1.Message)
+	if !ok {
+		return key{}, false
+	}
+	// deterministic encoding orders the map entries, so equal requests
+	// have equal bytes
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(protov1.MessageV2(m))
+	if err != nil {
+		return key{}, false
+	}
+
+	h := sha256.New()
+	io.WriteString(h, method)
+	h.Write([]byte{0})
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, k := range c.vary {
+		io.WriteString(h, k)
+		for _, v := range md.Get(k) {
+			h.Write([]byte{0})
+			io.WriteString(h, v)
+		}
+		h.Write([]byte{0, 0})
+	}
+	h.Write(b)
+
+	var k key
+	copy(k[:], h.Sum(nil))
+	return k, true
+}
+
+func (c *Cache) statsOf(method string) *MethodStats {
+	s, ok := c.stats[method]
+	if !ok {
+		s = &MethodStats{Method: method}
+		c.stats[method] = s
+	}
+	return s
+}
+
+// get returns the messages cached under k, counting a hit or a miss
+func (c *Cache) get(k key, method string) ([]interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.lru.get(k, c.now())
+	if !ok {
+		c.statsOf(method).Misses++
+		return nil, false
+	}
+	c.statsOf(method).Hits++
+	return e.messages, true
+}
+
+func (c *Cache) add(k key, method string, messages []interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := &entry{key: k, method: method, messages: messages, expires: c.now().Add(c.cfg.TTL)}
+	if evicted := c.lru.add(e); evicted != nil {
+		c.statsOf(evicted.method).Evictions++
+	}
+}
+
+// Stats returns the counters of every method called, sorted by method
+func (c *Cache) Stats() []MethodStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := c.lru.count()
+	stats := make([]MethodStats, 0, len(c.stats))
+	for method, s := range c.stats {
+		s := *s
+		s.Entries = counts[method]
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Method < stats[j].Method })
+	return stats
+}
+
+// Purge removes the cached responses of method, or of every method when it
+// is empty, and returns how many were removed
+func (c *Cache) Purge(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.purge(strings.TrimPrefix(method, "/"))
+}
+
+// clone copies the message m, so the cached one is not shared with the
+// interceptors and handlers of a call
+func clone(m interface{}) (interface{}, bool) {
+	msg, ok := m.(protov1.Message)
+	if !ok {
+		return nil, false
+	}
+	return protov1.Clone(msg), true
+}
+
+// UnaryServerInterceptor answers the calls of the cached methods from the
+// cache, only successful responses are cached
+func (c *Cache) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := strings.TrimPrefix(info.FullMethod, "/")
+		if !c.caches(method) {
+			return handler(ctx, req)
+		}
+		k, ok := c.key(ctx, method, req)
+		if !ok {
+			return handler(ctx, req)
+		}
+		if messages, ok := c.get(k, method); ok {
+			res, _ := clone(messages[0])
+			return res, nil
+		}
+
+		res, err := handler(ctx, req)
+		if err != nil {
+			return res, err
+		}
+		if cached, ok := clone(res); ok {
+			c.add(k, method, []interface{}{cached})
+		}
+		return res, err
+	}
+}
+
+// errHit stops the handler of a server stream answered from the cache, the
+// generated handlers return it from the RecvMsg of the request before
+// running the method
+var errHit = status.Error(codes.Aborted, "answered from the cache")
+
+// StreamServerInterceptor replays the messages of the server streams of the
+// cached methods, the streams are cached once they end successfully
+func (c *Cache) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		method := strings.TrimPrefix(info.FullMethod, "/")
+		if info.IsClientStream || !c.caches(method) {
+			return handler(srv, ss)
+		}
+
+		cs := &cachingStream{ServerStream: ss, cache: c, method: method}
+		err := handler(srv, cs)
+		if cs.hit != nil {
+			if !errors.Is(err, errHit) {
+				return err
+			}
+			for _, m := range cs.hit {
+				if err := ss.SendMsg(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err == nil && cs.keyed && !cs.uncacheable {
+			c.add(cs.key, method, cs.sent)
+		}
+		return err
+	}
+}
+
+// cachingStream looks the request of a server stream up in the cache and
+// records the messages sent
+type cachingStream struct {
+	grpc.ServerStream
+	cache  *Cache
+	method string
+
+	received    bool
+	keyed       bool
+	key         key
+	hit         []interface{}
+	sent        []interface{}
+	uncacheable bool
+}
+
+func (s *cachingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.received {
+		return nil
+	}
+	s.received = true
+	s.key, s.keyed = s.cache.key(s.Context(), s.method, m)
+	if !s.keyed {
+		return nil
+	}
+	if messages, ok := s.cache.get(s.key, s.method); ok {
+		s.hit = messages
+		return errHit
+	}
+	return nil
+}
+
+func (s *cachingStream) SendMsg(m interface{}) error {
+	if s.keyed && !s.uncacheable {
+		if len(s.sent) >= s.cache.cfg.MaxStreamMessages {
+			s.uncacheable, s.sent = true, nil
+		} else if cached, ok := clone(m); ok {
+			s.sent = append(s.sent, cached)
+		} else {
+			s.uncacheable = true
+		}
+	}
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,368 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+)
+
+const (
+	sumMethod   = "calculator.CalculatorService/Sum"
+	primeMethod = "calculator.CalculatorService/PrimeNumberDecomposition"
+)
+
+// testCache is a cache whose clock the tests move
+type testCache struct {
+	*Cache
+	now time.Time
+}
+
+func newCache(t *testing.T, cfg Config) *testCache {
+	t.Helper()
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := &testCache{Cache: c, now: time.Unix(0, 0)}
+	c.now = func() time.Time { return tc.now }
+	return tc
+}
+
+func config(methods ...string) Config {
+	cfg := DefaultConfig
+	cfg.Methods = methods
+	return cfg
+}
+
+// sumHandler counts its calls and answers the sum of the request, or err
+type sumHandler struct {
+	calls atomic.Int64
+	err   error
+}
+
+func (h *sumHandler) handle(ctx context.Context, req interface{}) (interface{}, error) {
+	n := h.calls.Add(1)
+	if h.err != nil {
+		return nil, h.err
+	}
+	r := req.(*calculatorpb.SumRequest)
+	// the number of the call tells the runs apart
+	return &calculatorpb.SumResponse{SumResult: r.GetFirstNumber() + r.GetSecondNumber() + int32(n)*1000}, nil
+}
+
+func sum(c *testCache, ctx context.Context, h *sumHandler, a, b int32) (int32, error) {
+	res, err := c.UnaryServerInterceptor()(ctx, &calculatorpb.SumRequest{FirstNumber: a, SecondNumber: b}, &grpc.UnaryServerInfo{FullMethod: "/" + sumMethod}, h.handle)
+	if err != nil {
+		return 0, err
+	}
+	return res.(*calculatorpb.SumResponse).GetSumResult(), nil
+}
+
+// runs checks the times h ran
+func runs(t *testing.T, h *sumHandler, want int64) {
+	t.Helper()
+	if n := h.calls.Load(); n != want {
+		t.Errorf("the handler ran %d times, want %d", n, want)
+	}
+}
+
+func TestRegisterFlags(t *testing.T) {
+	cfg := DefaultConfig
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.RegisterFlags(fs)
+	err := fs.Parse([]string{
+		"-cache", "calculator.CalculatorService/*, greet.GreetService/Greet",
+		"-cache-vary-headers", "accept-language,x-tenant",
+		"-cache-max-stream-messages", "10",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Config{
+		Methods:           []string{"calculator.CalculatorService/*", "greet.GreetService/Greet"},
+		MaxEntries:        DefaultConfig.MaxEntries,
+		TTL:               DefaultConfig.TTL,
+		VaryHeaders:       []string{"accept-language", "x-tenant"},
+		MaxStreamMessages: 10,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+
+	// an empty list varies on no header
+	if err := fs.Parse([]string{"-cache-vary-headers", ""}); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.VaryHeaders) != 0 {
+		t.Errorf("got vary headers %q, want none", cfg.VaryHeaders)
+	}
+}
+
+func TestMethodsExpanded(t *testing.T) {
+	tests := []struct {
+		pattern string
+		cached  []string
+	}{
+		{"calculator.CalculatorService/*", []string{
+			"calculator.CalculatorService/Sum",
+			"calculator.CalculatorService/SquareRoot",
+			"calculator.CalculatorService/PrimeNumberDecomposition",
+			"calculator.CalculatorService/Batch",
+		}},
+		{"greet.GreetService/*", []string{"greet.GreetService/Greet"}},
+		{"/calculator.CalculatorService/Sum", []string{"calculator.CalculatorService/Sum"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			c := newCache(t, config(tt.pattern))
+			var cached []string
+			for _, m := range append(Deterministic, "calculator.CalculatorService/FindMaximum", "greet.GreetService/GreetHistory") {
+				if c.caches(m) {
+					cached = append(cached, m)
+				}
+			}
+			if !reflect.DeepEqual(cached, tt.cached) {
+				t.Errorf("caches %q, want %q", cached, tt.cached)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"not a method", config("Sum")},
+		{"not deterministic", config("calculator.CalculatorService/FindMaximum")},
+		{"unknown service", config("unknown.Service/*")},
+		{"no entries", Config{Methods: []string{sumMethod}, TTL: time.Minute}},
+		{"no ttl", Config{Methods: []string{sumMethod}, MaxEntries: 1}},
+		{"negative stream messages", Config{Methods: []string{sumMethod}, MaxEntries: 1, TTL: time.Minute, MaxStreamMessages: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); err == nil {
+				t.Errorf("%+v is valid", tt.cfg)
+			}
+		})
+	}
+	if err := (Config{}).Validate(); err != nil {
+		t.Errorf("the config caching nothing is invalid: %v", err)
+	}
+}
+
+func TestHitsAndMisses(t *testing.T) {
+	c := newCache(t, config(sumMethod))
+	var h sumHandler
+	ctx := context.Background()
+
+	first, err := sum(c, ctx, &h, 3, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if got, err := sum(c, ctx, &h, 3, 10); err != nil || got != first {
+			t.Errorf("hit %d got %v, %v, want %v", i, got, err, first)
+		}
+	}
+	if _, err := sum(c, ctx, &h, 4, 10); err != nil {
+		t.Fatal(err)
+	}
+	runs(t, &h, 2)
+
+	// errors are not cached
+	h.err = status.Error(codes.Internal, "failed")
+	for i := 0; i < 2; i++ {
+		if _, err := sum(c, ctx, &h, 5, 10); status.Code(err) != codes.Internal {
+			t.Errorf("got %v, want Internal", err)
+		}
+	}
+	runs(t, &h, 4)
+
+	want := []MethodStats{{Method: sumMethod, Hits: 2, Misses: 4, Entries: 2}}
+	if got := c.Stats(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+	if n := c.Purge("/" + sumMethod); n != 2 {
+		t.Errorf("purged %d entries, want 2", n)
+	}
+	if got := c.Stats(); got[0].Entries != 0 {
+		t.Errorf("%d entries left after Purge", got[0].Entries)
+	}
+}
+
+func TestVaryHeaders(t *testing.T) {
+	c := newCache(t, config(sumMethod))
+	var h sumHandler
+	tests := []struct {
+		name string
+		md   []string
+		hit  bool
+	}{
+		{"first", []string{"accept-language", "en"}, false},
+		{"same language", []string{"accept-language", "en"}, true},
+		{"other language", []string{"accept-language", "fr"}, false},
+		{"no language", nil, false},
+		{"other header", []string{"accept-language", "en", "x-request-id", "1"}, true},
+	}
+	for _, tt := range tests {
+		before := h.calls.Load()
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tt.md...))
+		if _, err := sum(c, ctx, &h, 3, 10); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if hit := h.calls.Load() == before; hit != tt.hit {
+			t.Errorf("%s: hit %v, want %v", tt.name, hit, tt.hit)
+		}
+	}
+}
+
+func TestLeastRecentlyUsedEvicted(t *testing.T) {
+	cfg := config(sumMethod)
+	cfg.MaxEntries = 2
+	c := newCache(t, cfg)
+	var h sumHandler
+	ctx := context.Background()
+
+	sum(c, ctx, &h, 1, 0)
+	sum(c, ctx, &h, 2, 0)
+	// 1 is used again, so 2 is the one evicted for 3
+	sum(c, ctx, &h, 1, 0)
+	sum(c, ctx, &h, 3, 0)
+	runs(t, &h, 3)
+
+	sum(c, ctx, &h, 1, 0)
+	sum(c, ctx, &h, 3, 0)
+	runs(t, &h, 3)
+	sum(c, ctx, &h, 2, 0)
+	runs(t, &h, 4)
+
+	want := []MethodStats{{Method: sumMethod, Hits: 3, Misses: 4, Evictions: 2, Entries: 2}}
+	if got := c.Stats(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestEntriesExpire(t *testing.T) {
+	cfg := config(sumMethod)
+	cfg.TTL = time.Minute
+	c := newCache(t, cfg)
+	var h sumHandler
+	ctx := context.Background()
+
+	sum(c, ctx, &h, 3, 10)
+	c.now = c.now.Add(time.Minute)
+	sum(c, ctx, &h, 3, 10)
+	runs(t, &h, 1)
+	c.now = c.now.Add(time.Second)
+	sum(c, ctx, &h, 3, 10)
+	runs(t, &h, 2)
+}
+
+// primeStream serves a PrimeNumberDecomposition request and records the
+// messages sent
+type primeStream struct {
+	grpc.ServerStream
+	req      *calculatorpb.PrimeNumberDecompositionRequest
+	received bool
+	sent     []int64
+}
+
+func (s *primeStream) Context() context.Context { return context.Background() }
+
+func (s *primeStream) RecvMsg(m interface{}) error {
+	if s.received {
+		return errors.New("the request was received")
+	}
+	s.received = true
+	protov1.Merge(m.(protov1.Message), s.req)
+	return nil
+}
+
+func (s *primeStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m.(*calculatorpb.PrimeNumberDecompositionResponse).GetPrimeFactor())
+	return nil
+}
+
+// primeHandler counts its calls and sends the prime factors of the request,
+// as the generated handler and the service do
+type primeHandler struct {
+	calls int
+}
+
+func (h *primeHandler) handle(srv interface{}, stream grpc.ServerStream) error {
+	req := new(calculatorpb.PrimeNumberDecompositionRequest)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	h.calls++
+	n := req.GetNumber()
+	for k := int64(2); n > 1; {
+		if n%k != 0 {
+			k++
+			continue
+		}
+		if err := stream.SendMsg(&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: k}); err != nil {
+			return err
+		}
+		n /= k
+	}
+	return nil
+}
+
+func decompose(c *testCache, h *primeHandler, number int64) ([]int64, error) {
+	ss := &primeStream{req: &calculatorpb.PrimeNumberDecompositionRequest{Number: number}}
+	info := &grpc.StreamServerInfo{FullMethod: "/" + primeMethod, IsServerStream: true}
+	err := c.StreamServerInterceptor()(nil, ss, info, h.handle)
+	return ss.sent, err
+}
+
+func TestStreamReplayed(t *testing.T) {
+	c := newCache(t, config(primeMethod))
+	var h primeHandler
+
+	want := []int64{2, 2, 2, 3, 5}
+	for i := 0; i < 3; i++ {
+		factors, err := decompose(c, &h, 120)
+		if err != nil || !reflect.DeepEqual(factors, want) {
+			t.Errorf("stream %d got %v, %v, want %v", i, factors, err, want)
+		}
+	}
+	if h.calls != 1 {
+		t.Errorf("the handler ran %d times, want once", h.calls)
+	}
+	wantStats := []MethodStats{{Method: primeMethod, Hits: 2, Misses: 1, Entries: 1}}
+	if got := c.Stats(); !reflect.DeepEqual(got, wantStats) {
+		t.Errorf("got stats %+v, want %+v", got, wantStats)
+	}
+}
+
+func TestLongStreamsNotCached(t *testing.T) {
+	cfg := config(primeMethod)
+	cfg.MaxStreamMessages = 3
+	c := newCache(t, cfg)
+	var h primeHandler
+
+	for _, number := range []int64{30, 30, 120, 120} {
+		if _, err := decompose(c, &h, number); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 30 has 3 factors and is cached, 120 has 5
+	if h.calls != 3 {
+		t.Errorf("the handler ran %d times, want 3", h.calls)
+	}
+}
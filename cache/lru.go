@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"container/list"
+	"time"
+)
+
+// key identifies a request, the hash of its method, vary headers and bytes
+type key [32]byte
+
+// entry is a cached result, the response of a unary call or the messages of
+// a server stream
+type entry struct {
+	key      key
+	method   string
+	messages []interface{}
+	expires  time.Time
+}
+
+// lru holds at most size entries, evicting the least recently used one. It
+// is not safe for concurrent use.
+type lru struct {
+	size  int
+	order *list.List // front is the most recently used
+	items map[key]*list.Element
+}
+
+func newLRU(size int) *lru {
+	return &lru{size: size, order: list.New(), items: map[key]*list.Element{}}
+}
+
+// get returns the entry of k unless it expired at now
+func (c *lru) get(k key, now time.Time) (*entry, bool) {
+	el, ok := c.items[k]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if now.After(e.expires) {
+		c.order.Remove(el)
+		delete(c.items, k)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e, true
+}
+
+// add stores e and returns the entry evicted to make room for it, if any
+func (c *lru) add(e *entry) *entry {
+	if el, ok := c.items[e.key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return nil
+	}
+	c.items[e.key] = c.order.PushFront(e)
+	if c.order.Len() <= c.size {
+		return nil
+	}
+	oldest := c.order.Back()
+	c.order.Remove(oldest)
+	evicted := oldest.Value.(*entry)
+	delete(c.items, evicted.key)
+	return evicted
+}
+
+// purge removes every entry, or those of method when it is not empty, and
+// returns how many were removed
+func (c *lru) purge(method string) int {
+	n := 0
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if e := el.Value.(*entry); method == "" || e.method == method {
+			c.order.Remove(el)
+			delete(c.items, e.key)
+			n++
+		}
+		el = next
+	}
+	return n
+}
+
+// count returns the number of entries of each method
+func (c *lru) count() map[string]int {
+	counts := map[string]int{}
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		counts[el.Value.(*entry).method]++
+	}
+	return counts
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -10,13 +11,34 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/compression"
+	"github.com/christiangda/grpc-go-course/loadbalancing"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+	"github.com/christiangda/grpc-go-course/streaming"
+	"github.com/christiangda/grpc-go-course/transport"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 )
 
 func main() {
+	addr := flag.String("addr", "localhost:50051", "server addresses separated by commas, dns:///name, unix:///path or file:///path of a targets file")
+	lb := loadbalancing.DefaultConfig
+	lb.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
 	fmt.Println("Calculator Client")
-	cc, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	compress := compression.Config{Algorithms: []string{compression.Zstd, compression.Gzip}, MinSize: 1024}
+	dialOpts := append(compress.DialOptions(), grpc.WithInsecure())
+	// dead servers are noticed during long FindMaximum streams
+	dialOpts = append(dialOpts, streaming.DefaultClientKeepalive.DialOptions()...)
+	lbOpts, err := lb.DialOptions()
+	if err != nil {
+		log.Fatalf("Invalid balancing: %v", err)
+	}
+	dialOpts = append(dialOpts, lbOpts...)
+	dialOpts = append(dialOpts, transport.DialOptions(*addr)...)
+	cc, err := grpc.Dial(loadbalancing.Target(*addr), dialOpts...)
 	if err != nil {
 		log.Fatalf("Could not connect to: %v", err)
 	}
@@ -27,11 +49,16 @@ func main() {
 	//doUnary(c)
 
 	//doServerStreaming(c)
+	//doResumableServerStreaming(c)
 
 	//doClientStreaming(c)
 
 	//doBiDiStreaming(c)
 
+	//doRunningAggregate(c)
+
+	//doBatch(c)
+
 	doErrorUnary(c)
 }
 
@@ -69,6 +96,25 @@ func doServerStreaming(c calculatorpb.CalculatorServiceClient) {
 	}
 }
 
+func doResumableServerStreaming(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do a resumable PrimeDecomposition Server Streaming RPC...")
+	req := &calculatorpb.PrimeNumberDecompositionRequest{
+		Number: 999999000001,
+	}
+	// if the connection drops the stream is reopened from the last factor received
+	open := func(ctx context.Context, token string) (resume.Stream[*calculatorpb.PrimeNumberDecompositionResponse], error) {
+		req.ResumeToken = token
+		return c.PrimeNumberDecomposition(ctx, req)
+	}
+	err := resume.Receive(context.Background(), resume.DefaultBackoff, open, func(res *calculatorpb.PrimeNumberDecompositionResponse) error {
+		log.Printf("Response from PrimeDecomposition: %v", res.GetPrimeFactor())
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Someting is happened: %v", err)
+	}
+}
+
 func doClientStreaming(c calculatorpb.CalculatorServiceClient) {
 	fmt.Println("Starting to do a ComputeAverage Client Streaming RPC...")
 
@@ -104,15 +150,19 @@ func doBiDiStreaming(c calculatorpb.CalculatorServiceClient) {
 
 	waitc := make(chan struct{})
 
-	// send go routine
+	// send go routine, Send blocks while the server is not keeping up so
+	// there is no need to pace the numbers
 	go func() {
-		numbers := []int32{4, 1, 34, 5, 68, 44, 45, 70, 23}
+		numbers := []int32{-4, -1, 34, 5, 68, 44, 45, 70, 23}
 		for _, number := range numbers {
 			fmt.Printf("Sending number: %v\n", number)
-			stream.Send(&calculatorpb.FindMaximumRequest{
+			err := stream.Send(&calculatorpb.FindMaximumRequest{
 				Number: number,
 			})
-			time.Sleep(1000 * time.Millisecond)
+			if err != nil {
+				log.Printf("Error while sending number: %v\n", err)
+				break
+			}
 		}
 		stream.CloseSend()
 	}()
@@ -136,6 +186,77 @@ func doBiDiStreaming(c calculatorpb.CalculatorServiceClient) {
 	<-waitc
 }
 
+func doRunningAggregate(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do a RunningAggregate BiDi Streaming RPC...")
+
+	stream, err := c.RunningAggregate(context.Background())
+	if err != nil {
+		log.Fatalf("Error while opening stream and call RunningAggregate: %v", err)
+	}
+
+	waitc := make(chan struct{})
+
+	// send go routine
+	go func() {
+		config := &calculatorpb.AggregateConfig{
+			Aggregate:  calculatorpb.AggregateConfig_MEAN,
+			WindowSize: 3,
+		}
+		numbers := []float64{-4, -1, 34, 5, 68, 44, 45, 70, 23}
+		for _, number := range numbers {
+			fmt.Printf("Sending number: %v\n", number)
+			stream.Send(&calculatorpb.RunningAggregateRequest{
+				Config: config,
+				Number: number,
+			})
+			time.Sleep(1000 * time.Millisecond)
+		}
+		stream.CloseSend()
+	}()
+
+	// receive go routine
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("Error while reading sever stream: %v\n", err)
+				break
+			}
+			fmt.Printf("Received new aggregate of...: %v (window of %v)\n", res.GetValue(), res.GetCount())
+		}
+		close(waitc)
+	}()
+	<-waitc
+}
+
+func doBatch(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do a Batch unary RPC...")
+	req := &calculatorpb.BatchRequest{
+		Operations: []*calculatorpb.BatchOperation{
+			{Operation: &calculatorpb.BatchOperation_Sum{Sum: &calculatorpb.SumRequest{FirstNumber: 3, SecondNumber: 4}}},
+			{Operation: &calculatorpb.BatchOperation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: 16}}},
+			{Operation: &calculatorpb.BatchOperation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: -16}}},
+		},
+	}
+	res, err := c.Batch(context.Background(), req)
+	if err != nil {
+		log.Fatalf("error while calling Batch RPC: %v", err)
+	}
+	for i, result := range res.GetResults() {
+		switch {
+		case result.GetSum() != nil:
+			fmt.Printf("Operation %v: sum is %v\n", i, result.GetSum().GetSumResult())
+		case result.GetSquareRoot() != nil:
+			fmt.Printf("Operation %v: square root is %v\n", i, result.GetSquareRoot().GetNumberRoot())
+		case result.GetError() != nil:
+			fmt.Printf("Operation %v failed: %v\n", i, rpcerror.Describe(status.FromProto(result.GetError()).Err()))
+		}
+	}
+}
+
 func doErrorUnary(c calculatorpb.CalculatorServiceClient) {
 	fmt.Println("Starting to do a Sum unary RPC...")
 
@@ -153,10 +274,11 @@ func doErrorCall(c calculatorpb.CalculatorServiceClient, n int32) {
 	if err != nil {
 		respErr, ok := status.FromError(err)
 		if ok {
-			fmt.Printf("Error message from server: %v\n", respErr.Message())
-			fmt.Println(respErr.Code())
+			fmt.Printf("Error from server: %v\n", rpcerror.Describe(err))
 			if respErr.Code() == codes.InvalidArgument {
-				fmt.Println("You probably sent a negative number!")
+				for _, v := range rpcerror.Violations(err) {
+					fmt.Printf("Fix the %v field of the request: %v\n", v.GetField(), v.GetDescription())
+				}
 				return
 			}
 		} else {
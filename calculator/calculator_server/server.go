@@ -1,129 +1,157 @@
 package main
 
 import (
-	"context"
+	"flag"
 	"fmt"
-	"io"
 	"log"
-	"math"
 	"net"
+	"os"
 
-	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/christiangda/grpc-go-course/admin"
+	"github.com/christiangda/grpc-go-course/bridge"
+	"github.com/christiangda/grpc-go-course/cache"
 	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/calculator/calculatorservice"
+	"github.com/christiangda/grpc-go-course/compression"
+	"github.com/christiangda/grpc-go-course/fault"
+	"github.com/christiangda/grpc-go-course/idempotency"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/streaming"
+	"github.com/christiangda/grpc-go-course/transport"
+	"github.com/christiangda/grpc-go-course/validate"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/status"
 )
 
-type server struct{}
-
-func (s *server) Sum(ctx context.Context, req *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
-	fmt.Printf("Received Sum RPC: %v", req)
-	firstNumber := req.FirstNumber
-	secondNumber := req.SecondNumber
-	sum := firstNumber + secondNumber
-	res := &calculatorpb.SumResponse{
-		SumResult: sum,
+func main() {
+	cfg := calculatorservice.DefaultConfig
+	flag.IntVar(&cfg.BatchParallelism, "batch-parallelism", cfg.BatchParallelism, "max operations of a Batch RPC running at once")
+	flag.IntVar(&cfg.MaxBatchSize, "max-batch-size", cfg.MaxBatchSize, "max operations in a Batch RPC, 0 means unbounded")
+	flag.IntVar(&cfg.Pipeline.Workers, "stream-workers", cfg.Pipeline.Workers, "messages of a FindMaximum stream processed at once")
+	flag.IntVar(&cfg.Pipeline.Depth, "stream-depth", cfg.Pipeline.Depth, "messages of a FindMaximum stream received ahead of the responses sent")
+	var flowControl streaming.FlowControl
+	flowControl.RegisterFlags(flag.CommandLine)
+	keepalive := streaming.DefaultKeepalive
+	keepalive.RegisterFlags(flag.CommandLine)
+	var compress compression.Config
+	compress.RegisterFlags(flag.CommandLine)
+	cacheConfig := cache.DefaultConfig
+	cacheConfig.RegisterFlags(flag.CommandLine)
+	idempotencyConfig := idempotency.DefaultConfig
+	idempotencyConfig.RegisterFlags(flag.CommandLine)
+	web := flag.Bool("web", false, "also serve the gRPC-Web and Connect protocols on the gRPC port")
+	corsOrigins := flag.String("cors-origins", "", "comma separated origins allowed to call the server from a browser, * for any")
+	listen := flag.String("listen", "0.0.0.0:50051", "comma separated addresses served: host:port, unix:///path or unix:relative/path")
+	socketMode := transport.DefaultSocketMode
+	transport.SocketModeFlag(flag.CommandLine, &socketMode)
+	reflectionOn := flag.Bool("reflection", true, "serve the gRPC reflection service, v1 and v1alpha")
+	faultsFile := flag.String("faults", "", "YAML file of fault injection rules, off unless it sets enabled: true")
+	adminListen := flag.String("admin-listen", "", "address of channelz and the AdminService, authenticated with the ADMIN_TOKEN environment variable, empty for none")
+	adminCert := flag.String("admin-cert", "", "certificate of the admin port, required with -admin-listen")
+	adminKey := flag.String("admin-key", "", "private key of the admin port, required with -admin-listen")
+	logLevel := logging.Info
+	flag.TextVar(&logLevel, "log-level", logLevel, "minimum level of the messages logged: debug, info, warning or error")
+	flag.Parse()
+	logging.SetLevel(logLevel)
+	grpclog.SetLoggerV2(logging.GRPCLogger{})
+	// the servers sharing the key resume the streams of each other, and
+	// their own streams across restarts
+	if key := os.Getenv("RESUME_KEY"); key != "" {
+		resume.SetKey([]byte(key))
 	}
-	return res, nil
-}
 
-func (s *server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
-	fmt.Printf("Received PrimeNumberDecomposition RPC: %v\n", req)
-	number := req.GetNumber()
-	divisor := int64(2)
-
-	for number > 1 {
-		if number%divisor == 2 {
-			stream.Send(&calculatorpb.PrimeNumberDecompositionResponse{
-				PrimeFactor: divisor,
-			})
-			number = number / divisor
-		} else {
-			divisor++
-			fmt.Printf("Divisor has increased to %v\n", divisor)
-		}
+	fmt.Println("Calculator Server")
+
+	calculatorServer, err := calculatorservice.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed creating the calculator service: %v", err)
 	}
-	return nil
-}
 
-func (s *server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
-	fmt.Printf("Received ComputeAverage RPC\n")
+	listeners, err := transport.ListenAll(transport.SplitList(*listen), socketMode)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
 
-	sum := int32(0)
-	count := 0
+	faults, err := fault.LoadFile(*faultsFile)
+	if err != nil {
+		log.Fatalf("Failed loading the fault injection rules: %v", err)
+	}
 
-	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			average := float64(sum) / float64(count)
-			return stream.SendAndClose(&calculatorpb.ComputeAverageResponse{
-				Average: average,
-			})
-		}
-		if err != nil {
-			log.Fatalf("Error while reading client streaming: %v\n", err)
-		}
-		sum += req.GetNumber()
-		count++
+	responseCache, err := cache.New(cacheConfig)
+	if err != nil {
+		log.Fatalf("Failed creating the response cache: %v", err)
 	}
-}
 
-func (s *server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServer) error {
-	fmt.Printf("Received Findmaximun RPC\n")
-	maximum := int32(0)
+	idempotent, err := idempotency.New(idempotencyConfig)
+	if err != nil {
+		log.Fatalf("Failed creating the idempotency store: %v", err)
+	}
 
-	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			return nil
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(validate.UnaryServerInterceptor(), faults.UnaryServerInterceptor(), idempotent.UnaryServerInterceptor(), responseCache.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(validate.StreamServerInterceptor(), faults.StreamServerInterceptor(), responseCache.StreamServerInterceptor()),
+	}
+	opts = append(opts, flowControl.ServerOptions()...)
+	opts = append(opts, keepalive.ServerOptions()...)
+	opts = append(opts, compress.ServerOptions()...)
+	var adminServer *grpc.Server
+	if *adminListen != "" {
+		// the gRPC port has no TLS, but the bearer token of the admin port
+		// is only sent over it
+		if *adminCert == "" || *adminKey == "" {
+			log.Fatalf("The admin port is only served with TLS, set -admin-cert and -admin-key")
 		}
-		if err != nil {
-			log.Fatalf("Error while reading client stream: %v\n", err)
+		creds, sslErr := credentials.NewServerTLSFromFile(*adminCert, *adminKey)
+		if sslErr != nil {
+			log.Fatalf("Failed loading the admin certificates: %v", sslErr)
 		}
-		number := req.GetNumber()
-		if number > maximum {
-			maximum = number
-			sendErr := stream.Send(&calculatorpb.FindMaximumResponse{
-				Maximum: float64(maximum),
-			})
-			if sendErr != nil {
-				log.Fatalf("Error while sending data to client: %v\n", sendErr)
-			}
+		adminServer, err = admin.Attach(admin.Options{
+			Token:  os.Getenv("ADMIN_TOKEN"),
+			Config: cfg,
+			Creds:  creds,
+			Faults: faults,
+			Cache:  responseCache,
+		}, &opts)
+		if err != nil {
+			log.Fatalf("Failed creating the admin server: %v", err)
 		}
 	}
-}
+	s := grpc.NewServer(opts...)
+	calculatorpb.RegisterCalculatorServiceServer(s, calculatorServer)
 
-func (s *server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
-	fmt.Printf("Received SquareRoot RPC\n")
-	number := req.GetNumber()
+	// clients balancing over several servers skip the ones not serving
+	healthpb.RegisterHealthServer(s, health.NewServer())
 
-	if number < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "Received a negative number: %v", number)
+	if *reflectionOn {
+		reflection.Register(s)
 	}
 
-	return &calculatorpb.SquareRootResponse{
-		NumberRoot: math.Sqrt(float64(number)),
-	}, nil
-
-}
-
-func main() {
-	fmt.Println("Calculator Server")
-
-	lis, err := net.Listen("tcp", "0.0.0.0:50051")
-	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+	serve := s.Serve
+	if *web {
+		hs := bridge.NewServer(s, bridge.Options{AllowedOrigins: transport.SplitList(*corsOrigins)}, keepalive.ApplyHTTP2(flowControl.HTTP2Server()))
+		serve = hs.Serve
 	}
 
-	s := grpc.NewServer()
-	calculatorpb.RegisterCalculatorServiceServer(s, &server{})
-
-	reflection.Register(s)
-
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	// every listener is served until one fails
+	errc := make(chan error, len(listeners)+1)
+	for _, lis := range listeners {
+		log.Printf("Serving on %v", transport.Addr(lis))
+		go func(lis net.Listener) { errc <- serve(lis) }(lis)
+	}
+	if adminServer != nil {
+		adminLis, err := transport.Listen(*adminListen, socketMode)
+		if err != nil {
+			log.Fatalf("Failed to listen on the admin port: %v", err)
+		}
+		log.Printf("Serving admin on %v", transport.Addr(adminLis))
+		go func() { errc <- adminServer.Serve(adminLis) }()
 	}
+	log.Fatalf("failed to serve: %v", <-errc)
 }
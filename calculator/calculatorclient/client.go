@@ -0,0 +1,184 @@
+// Package calculatorclient calls the calculator service with plain Go types:
+// streams are iterators and channels, errors are *rpcerror.Error.
+//
+//	c, err := calculatorclient.Dial("localhost:50051", client.WithRetry(resume.DefaultBackoff))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	for factor, err := range c.PrimeFactors(ctx, 120) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(factor)
+//	}
+//
+//	m, err := c.RunningMax(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	go func() {
+//		for _, n := range []int32{1, 5, 3, 6} {
+//			m.Send(n)
+//		}
+//		m.Close()
+//	}()
+//	for max := range m.Out() {
+//		fmt.Println("maximum is now", max)
+//	}
+//	return m.Err()
+package calculatorclient
+
+import (
+	"context"
+	"iter"
+
+	"google.golang.org/grpc"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/client"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// Client calls the calculator service
+type Client struct {
+	c       calculatorpb.CalculatorServiceClient
+	backoff resume.Backoff
+	close   func() error
+}
+
+// Dial connects to the calculator service at target, see client.Dial
+func Dial(target string, opts ...client.Option) (*Client, error) {
+	cc, err := client.Dial(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{c: calculatorpb.NewCalculatorServiceClient(cc.ClientConn), backoff: cc.Backoff, close: cc.Close}, nil
+}
+
+// New calls the calculator service over cc, which the caller closes
+func New(cc *grpc.ClientConn) *Client {
+	return &Client{c: calculatorpb.NewCalculatorServiceClient(cc), backoff: resume.DefaultBackoff, close: func() error { return nil }}
+}
+
+// Close closes the connection opened by Dial
+func (c *Client) Close() error {
+	return c.close()
+}
+
+// Sum returns a + b
+func (c *Client) Sum(ctx context.Context, a, b int32) (int32, error) {
+	res, err := c.c.Sum(ctx, &calculatorpb.SumRequest{FirstNumber: a, SecondNumber: b})
+	if err != nil {
+		return 0, rpcerror.FromError(err)
+	}
+	return res.GetSumResult(), nil
+}
+
+// SquareRoot returns the square root of n, negative numbers fail with
+// rpcerror.ErrInvalidArgument
+func (c *Client) SquareRoot(ctx context.Context, n int32) (float64, error) {
+	res, err := c.c.SquareRoot(ctx, &calculatorpb.SquareRootRequest{Number: n})
+	if err != nil {
+		return 0, rpcerror.FromError(err)
+	}
+	return res.GetNumberRoot(), nil
+}
+
+// PrimeFactors yields the prime factors of n in increasing order, then the
+// error ending the stream, if any. Broken streams are resumed.
+func (c *Client) PrimeFactors(ctx context.Context, n int64) iter.Seq2[int64, error] {
+	req := &calculatorpb.PrimeNumberDecompositionRequest{Number: n}
+	open := func(ctx context.Context, token string) (resume.Stream[*calculatorpb.PrimeNumberDecompositionResponse], error) {
+		req.ResumeToken = token
+		return c.c.PrimeNumberDecomposition(ctx, req)
+	}
+	return func(yield func(int64, error) bool) {
+		for res, err := range client.Resume(ctx, c.backoff, open) {
+			if !yield(res.GetPrimeFactor(), err) {
+				return
+			}
+		}
+	}
+}
+
+// Average returns the average of numbers, sent in one stream
+func (c *Client) Average(ctx context.Context, numbers iter.Seq[int32]) (float64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.c.ComputeAverage(ctx)
+	if err != nil {
+		return 0, rpcerror.FromError(err)
+	}
+	for n := range numbers {
+		// io.EOF means the server ended the stream, CloseAndRecv tells why
+		if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: n}); err != nil {
+			break
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, rpcerror.FromError(err)
+	}
+	return res.GetAverage(), nil
+}
+
+// RunningMaxSession is a FindMaximum stream
+type RunningMaxSession = client.Session[int32, int32]
+
+// RunningMax opens a FindMaximum stream, Out delivers the maximum of the
+// numbers sent each time it changes. Canceling ctx ends it.
+func (c *Client) RunningMax(ctx context.Context) (*RunningMaxSession, error) {
+	stream, err := c.c.FindMaximum(ctx)
+	if err != nil {
+		return nil, rpcerror.FromError(err)
+	}
+	return client.NewSession(stream,
+		func(n int32) *calculatorpb.FindMaximumRequest { return &calculatorpb.FindMaximumRequest{Number: n} },
+		func(res *calculatorpb.FindMaximumResponse) int32 { return res.GetMaximum() },
+	), nil
+}
+
+// Aggregate is a value of a RunningAggregate stream
+type Aggregate struct {
+	Value float64
+	// Count is the number of inputs inside the window
+	Count int
+}
+
+// AggregateSession is a RunningAggregate stream
+type AggregateSession = client.Session[float64, Aggregate]
+
+// RunningAggregate opens a RunningAggregate stream computing cfg over the
+// numbers sent, Out delivers the value after each number
+func (c *Client) RunningAggregate(ctx context.Context, cfg *calculatorpb.AggregateConfig) (*AggregateSession, error) {
+	stream, err := c.c.RunningAggregate(ctx)
+	if err != nil {
+		return nil, rpcerror.FromError(err)
+	}
+	first := true
+	return client.NewSession(stream,
+		func(n float64) *calculatorpb.RunningAggregateRequest {
+			req := &calculatorpb.RunningAggregateRequest{Number: n}
+			if first {
+				// the configuration is only read from the first message
+				req.Config, first = cfg, false
+			}
+			return req
+		},
+		func(res *calculatorpb.RunningAggregateResponse) Aggregate {
+			return Aggregate{Value: res.GetValue(), Count: int(res.GetCount())}
+		},
+	), nil
+}
+
+// Batch runs ops in one call, the result i belongs to ops[i] and fails alone
+func (c *Client) Batch(ctx context.Context, ops ...*calculatorpb.BatchOperation) ([]*calculatorpb.BatchResult, error) {
+	res, err := c.c.Batch(ctx, &calculatorpb.BatchRequest{Operations: ops})
+	if err != nil {
+		return nil, rpcerror.FromError(err)
+	}
+	return res.GetResults(), nil
+}
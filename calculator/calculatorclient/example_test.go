@@ -0,0 +1,117 @@
+package calculatorclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorclient"
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/mock"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// the examples call a mock server scripted with the responses shown
+
+func Example() {
+	m, err := mock.New(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	m.On("calculator.CalculatorService/Sum", mock.Reply(&calculatorpb.SumResponse{SumResult: 13}))
+	addr, stop, err := m.Start()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stop()
+
+	c, err := calculatorclient.Dial(addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	sum, err := c.Sum(context.Background(), 3, 10)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(sum)
+	// Output: 13
+}
+
+func ExampleClient_PrimeFactors() {
+	m, err := mock.New(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// the first stream breaks after two factors, the second one resumes
+	// from the token of the last factor received
+	m.On("calculator.CalculatorService/PrimeNumberDecomposition",
+		mock.Reply(
+			&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: 2, ResumeToken: "60"},
+			&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: 2, ResumeToken: "30"},
+		).Then(codes.Unavailable, "server restarting"),
+		mock.Reply(
+			&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: 2, ResumeToken: "15"},
+			&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: 3, ResumeToken: "5"},
+			&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: 5, ResumeToken: "1"},
+		),
+	)
+	addr, stop, err := m.Start()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stop()
+
+	c, err := calculatorclient.Dial(addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	for factor, err := range c.PrimeFactors(context.Background(), 120) {
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(factor)
+	}
+
+	var resumed calculatorpb.PrimeNumberDecompositionRequest
+	if err := m.Calls("calculator.CalculatorService/PrimeNumberDecomposition")[1].Decode(0, &resumed); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("resumed with", resumed.GetResumeToken())
+	// Output:
+	// 2
+	// 2
+	// 2
+	// 3
+	// 5
+	// resumed with 30
+}
+
+func ExampleClient_SquareRoot() {
+	m, err := mock.New(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	m.On("calculator.CalculatorService/SquareRoot", mock.Fail(codes.InvalidArgument, "negative number"))
+	addr, stop, err := m.Start()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stop()
+
+	c, err := calculatorclient.Dial(addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	_, err = c.SquareRoot(context.Background(), -4)
+	fmt.Println(errors.Is(err, rpcerror.ErrInvalidArgument))
+	// Output: true
+}
@@ -6,6 +6,9 @@ package calculatorpb
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import _ "github.com/christiangda/grpc-go-course/validate/validatepb"
+import _ "google.golang.org/genproto/googleapis/api/annotations"
+import status "google.golang.org/genproto/googleapis/rpc/status"
 
 import (
 	context "golang.org/x/net/context"
@@ -23,6 +26,38 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type AggregateConfig_Aggregate int32
+
+const (
+	AggregateConfig_MAX  AggregateConfig_Aggregate = 0
+	AggregateConfig_MIN  AggregateConfig_Aggregate = 1
+	AggregateConfig_SUM  AggregateConfig_Aggregate = 2
+	AggregateConfig_MEAN AggregateConfig_Aggregate = 3
+	AggregateConfig_EWMA AggregateConfig_Aggregate = 4
+)
+
+var AggregateConfig_Aggregate_name = map[int32]string{
+	0: "MAX",
+	1: "MIN",
+	2: "SUM",
+	3: "MEAN",
+	4: "EWMA",
+}
+var AggregateConfig_Aggregate_value = map[string]int32{
+	"MAX":  0,
+	"MIN":  1,
+	"SUM":  2,
+	"MEAN": 3,
+	"EWMA": 4,
+}
+
+func (x AggregateConfig_Aggregate) String() string {
+	return proto.EnumName(AggregateConfig_Aggregate_name, int32(x))
+}
+func (AggregateConfig_Aggregate) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{8, 0}
+}
+
 type SumRequest struct {
 	FirstNumber          int32    `protobuf:"varint,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
 	SecondNumber         int32    `protobuf:"varint,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
@@ -35,7 +70,7 @@ func (m *SumRequest) Reset()         { *m = SumRequest{} }
 func (m *SumRequest) String() string { return proto.CompactTextString(m) }
 func (*SumRequest) ProtoMessage()    {}
 func (*SumRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{0}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{0}
 }
 func (m *SumRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SumRequest.Unmarshal(m, b)
@@ -80,7 +115,7 @@ func (m *SumResponse) Reset()         { *m = SumResponse{} }
 func (m *SumResponse) String() string { return proto.CompactTextString(m) }
 func (*SumResponse) ProtoMessage()    {}
 func (*SumResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{1}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{1}
 }
 func (m *SumResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SumResponse.Unmarshal(m, b)
@@ -108,7 +143,9 @@ func (m *SumResponse) GetSumResult() int32 {
 }
 
 type PrimeNumberDecompositionRequest struct {
-	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// resume_token of the last response received, to continue a broken stream
+	ResumeToken          string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -118,7 +155,7 @@ func (m *PrimeNumberDecompositionRequest) Reset()         { *m = PrimeNumberDeco
 func (m *PrimeNumberDecompositionRequest) String() string { return proto.CompactTextString(m) }
 func (*PrimeNumberDecompositionRequest) ProtoMessage()    {}
 func (*PrimeNumberDecompositionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{2}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{2}
 }
 func (m *PrimeNumberDecompositionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrimeNumberDecompositionRequest.Unmarshal(m, b)
@@ -145,8 +182,16 @@ func (m *PrimeNumberDecompositionRequest) GetNumber() int64 {
 	return 0
 }
 
+func (m *PrimeNumberDecompositionRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
 type PrimeNumberDecompositionResponse struct {
 	PrimeFactor          int64    `protobuf:"varint,1,opt,name=prime_factor,json=primeFactor,proto3" json:"prime_factor,omitempty"`
+	ResumeToken          string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -156,7 +201,7 @@ func (m *PrimeNumberDecompositionResponse) Reset()         { *m = PrimeNumberDec
 func (m *PrimeNumberDecompositionResponse) String() string { return proto.CompactTextString(m) }
 func (*PrimeNumberDecompositionResponse) ProtoMessage()    {}
 func (*PrimeNumberDecompositionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{3}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{3}
 }
 func (m *PrimeNumberDecompositionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrimeNumberDecompositionResponse.Unmarshal(m, b)
@@ -183,6 +228,13 @@ func (m *PrimeNumberDecompositionResponse) GetPrimeFactor() int64 {
 	return 0
 }
 
+func (m *PrimeNumberDecompositionResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
 type ComputeAverageRequest struct {
 	Number               int32    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -194,7 +246,7 @@ func (m *ComputeAverageRequest) Reset()         { *m = ComputeAverageRequest{} }
 func (m *ComputeAverageRequest) String() string { return proto.CompactTextString(m) }
 func (*ComputeAverageRequest) ProtoMessage()    {}
 func (*ComputeAverageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{4}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{4}
 }
 func (m *ComputeAverageRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ComputeAverageRequest.Unmarshal(m, b)
@@ -232,7 +284,7 @@ func (m *ComputeAverageResponse) Reset()         { *m = ComputeAverageResponse{}
 func (m *ComputeAverageResponse) String() string { return proto.CompactTextString(m) }
 func (*ComputeAverageResponse) ProtoMessage()    {}
 func (*ComputeAverageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{5}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{5}
 }
 func (m *ComputeAverageResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ComputeAverageResponse.Unmarshal(m, b)
@@ -270,7 +322,7 @@ func (m *FindMaximumRequest) Reset()         { *m = FindMaximumRequest{} }
 func (m *FindMaximumRequest) String() string { return proto.CompactTextString(m) }
 func (*FindMaximumRequest) ProtoMessage()    {}
 func (*FindMaximumRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{6}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{6}
 }
 func (m *FindMaximumRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindMaximumRequest.Unmarshal(m, b)
@@ -298,7 +350,7 @@ func (m *FindMaximumRequest) GetNumber() int32 {
 }
 
 type FindMaximumResponse struct {
-	Maximum              float64  `protobuf:"fixed64,1,opt,name=maximum,proto3" json:"maximum,omitempty"`
+	Maximum              int32    `protobuf:"varint,2,opt,name=maximum,proto3" json:"maximum,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -308,7 +360,7 @@ func (m *FindMaximumResponse) Reset()         { *m = FindMaximumResponse{} }
 func (m *FindMaximumResponse) String() string { return proto.CompactTextString(m) }
 func (*FindMaximumResponse) ProtoMessage()    {}
 func (*FindMaximumResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{7}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{7}
 }
 func (m *FindMaximumResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindMaximumResponse.Unmarshal(m, b)
@@ -328,13 +380,172 @@ func (m *FindMaximumResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_FindMaximumResponse proto.InternalMessageInfo
 
-func (m *FindMaximumResponse) GetMaximum() float64 {
+func (m *FindMaximumResponse) GetMaximum() int32 {
 	if m != nil {
 		return m.Maximum
 	}
 	return 0
 }
 
+type AggregateConfig struct {
+	Aggregate AggregateConfig_Aggregate `protobuf:"varint,1,opt,name=aggregate,proto3,enum=calculator.AggregateConfig_Aggregate" json:"aggregate,omitempty"`
+	// window by number of inputs, 0 means unbounded
+	WindowSize int32 `protobuf:"varint,2,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
+	// window by age of inputs in milliseconds, 0 means unbounded
+	WindowMillis int64 `protobuf:"varint,3,opt,name=window_millis,json=windowMillis,proto3" json:"window_millis,omitempty"`
+	// smoothing factor in (0, 1] used by EWMA, defaults to 0.5
+	Alpha                float64  `protobuf:"fixed64,4,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AggregateConfig) Reset()         { *m = AggregateConfig{} }
+func (m *AggregateConfig) String() string { return proto.CompactTextString(m) }
+func (*AggregateConfig) ProtoMessage()    {}
+func (*AggregateConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{8}
+}
+func (m *AggregateConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AggregateConfig.Unmarshal(m, b)
+}
+func (m *AggregateConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AggregateConfig.Marshal(b, m, deterministic)
+}
+func (dst *AggregateConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AggregateConfig.Merge(dst, src)
+}
+func (m *AggregateConfig) XXX_Size() int {
+	return xxx_messageInfo_AggregateConfig.Size(m)
+}
+func (m *AggregateConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_AggregateConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AggregateConfig proto.InternalMessageInfo
+
+func (m *AggregateConfig) GetAggregate() AggregateConfig_Aggregate {
+	if m != nil {
+		return m.Aggregate
+	}
+	return AggregateConfig_MAX
+}
+
+func (m *AggregateConfig) GetWindowSize() int32 {
+	if m != nil {
+		return m.WindowSize
+	}
+	return 0
+}
+
+func (m *AggregateConfig) GetWindowMillis() int64 {
+	if m != nil {
+		return m.WindowMillis
+	}
+	return 0
+}
+
+func (m *AggregateConfig) GetAlpha() float64 {
+	if m != nil {
+		return m.Alpha
+	}
+	return 0
+}
+
+type RunningAggregateRequest struct {
+	// only read from the first message of the stream
+	Config               *AggregateConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Number               float64          `protobuf:"fixed64,2,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RunningAggregateRequest) Reset()         { *m = RunningAggregateRequest{} }
+func (m *RunningAggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*RunningAggregateRequest) ProtoMessage()    {}
+func (*RunningAggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{9}
+}
+func (m *RunningAggregateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunningAggregateRequest.Unmarshal(m, b)
+}
+func (m *RunningAggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunningAggregateRequest.Marshal(b, m, deterministic)
+}
+func (dst *RunningAggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunningAggregateRequest.Merge(dst, src)
+}
+func (m *RunningAggregateRequest) XXX_Size() int {
+	return xxx_messageInfo_RunningAggregateRequest.Size(m)
+}
+func (m *RunningAggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunningAggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunningAggregateRequest proto.InternalMessageInfo
+
+func (m *RunningAggregateRequest) GetConfig() *AggregateConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *RunningAggregateRequest) GetNumber() float64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type RunningAggregateResponse struct {
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	// number of inputs inside the window
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunningAggregateResponse) Reset()         { *m = RunningAggregateResponse{} }
+func (m *RunningAggregateResponse) String() string { return proto.CompactTextString(m) }
+func (*RunningAggregateResponse) ProtoMessage()    {}
+func (*RunningAggregateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{10}
+}
+func (m *RunningAggregateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunningAggregateResponse.Unmarshal(m, b)
+}
+func (m *RunningAggregateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunningAggregateResponse.Marshal(b, m, deterministic)
+}
+func (dst *RunningAggregateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunningAggregateResponse.Merge(dst, src)
+}
+func (m *RunningAggregateResponse) XXX_Size() int {
+	return xxx_messageInfo_RunningAggregateResponse.Size(m)
+}
+func (m *RunningAggregateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunningAggregateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunningAggregateResponse proto.InternalMessageInfo
+
+func (m *RunningAggregateResponse) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *RunningAggregateResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 type SquareRootRequest struct {
 	Number               int32    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -346,7 +557,7 @@ func (m *SquareRootRequest) Reset()         { *m = SquareRootRequest{} }
 func (m *SquareRootRequest) String() string { return proto.CompactTextString(m) }
 func (*SquareRootRequest) ProtoMessage()    {}
 func (*SquareRootRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{8}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{11}
 }
 func (m *SquareRootRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SquareRootRequest.Unmarshal(m, b)
@@ -384,7 +595,7 @@ func (m *SquareRootResponse) Reset()         { *m = SquareRootResponse{} }
 func (m *SquareRootResponse) String() string { return proto.CompactTextString(m) }
 func (*SquareRootResponse) ProtoMessage()    {}
 func (*SquareRootResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_calculator_0374c2c1c5103341, []int{9}
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{12}
 }
 func (m *SquareRootResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SquareRootResponse.Unmarshal(m, b)
@@ -411,6 +622,407 @@ func (m *SquareRootResponse) GetNumberRoot() float64 {
 	return 0
 }
 
+type BatchOperation struct {
+	// Types that are valid to be assigned to Operation:
+	//	*BatchOperation_Sum
+	//	*BatchOperation_SquareRoot
+	Operation            isBatchOperation_Operation `protobuf_oneof:"operation"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *BatchOperation) Reset()         { *m = BatchOperation{} }
+func (m *BatchOperation) String() string { return proto.CompactTextString(m) }
+func (*BatchOperation) ProtoMessage()    {}
+func (*BatchOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{13}
+}
+func (m *BatchOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchOperation.Unmarshal(m, b)
+}
+func (m *BatchOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchOperation.Marshal(b, m, deterministic)
+}
+func (dst *BatchOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchOperation.Merge(dst, src)
+}
+func (m *BatchOperation) XXX_Size() int {
+	return xxx_messageInfo_BatchOperation.Size(m)
+}
+func (m *BatchOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchOperation proto.InternalMessageInfo
+
+type isBatchOperation_Operation interface {
+	isBatchOperation_Operation()
+}
+
+type BatchOperation_Sum struct {
+	Sum *SumRequest `protobuf:"bytes,1,opt,name=sum,proto3,oneof"`
+}
+
+type BatchOperation_SquareRoot struct {
+	SquareRoot *SquareRootRequest `protobuf:"bytes,2,opt,name=square_root,json=squareRoot,proto3,oneof"`
+}
+
+func (*BatchOperation_Sum) isBatchOperation_Operation() {}
+
+func (*BatchOperation_SquareRoot) isBatchOperation_Operation() {}
+
+func (m *BatchOperation) GetOperation() isBatchOperation_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *BatchOperation) GetSum() *SumRequest {
+	if x, ok := m.GetOperation().(*BatchOperation_Sum); ok {
+		return x.Sum
+	}
+	return nil
+}
+
+func (m *BatchOperation) GetSquareRoot() *SquareRootRequest {
+	if x, ok := m.GetOperation().(*BatchOperation_SquareRoot); ok {
+		return x.SquareRoot
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*BatchOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _BatchOperation_OneofMarshaler, _BatchOperation_OneofUnmarshaler, _BatchOperation_OneofSizer, []interface{}{
+		(*BatchOperation_Sum)(nil),
+		(*BatchOperation_SquareRoot)(nil),
+	}
+}
+
+func _BatchOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*BatchOperation)
+	// operation
+	switch x := m.Operation.(type) {
+	case *BatchOperation_Sum:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Sum); err != nil {
+			return err
+		}
+	case *BatchOperation_SquareRoot:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SquareRoot); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("BatchOperation.Operation has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _BatchOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*BatchOperation)
+	switch tag {
+	case 1: // operation.sum
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SumRequest)
+		err := b.DecodeMessage(msg)
+		m.Operation = &BatchOperation_Sum{msg}
+		return true, err
+	case 2: // operation.square_root
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SquareRootRequest)
+		err := b.DecodeMessage(msg)
+		m.Operation = &BatchOperation_SquareRoot{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _BatchOperation_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*BatchOperation)
+	// operation
+	switch x := m.Operation.(type) {
+	case *BatchOperation_Sum:
+		s := proto.Size(x.Sum)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *BatchOperation_SquareRoot:
+		s := proto.Size(x.SquareRoot)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type BatchRequest struct {
+	// every operation is validated on its own so it fails alone
+	Operations           []*BatchOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BatchRequest) Reset()         { *m = BatchRequest{} }
+func (m *BatchRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchRequest) ProtoMessage()    {}
+func (*BatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{14}
+}
+func (m *BatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchRequest.Unmarshal(m, b)
+}
+func (m *BatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchRequest.Marshal(b, m, deterministic)
+}
+func (dst *BatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchRequest.Merge(dst, src)
+}
+func (m *BatchRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchRequest.Size(m)
+}
+func (m *BatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchRequest proto.InternalMessageInfo
+
+func (m *BatchRequest) GetOperations() []*BatchOperation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+type BatchResult struct {
+	// Types that are valid to be assigned to Result:
+	//	*BatchResult_Sum
+	//	*BatchResult_SquareRoot
+	//	*BatchResult_Error
+	Result               isBatchResult_Result `protobuf_oneof:"result"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BatchResult) Reset()         { *m = BatchResult{} }
+func (m *BatchResult) String() string { return proto.CompactTextString(m) }
+func (*BatchResult) ProtoMessage()    {}
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{15}
+}
+func (m *BatchResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchResult.Unmarshal(m, b)
+}
+func (m *BatchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchResult.Marshal(b, m, deterministic)
+}
+func (dst *BatchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchResult.Merge(dst, src)
+}
+func (m *BatchResult) XXX_Size() int {
+	return xxx_messageInfo_BatchResult.Size(m)
+}
+func (m *BatchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchResult proto.InternalMessageInfo
+
+type isBatchResult_Result interface {
+	isBatchResult_Result()
+}
+
+type BatchResult_Sum struct {
+	Sum *SumResponse `protobuf:"bytes,1,opt,name=sum,proto3,oneof"`
+}
+
+type BatchResult_SquareRoot struct {
+	SquareRoot *SquareRootResponse `protobuf:"bytes,2,opt,name=square_root,json=squareRoot,proto3,oneof"`
+}
+
+type BatchResult_Error struct {
+	Error *status.Status `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchResult_Sum) isBatchResult_Result() {}
+
+func (*BatchResult_SquareRoot) isBatchResult_Result() {}
+
+func (*BatchResult_Error) isBatchResult_Result() {}
+
+func (m *BatchResult) GetResult() isBatchResult_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *BatchResult) GetSum() *SumResponse {
+	if x, ok := m.GetResult().(*BatchResult_Sum); ok {
+		return x.Sum
+	}
+	return nil
+}
+
+func (m *BatchResult) GetSquareRoot() *SquareRootResponse {
+	if x, ok := m.GetResult().(*BatchResult_SquareRoot); ok {
+		return x.SquareRoot
+	}
+	return nil
+}
+
+func (m *BatchResult) GetError() *status.Status {
+	if x, ok := m.GetResult().(*BatchResult_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*BatchResult) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _BatchResult_OneofMarshaler, _BatchResult_OneofUnmarshaler, _BatchResult_OneofSizer, []interface{}{
+		(*BatchResult_Sum)(nil),
+		(*BatchResult_SquareRoot)(nil),
+		(*BatchResult_Error)(nil),
+	}
+}
+
+func _BatchResult_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*BatchResult)
+	// result
+	switch x := m.Result.(type) {
+	case *BatchResult_Sum:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Sum); err != nil {
+			return err
+		}
+	case *BatchResult_SquareRoot:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SquareRoot); err != nil {
+			return err
+		}
+	case *BatchResult_Error:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Error); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("BatchResult.Result has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _BatchResult_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*BatchResult)
+	switch tag {
+	case 1: // result.sum
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SumResponse)
+		err := b.DecodeMessage(msg)
+		m.Result = &BatchResult_Sum{msg}
+		return true, err
+	case 2: // result.square_root
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SquareRootResponse)
+		err := b.DecodeMessage(msg)
+		m.Result = &BatchResult_SquareRoot{msg}
+		return true, err
+	case 3: // result.error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(status.Status)
+		err := b.DecodeMessage(msg)
+		m.Result = &BatchResult_Error{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _BatchResult_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*BatchResult)
+	// result
+	switch x := m.Result.(type) {
+	case *BatchResult_Sum:
+		s := proto.Size(x.Sum)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *BatchResult_SquareRoot:
+		s := proto.Size(x.SquareRoot)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *BatchResult_Error:
+		s := proto.Size(x.Error)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type BatchResponse struct {
+	// results[i] belongs to operations[i] of the request
+	Results              []*BatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BatchResponse) Reset()         { *m = BatchResponse{} }
+func (m *BatchResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchResponse) ProtoMessage()    {}
+func (*BatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_calculator_07f500f9bebe241a, []int{16}
+}
+func (m *BatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchResponse.Unmarshal(m, b)
+}
+func (m *BatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchResponse.Marshal(b, m, deterministic)
+}
+func (dst *BatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchResponse.Merge(dst, src)
+}
+func (m *BatchResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchResponse.Size(m)
+}
+func (m *BatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchResponse proto.InternalMessageInfo
+
+func (m *BatchResponse) GetResults() []*BatchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SumRequest)(nil), "calculator.SumRequest")
 	proto.RegisterType((*SumResponse)(nil), "calculator.SumResponse")
@@ -420,8 +1032,16 @@ func init() {
 	proto.RegisterType((*ComputeAverageResponse)(nil), "calculator.ComputeAverageResponse")
 	proto.RegisterType((*FindMaximumRequest)(nil), "calculator.FindMaximumRequest")
 	proto.RegisterType((*FindMaximumResponse)(nil), "calculator.FindMaximumResponse")
+	proto.RegisterType((*AggregateConfig)(nil), "calculator.AggregateConfig")
+	proto.RegisterType((*RunningAggregateRequest)(nil), "calculator.RunningAggregateRequest")
+	proto.RegisterType((*RunningAggregateResponse)(nil), "calculator.RunningAggregateResponse")
 	proto.RegisterType((*SquareRootRequest)(nil), "calculator.SquareRootRequest")
 	proto.RegisterType((*SquareRootResponse)(nil), "calculator.SquareRootResponse")
+	proto.RegisterType((*BatchOperation)(nil), "calculator.BatchOperation")
+	proto.RegisterType((*BatchRequest)(nil), "calculator.BatchRequest")
+	proto.RegisterType((*BatchResult)(nil), "calculator.BatchResult")
+	proto.RegisterType((*BatchResponse)(nil), "calculator.BatchResponse")
+	proto.RegisterEnum("calculator.AggregateConfig_Aggregate", AggregateConfig_Aggregate_name, AggregateConfig_Aggregate_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -440,7 +1060,9 @@ type CalculatorServiceClient interface {
 	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
 	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
 	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
+	RunningAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_RunningAggregateClient, error)
 	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
 }
 
 type calculatorServiceClient struct {
@@ -557,6 +1179,37 @@ func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error
 	return m, nil
 }
 
+func (c *calculatorServiceClient) RunningAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_RunningAggregateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[3], "/calculator.CalculatorService/RunningAggregate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceRunningAggregateClient{stream}
+	return x, nil
+}
+
+type CalculatorService_RunningAggregateClient interface {
+	Send(*RunningAggregateRequest) error
+	Recv() (*RunningAggregateResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceRunningAggregateClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceRunningAggregateClient) Send(m *RunningAggregateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceRunningAggregateClient) Recv() (*RunningAggregateResponse, error) {
+	m := new(RunningAggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error) {
 	out := new(SquareRootResponse)
 	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SquareRoot", in, out, opts...)
@@ -566,13 +1219,24 @@ func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRoot
 	return out, nil
 }
 
+func (c *calculatorServiceClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Batch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CalculatorServiceServer is the server API for CalculatorService service.
 type CalculatorServiceServer interface {
 	Sum(context.Context, *SumRequest) (*SumResponse, error)
 	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
 	ComputeAverage(CalculatorService_ComputeAverageServer) error
 	FindMaximum(CalculatorService_FindMaximumServer) error
+	RunningAggregate(CalculatorService_RunningAggregateServer) error
 	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
 }
 
 func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
@@ -670,6 +1334,32 @@ func (x *calculatorServiceFindMaximumServer) Recv() (*FindMaximumRequest, error)
 	return m, nil
 }
 
+func _CalculatorService_RunningAggregate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).RunningAggregate(&calculatorServiceRunningAggregateServer{stream})
+}
+
+type CalculatorService_RunningAggregateServer interface {
+	Send(*RunningAggregateResponse) error
+	Recv() (*RunningAggregateRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceRunningAggregateServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceRunningAggregateServer) Send(m *RunningAggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceRunningAggregateServer) Recv() (*RunningAggregateRequest, error) {
+	m := new(RunningAggregateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SquareRootRequest)
 	if err := dec(in); err != nil {
@@ -688,6 +1378,24 @@ func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalculatorService_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Batch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Batch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "calculator.CalculatorService",
 	HandlerType: (*CalculatorServiceServer)(nil),
@@ -700,6 +1408,10 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SquareRoot",
 			Handler:    _CalculatorService_SquareRoot_Handler,
 		},
+		{
+			MethodName: "Batch",
+			Handler:    _CalculatorService_Batch_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -718,42 +1430,85 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "RunningAggregate",
+			Handler:       _CalculatorService_RunningAggregate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "calculator/calculatorpb/calculator.proto",
 }
 
 func init() {
-	proto.RegisterFile("calculator/calculatorpb/calculator.proto", fileDescriptor_calculator_0374c2c1c5103341)
-}
-
-var fileDescriptor_calculator_0374c2c1c5103341 = []byte{
-	// 433 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5b, 0x6b, 0xd4, 0x40,
-	0x14, 0x36, 0x2e, 0xad, 0x78, 0x12, 0x0b, 0x3d, 0xe2, 0xba, 0x04, 0xda, 0xed, 0x8e, 0x2f, 0x0b,
-	0x5d, 0xba, 0xa5, 0x22, 0xe8, 0xa3, 0x56, 0xfb, 0x56, 0x91, 0xa4, 0x4f, 0xfa, 0x10, 0xb2, 0xe9,
-	0x54, 0x02, 0x99, 0x4c, 0x3a, 0x97, 0xa2, 0x7f, 0xd1, 0x5f, 0x25, 0x9d, 0xc9, 0x6c, 0x66, 0x2f,
-	0x71, 0x7d, 0xcb, 0xf9, 0xe6, 0xbb, 0x84, 0x39, 0x1f, 0x03, 0xd3, 0x22, 0xaf, 0x0a, 0x5d, 0xe5,
-	0x8a, 0x8b, 0x79, 0xf7, 0xd9, 0x2c, 0xbc, 0xe1, 0xac, 0x11, 0x5c, 0x71, 0x84, 0x0e, 0x21, 0x37,
-	0x00, 0xa9, 0x66, 0x09, 0xbd, 0xd7, 0x54, 0x2a, 0x9c, 0x40, 0x74, 0x57, 0x0a, 0xa9, 0xb2, 0x5a,
-	0xb3, 0x05, 0x15, 0xa3, 0xe0, 0x24, 0x98, 0xee, 0x25, 0xa1, 0xc1, 0xbe, 0x1a, 0x08, 0xdf, 0xc0,
-	0x0b, 0x49, 0x0b, 0x5e, 0xdf, 0x3a, 0xce, 0x53, 0xc3, 0x89, 0x2c, 0x68, 0x49, 0x64, 0x06, 0xa1,
-	0x71, 0x95, 0x0d, 0xaf, 0x25, 0xc5, 0x23, 0x00, 0xa9, 0x59, 0x26, 0xa8, 0xd4, 0x95, 0x6a, 0x4d,
-	0x9f, 0x4b, 0x43, 0xd0, 0x95, 0x22, 0x1f, 0x60, 0xfc, 0x4d, 0x94, 0x8c, 0x5a, 0xf1, 0x67, 0x5a,
-	0x70, 0xd6, 0x70, 0x59, 0xaa, 0x92, 0xd7, 0xee, 0xc7, 0x86, 0xb0, 0xef, 0xfd, 0xd2, 0x20, 0x69,
-	0x27, 0xf2, 0x05, 0x4e, 0xfa, 0xa5, 0x6d, 0xfa, 0x04, 0xa2, 0xe6, 0x91, 0x93, 0xdd, 0xe5, 0x85,
-	0xe2, 0xce, 0x21, 0x34, 0xd8, 0x95, 0x81, 0xc8, 0x1c, 0x5e, 0x5d, 0x72, 0xd6, 0x68, 0x45, 0x3f,
-	0x3e, 0x50, 0x91, 0xff, 0xa4, 0xdb, 0x73, 0xf7, 0x96, 0xb9, 0x17, 0x30, 0x5c, 0x17, 0xb4, 0x69,
-	0x23, 0x78, 0x96, 0x5b, 0xc8, 0x48, 0x82, 0xc4, 0x8d, 0x64, 0x06, 0x78, 0x55, 0xd6, 0xb7, 0xd7,
-	0xf9, 0xaf, 0x92, 0x75, 0x57, 0xde, 0x97, 0x30, 0x87, 0x97, 0x2b, 0xec, 0xce, 0x9e, 0x59, 0xc8,
-	0xd9, 0xb7, 0x23, 0x39, 0x85, 0xc3, 0xf4, 0x5e, 0xe7, 0x82, 0x26, 0x9c, 0xab, 0x5d, 0xee, 0xef,
-	0x00, 0x7d, 0x72, 0x6b, 0x3e, 0x86, 0xd0, 0x9e, 0x67, 0x82, 0x73, 0xd5, 0x06, 0x80, 0x85, 0x1e,
-	0x89, 0x17, 0x7f, 0x06, 0x70, 0x78, 0xb9, 0x2c, 0x4f, 0x4a, 0xc5, 0x43, 0x59, 0x50, 0x7c, 0x0f,
-	0x83, 0x54, 0x33, 0x1c, 0x9e, 0x79, 0x4d, 0xeb, 0x4a, 0x15, 0xbf, 0xde, 0xc0, 0x6d, 0x1c, 0x79,
-	0x82, 0xbf, 0x61, 0xd4, 0xb7, 0x3e, 0x3c, 0xf5, 0x65, 0x3b, 0xfa, 0x11, 0xcf, 0xfe, 0x8f, 0xec,
-	0x82, 0xcf, 0x03, 0xfc, 0x01, 0x07, 0xab, 0x1b, 0xc4, 0x89, 0xef, 0xb1, 0xb5, 0x0e, 0x31, 0xf9,
-	0x17, 0xc5, 0x99, 0x4f, 0x03, 0xbc, 0x81, 0xd0, 0x5b, 0x1e, 0x1e, 0xfb, 0xb2, 0xcd, 0x0e, 0xc4,
-	0xe3, 0xde, 0xf3, 0xce, 0xf3, 0x3c, 0xc0, 0x6b, 0x80, 0x6e, 0x69, 0x78, 0xb4, 0x72, 0xad, 0xeb,
-	0x9b, 0x8f, 0x8f, 0xfb, 0x8e, 0x9d, 0xe5, 0xa7, 0x83, 0xef, 0x91, 0xff, 0x4e, 0x2c, 0xf6, 0xcd,
-	0xeb, 0xf0, 0xf6, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x1c, 0x33, 0x5d, 0x02, 0x49, 0x04, 0x00,
-	0x00,
+	proto.RegisterFile("calculator/calculatorpb/calculator.proto", fileDescriptor_calculator_07f500f9bebe241a)
+}
+
+var fileDescriptor_calculator_07f500f9bebe241a = []byte{
+	// 1030 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xcf, 0x6e, 0xdb, 0xc6,
+	0x13, 0x16, 0xf5, 0xc7, 0xb6, 0x86, 0x8a, 0x43, 0xaf, 0x1d, 0x9b, 0x61, 0x7e, 0xfe, 0xd9, 0xde,
+	0xf4, 0x8f, 0xe1, 0x18, 0x62, 0xa2, 0x20, 0x97, 0x9c, 0x2a, 0xba, 0x31, 0xdc, 0x02, 0x72, 0x0b,
+	0xca, 0x45, 0x8b, 0xf6, 0x20, 0xd0, 0xf4, 0x4a, 0x26, 0x4a, 0x72, 0x69, 0x72, 0xe9, 0x04, 0x31,
+	0x02, 0x04, 0xbd, 0x16, 0x3d, 0x35, 0x0f, 0xd0, 0x4b, 0x9f, 0xa0, 0x8f, 0xd2, 0x57, 0x28, 0x7a,
+	0xec, 0xd5, 0x80, 0x4f, 0x05, 0x77, 0x97, 0x12, 0x69, 0x59, 0x76, 0x6f, 0xbb, 0x33, 0xdf, 0xcc,
+	0x7c, 0xdf, 0xce, 0x68, 0x28, 0xd8, 0x76, 0x1d, 0xdf, 0x4d, 0x7d, 0x87, 0xd1, 0xd8, 0x9c, 0x1c,
+	0xa3, 0xe3, 0xc2, 0xa5, 0x1d, 0xc5, 0x94, 0x51, 0x04, 0x13, 0x8b, 0xf1, 0xbf, 0x11, 0xa5, 0x23,
+	0x9f, 0x98, 0x4e, 0xe4, 0x99, 0x4e, 0x18, 0x52, 0xe6, 0x30, 0x8f, 0x86, 0x89, 0x40, 0x1a, 0x6b,
+	0xd2, 0x1b, 0x47, 0xae, 0x99, 0x30, 0x87, 0xa5, 0xb9, 0x03, 0x9f, 0x3b, 0xbe, 0x77, 0xe2, 0x30,
+	0x62, 0xe6, 0x87, 0xe8, 0x78, 0x7c, 0x14, 0x18, 0x7c, 0x04, 0xd0, 0x4f, 0x03, 0x9b, 0x9c, 0xa5,
+	0x24, 0x61, 0x68, 0x0b, 0x5a, 0x43, 0x2f, 0x4e, 0xd8, 0x20, 0x4c, 0x83, 0x63, 0x12, 0xeb, 0xca,
+	0xa6, 0xb2, 0xdd, 0xb0, 0x55, 0x6e, 0x3b, 0xe4, 0x26, 0xf4, 0x18, 0xee, 0x25, 0xc4, 0xa5, 0xe1,
+	0x49, 0x8e, 0xa9, 0x72, 0x4c, 0x4b, 0x18, 0x05, 0x08, 0xef, 0x82, 0xca, 0xb3, 0x26, 0x11, 0x0d,
+	0x13, 0x82, 0xd6, 0x01, 0x92, 0x34, 0x18, 0xc4, 0x24, 0x49, 0x7d, 0x26, 0x93, 0x36, 0x13, 0x0e,
+	0x48, 0x7d, 0x86, 0xdf, 0xc0, 0xc6, 0xd7, 0xb1, 0x17, 0x10, 0x11, 0xfc, 0x39, 0x71, 0x69, 0x10,
+	0xd1, 0xc4, 0xcb, 0x34, 0xe6, 0xc4, 0x3e, 0x85, 0xb9, 0x02, 0xa5, 0x9a, 0x75, 0xff, 0xea, 0x52,
+	0x57, 0x77, 0x9a, 0x9a, 0xb2, 0xf9, 0xfe, 0xb7, 0x0f, 0xbf, 0xff, 0xb2, 0x6e, 0x4b, 0x37, 0xda,
+	0x85, 0x56, 0x56, 0x26, 0x20, 0x03, 0x46, 0x7f, 0x24, 0x21, 0x67, 0xd7, 0xb4, 0x9a, 0x57, 0x97,
+	0x7a, 0xc3, 0xa8, 0x69, 0xef, 0xeb, 0xb6, 0x2a, 0xdc, 0x47, 0x99, 0x17, 0x9f, 0xc2, 0xe6, 0xec,
+	0xca, 0x92, 0xfc, 0x16, 0xb4, 0xa2, 0x0c, 0x33, 0x18, 0x3a, 0x2e, 0xa3, 0x92, 0x80, 0xad, 0x72,
+	0xdb, 0x3e, 0x37, 0x65, 0x90, 0xe9, 0xa2, 0xe5, 0x4a, 0x26, 0x3c, 0xd8, 0xa3, 0x41, 0x94, 0x32,
+	0xd2, 0x3d, 0x27, 0xb1, 0x33, 0x22, 0xb9, 0xb2, 0xd5, 0x92, 0xb2, 0x46, 0x2e, 0x04, 0x77, 0x60,
+	0xf5, 0x7a, 0x80, 0x24, 0xa4, 0xc3, 0xbc, 0x23, 0x4c, 0x3c, 0x44, 0xb1, 0xf3, 0x2b, 0xde, 0x05,
+	0xb4, 0xef, 0x85, 0x27, 0x3d, 0xe7, 0x8d, 0x17, 0xa4, 0xc1, 0x5d, 0x15, 0x5e, 0xc0, 0x72, 0x09,
+	0x3d, 0x49, 0x1f, 0x08, 0x93, 0x6c, 0x6d, 0x7e, 0xfd, 0xb2, 0xbe, 0xa0, 0x68, 0x55, 0xfc, 0x8f,
+	0x02, 0xf7, 0xbb, 0xa3, 0x51, 0x4c, 0x46, 0x0e, 0x23, 0x7b, 0x34, 0x1c, 0x7a, 0x23, 0xb4, 0x07,
+	0x4d, 0x27, 0x37, 0xf1, 0x2a, 0x8b, 0x9d, 0x8f, 0xdb, 0x85, 0x91, 0xbe, 0x86, 0x9f, 0xdc, 0xed,
+	0x49, 0x1c, 0xda, 0x00, 0xf5, 0xb5, 0x17, 0x9e, 0xd0, 0xd7, 0x83, 0xc4, 0x7b, 0x4b, 0x64, 0x71,
+	0x10, 0xa6, 0xbe, 0xf7, 0x96, 0x64, 0xa3, 0x27, 0x01, 0x81, 0xe7, 0xfb, 0x5e, 0xa2, 0xd7, 0x78,
+	0x2b, 0x5a, 0xc2, 0xd8, 0xe3, 0x36, 0xb4, 0x02, 0x0d, 0xc7, 0x8f, 0x4e, 0x1d, 0xbd, 0xce, 0xdf,
+	0x46, 0x5c, 0xf0, 0x4b, 0x68, 0x8e, 0x6b, 0xa2, 0x79, 0xa8, 0xf5, 0xba, 0xdf, 0x69, 0x15, 0x7e,
+	0xf8, 0xe2, 0x50, 0x53, 0xb2, 0x43, 0xff, 0x9b, 0x9e, 0x56, 0x45, 0x0b, 0x50, 0xef, 0xbd, 0xea,
+	0x1e, 0x6a, 0xb5, 0xec, 0xf4, 0xea, 0xdb, 0x5e, 0x57, 0xab, 0xe3, 0x21, 0xac, 0xd9, 0x69, 0x18,
+	0x7a, 0xe1, 0x68, 0x42, 0x5b, 0x3e, 0xed, 0x73, 0x98, 0x73, 0xb9, 0x22, 0x2e, 0x5a, 0xed, 0x3c,
+	0xba, 0x45, 0xb4, 0x2d, 0xa1, 0x85, 0x7e, 0x54, 0x39, 0xc5, 0xbc, 0x1f, 0xfb, 0xa0, 0x4f, 0xd7,
+	0x91, 0x4d, 0x59, 0x81, 0xc6, 0xb9, 0xe3, 0xa7, 0x79, 0xc7, 0xc5, 0x25, 0xb3, 0xba, 0x34, 0x0d,
+	0x99, 0x7c, 0x2b, 0x71, 0xc1, 0x2f, 0x60, 0xa9, 0x7f, 0x96, 0x3a, 0x31, 0xb1, 0x29, 0x65, 0x39,
+	0xd3, 0xcd, 0xf2, 0x10, 0x58, 0x0b, 0x57, 0x97, 0x7a, 0x1d, 0x57, 0xb5, 0x4a, 0x61, 0x1c, 0x50,
+	0x31, 0x4c, 0x16, 0xde, 0x00, 0x55, 0xf8, 0x07, 0x31, 0xa5, 0x4c, 0x96, 0x07, 0x61, 0xca, 0x80,
+	0xf8, 0x67, 0x05, 0x16, 0x2d, 0x87, 0xb9, 0xa7, 0x5f, 0x45, 0x24, 0xe6, 0x7b, 0x09, 0xed, 0x40,
+	0x2d, 0x49, 0x03, 0xf9, 0x24, 0xab, 0xc5, 0x27, 0x99, 0xac, 0x9a, 0x83, 0x8a, 0x9d, 0x81, 0xd0,
+	0x67, 0xa0, 0x26, 0xbc, 0xaa, 0xc8, 0x5f, 0xe5, 0x31, 0xeb, 0xa5, 0x98, 0xeb, 0x5a, 0x0e, 0x2a,
+	0x36, 0x24, 0x63, 0xa3, 0xa5, 0x42, 0x93, 0xe6, 0xa5, 0xb1, 0x0d, 0x2d, 0x4e, 0x26, 0x97, 0x6d,
+	0x01, 0x8c, 0x9d, 0x89, 0xae, 0x6c, 0xd6, 0xb6, 0xd5, 0x8e, 0x51, 0xcc, 0x5e, 0xa6, 0x6e, 0xcd,
+	0x5d, 0x5d, 0xea, 0x55, 0x4d, 0xb1, 0x0b, 0x51, 0xf8, 0x0f, 0x05, 0x54, 0x99, 0x34, 0x5b, 0x57,
+	0xe8, 0x49, 0x51, 0xde, 0xda, 0x94, 0x3c, 0xf1, 0x70, 0xb9, 0xbe, 0xee, 0x4d, 0xfa, 0xfe, 0x3f,
+	0x4b, 0xdf, 0x38, 0xb6, 0x20, 0x10, 0xed, 0x40, 0x83, 0xc4, 0x31, 0x8d, 0xf9, 0xb8, 0xab, 0x1d,
+	0xd4, 0x16, 0xfb, 0xbe, 0x1d, 0x47, 0x6e, 0xbb, 0xcf, 0xf7, 0xfd, 0x41, 0xc5, 0x16, 0x10, 0x6b,
+	0x01, 0xe6, 0xc4, 0x96, 0xc5, 0x16, 0xdc, 0xcb, 0x49, 0x8b, 0x4e, 0x3e, 0x83, 0x79, 0xe1, 0xca,
+	0xdf, 0x61, 0x6d, 0xea, 0x1d, 0x84, 0x40, 0x3b, 0xc7, 0x75, 0xfe, 0x6e, 0xc0, 0xd2, 0xde, 0x18,
+	0xd3, 0x27, 0xf1, 0xb9, 0xe7, 0x12, 0x34, 0x84, 0x5a, 0x3f, 0x0d, 0xd0, 0x8c, 0xc6, 0x1a, 0xb3,
+	0x5e, 0x04, 0xb7, 0x7f, 0xfa, 0xf3, 0xaf, 0x5f, 0xab, 0xdb, 0xe8, 0x13, 0xf3, 0xfc, 0x99, 0x99,
+	0xa4, 0x81, 0x79, 0x51, 0xfc, 0xd6, 0xbc, 0x33, 0x2f, 0x4a, 0xdf, 0x95, 0x77, 0xe8, 0x83, 0x02,
+	0xfa, 0xac, 0xed, 0x8c, 0x9e, 0x14, 0xab, 0xdc, 0xf1, 0xf5, 0x30, 0x76, 0xff, 0x1b, 0x58, 0xf2,
+	0x7c, 0xc4, 0x79, 0x3e, 0x40, 0xcb, 0x19, 0x4f, 0xbe, 0xe6, 0x13, 0xf3, 0x42, 0x92, 0x7a, 0xaa,
+	0xa0, 0x1f, 0x60, 0xb1, 0xbc, 0x98, 0xd1, 0x56, 0x31, 0xfd, 0x8d, 0x5b, 0xde, 0xc0, 0xb7, 0x41,
+	0x64, 0xdd, 0xca, 0xb6, 0x82, 0x8e, 0x40, 0x2d, 0xec, 0x64, 0x54, 0x1a, 0x94, 0xe9, 0xd5, 0x6e,
+	0x6c, 0xcc, 0xf4, 0x4f, 0x72, 0x3e, 0x55, 0x90, 0x0b, 0xda, 0xf5, 0xcd, 0x82, 0x1e, 0x17, 0x43,
+	0x67, 0xec, 0x37, 0xe3, 0xa3, 0xdb, 0x41, 0xa5, 0x22, 0x43, 0x80, 0xc9, 0x28, 0xa3, 0xdb, 0x7f,
+	0xc2, 0xc6, 0x1d, 0xbf, 0x00, 0xfc, 0x90, 0xf7, 0x60, 0x19, 0x2d, 0xf1, 0x59, 0x39, 0x8b, 0xd9,
+	0xb8, 0x03, 0xc8, 0x86, 0x06, 0x1f, 0x56, 0xa4, 0xdf, 0x30, 0xbf, 0x22, 0xfb, 0xc3, 0x1b, 0x3c,
+	0x32, 0xf1, 0x0a, 0x4f, 0xbc, 0x88, 0x9b, 0x59, 0xe2, 0xe3, 0xcc, 0xf5, 0x52, 0xd9, 0xb1, 0x16,
+	0xbf, 0x6f, 0x15, 0xff, 0x8d, 0x1d, 0xcf, 0xf1, 0x3f, 0x47, 0xcf, 0xff, 0x1d, 0x00, 0xd6, 0xdd,
+	0xdf, 0x33, 0xaf, 0x09, 0x00, 0x00,
 }
@@ -0,0 +1,112 @@
+package calculatorservice
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+const defaultAlpha = 0.5
+
+type sample struct {
+	number float64
+	at     time.Time
+}
+
+// aggregator keeps the window of samples of a RunningAggregate stream
+type aggregator struct {
+	kind    calculatorpb.AggregateConfig_Aggregate
+	size    int
+	age     time.Duration
+	alpha   float64
+	samples []sample
+}
+
+func newAggregator(cfg *calculatorpb.AggregateConfig) (*aggregator, error) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if cfg.GetWindowSize() < 0 {
+		violations = append(violations, rpcerror.Violation("config.window_size", "must be >= 0"))
+	}
+	if cfg.GetWindowMillis() < 0 {
+		violations = append(violations, rpcerror.Violation("config.window_millis", "must be >= 0"))
+	}
+	alpha := cfg.GetAlpha()
+	if alpha == 0 {
+		alpha = defaultAlpha
+	}
+	if alpha < 0 || alpha > 1 {
+		violations = append(violations, rpcerror.Violation("config.alpha", "must be in (0, 1]"))
+	}
+	if _, ok := calculatorpb.AggregateConfig_Aggregate_name[int32(cfg.GetAggregate())]; !ok {
+		violations = append(violations, rpcerror.Violation("config.aggregate", fmt.Sprintf("unknown aggregate %v", cfg.GetAggregate())))
+	}
+	if len(violations) > 0 {
+		return nil, rpcerror.InvalidArgument("INVALID_AGGREGATE_CONFIG", violations...)
+	}
+
+	return &aggregator{
+		kind:  cfg.GetAggregate(),
+		size:  int(cfg.GetWindowSize()),
+		age:   time.Duration(cfg.GetWindowMillis()) * time.Millisecond,
+		alpha: alpha,
+	}, nil
+}
+
+// add puts number in the window, evicts the samples that fall out of it
+// and returns the aggregate of what is left with the window length
+func (a *aggregator) add(number float64, now time.Time) (float64, int) {
+	a.samples = append(a.samples, sample{number: number, at: now})
+
+	if a.size > 0 && len(a.samples) > a.size {
+		a.samples = a.samples[len(a.samples)-a.size:]
+	}
+	if a.age > 0 {
+		i := 0
+		for i < len(a.samples)-1 && now.Sub(a.samples[i].at) > a.age {
+			i++
+		}
+		a.samples = a.samples[i:]
+	}
+
+	return a.value(), len(a.samples)
+}
+
+func (a *aggregator) value() float64 {
+	switch a.kind {
+	case calculatorpb.AggregateConfig_MIN:
+		min := math.Inf(1)
+		for _, s := range a.samples {
+			min = math.Min(min, s.number)
+		}
+		return min
+	case calculatorpb.AggregateConfig_SUM:
+		return a.sum()
+	case calculatorpb.AggregateConfig_MEAN:
+		return a.sum() / float64(len(a.samples))
+	case calculatorpb.AggregateConfig_EWMA:
+		ewma := a.samples[0].number
+		for _, s := range a.samples[1:] {
+			ewma = a.alpha*s.number + (1-a.alpha)*ewma
+		}
+		return ewma
+	default:
+		max := math.Inf(-1)
+		for _, s := range a.samples {
+			max = math.Max(max, s.number)
+		}
+		return max
+	}
+}
+
+func (a *aggregator) sum() float64 {
+	sum := 0.0
+	for _, s := range a.samples {
+		sum += s.number
+	}
+	return sum
+}
@@ -0,0 +1,73 @@
+package calculatorservice
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+	"github.com/christiangda/grpc-go-course/validate"
+)
+
+func (s *Server) Batch(ctx context.Context, req *calculatorpb.BatchRequest) (*calculatorpb.BatchResponse, error) {
+	operations := req.GetOperations()
+	logging.Infof("Received Batch RPC with %v operations", len(operations))
+
+	if s.maxBatchSize > 0 && len(operations) > s.maxBatchSize {
+		return nil, rpcerror.InvalidArgument("BATCH_TOO_LARGE",
+			rpcerror.Violation("operations", fmt.Sprintf("must have at most %v operations, received %v", s.maxBatchSize, len(operations))))
+	}
+
+	results := make([]*calculatorpb.BatchResult, len(operations))
+
+	// sem bounds the number of operations running at the same time
+	sem := make(chan struct{}, s.batchParallelism)
+	var wg sync.WaitGroup
+	for i, op := range operations {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		wg.Add(1)
+		go func(i int, op *calculatorpb.BatchOperation) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = s.runBatchOperation(ctx, op)
+		}(i, op)
+	}
+	wg.Wait()
+
+	return &calculatorpb.BatchResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *Server) runBatchOperation(ctx context.Context, op *calculatorpb.BatchOperation) *calculatorpb.BatchResult {
+	// the operations skip the validate interceptor, see BatchRequest
+	err := validate.Message(op)
+	switch {
+	case err != nil:
+	case op.GetSum() != nil:
+		var res *calculatorpb.SumResponse
+		if res, err = s.Sum(ctx, op.GetSum()); err == nil {
+			return &calculatorpb.BatchResult{Result: &calculatorpb.BatchResult_Sum{Sum: res}}
+		}
+	case op.GetSquareRoot() != nil:
+		var res *calculatorpb.SquareRootResponse
+		if res, err = s.SquareRoot(ctx, op.GetSquareRoot()); err == nil {
+			return &calculatorpb.BatchResult{Result: &calculatorpb.BatchResult_SquareRoot{SquareRoot: res}}
+		}
+	default:
+		err = rpcerror.InvalidArgument("EMPTY_OPERATION", rpcerror.Violation("operation", "must be set"))
+	}
+
+	return &calculatorpb.BatchResult{
+		Result: &calculatorpb.BatchResult_Error{Error: status.Convert(err).Proto()},
+	}
+}
@@ -0,0 +1,197 @@
+// Package calculatorservice implements calculatorpb.CalculatorServiceServer
+// so it can be served by the calculator server or together with other
+// services.
+package calculatorservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+	"github.com/christiangda/grpc-go-course/streaming"
+)
+
+// Config is the configuration of the calculator service
+type Config struct {
+	// BatchParallelism bounds the operations of a Batch RPC running at once
+	BatchParallelism int `yaml:"batch_parallelism"`
+	// MaxBatchSize is the max number of operations in a Batch RPC, 0 means unbounded
+	MaxBatchSize int `yaml:"max_batch_size"`
+	// Pipeline processes the messages of FindMaximum streams
+	Pipeline streaming.Pipeline `yaml:"pipeline"`
+}
+
+// DefaultConfig is the configuration of the calculator server flags
+var DefaultConfig = Config{
+	BatchParallelism: runtime.NumCPU(),
+	MaxBatchSize:     10000,
+	Pipeline:         streaming.Pipeline{Workers: 1, Depth: 16},
+}
+
+// Server implements calculatorpb.CalculatorServiceServer
+type Server struct {
+	batchParallelism int
+	maxBatchSize     int
+	pipeline         streaming.Pipeline
+}
+
+// New returns a Server configured by cfg
+func New(cfg Config) (*Server, error) {
+	if cfg.BatchParallelism < 1 {
+		return nil, fmt.Errorf("batch parallelism must be >= 1, got %v", cfg.BatchParallelism)
+	}
+	return &Server{
+		batchParallelism: cfg.BatchParallelism,
+		maxBatchSize:     cfg.MaxBatchSize,
+		pipeline:         cfg.Pipeline,
+	}, nil
+}
+
+func (s *Server) Sum(ctx context.Context, req *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
+	logging.Infof("Received Sum RPC: %v", req)
+	firstNumber := req.FirstNumber
+	secondNumber := req.SecondNumber
+	sum := firstNumber + secondNumber
+	res := &calculatorpb.SumResponse{
+		SumResult: sum,
+	}
+	return res, nil
+}
+
+func (s *Server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
+	logging.Infof("Received PrimeNumberDecomposition RPC: %v", req)
+	number := req.GetNumber()
+	divisor := int64(2)
+
+	// the state is the part of the number left to decompose and the divisor
+	state, err := resume.State(req, 2)
+	if err != nil {
+		return err
+	}
+	if state != nil {
+		number, divisor = state[0], state[1]
+		// the divisors tried stay below the square root of the number,
+		// unless the rest of it is a prime already sent
+		if number < 1 || divisor < 2 || req.GetNumber()%number != 0 || (number > 1 && divisor > req.GetNumber()/divisor) {
+			return rpcerror.InvalidArgument(resume.Reason, rpcerror.Violation("resume_token", "has an unexpected state"))
+		}
+	}
+
+	for number > 1 {
+		if divisor > number/divisor {
+			// no divisor left below its square root, what remains is prime
+			divisor = number
+		}
+		if number%divisor == 0 {
+			number = number / divisor
+			err := stream.Send(&calculatorpb.PrimeNumberDecompositionResponse{
+				PrimeFactor: divisor,
+				ResumeToken: resume.Token(req, number, divisor),
+			})
+			if err != nil {
+				return err
+			}
+		} else {
+			divisor++
+		}
+	}
+	return nil
+}
+
+func (s *Server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
+	logging.Infof("Received ComputeAverage RPC")
+
+	sum := int32(0)
+	count := 0
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			average := float64(sum) / float64(count)
+			return stream.SendAndClose(&calculatorpb.ComputeAverageResponse{
+				Average: average,
+			})
+		}
+		if err != nil {
+			logging.Warningf("Error while reading client streaming: %v", err)
+			return err
+		}
+		sum += req.GetNumber()
+		count++
+	}
+}
+
+func (s *Server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServer) error {
+	logging.Infof("Received Findmaximun RPC")
+	maximum := int32(0)
+	received := false
+
+	// numbers need no work, the pipeline only keeps the backpressure
+	return streaming.Run(stream.Context(), s.pipeline, stream.Recv,
+		func(ctx context.Context, req *calculatorpb.FindMaximumRequest) (int32, bool, error) {
+			return req.GetNumber(), true, nil
+		},
+		func(number int32) error {
+			if received && number <= maximum {
+				return nil
+			}
+			received = true
+			maximum = number
+			return stream.Send(&calculatorpb.FindMaximumResponse{
+				Maximum: maximum,
+			})
+		},
+	)
+}
+
+func (s *Server) RunningAggregate(stream calculatorpb.CalculatorService_RunningAggregateServer) error {
+	logging.Infof("Received RunningAggregate RPC")
+	var agg *aggregator
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if agg == nil {
+			agg, err = newAggregator(req.GetConfig())
+			if err != nil {
+				return err
+			}
+		}
+		value, count := agg.add(req.GetNumber(), time.Now())
+		sendErr := stream.Send(&calculatorpb.RunningAggregateResponse{
+			Value: value,
+			Count: int32(count),
+		})
+		if sendErr != nil {
+			return sendErr
+		}
+	}
+}
+
+func (s *Server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
+	logging.Infof("Received SquareRoot RPC")
+	number := req.GetNumber()
+
+	// also checked here, the validate interceptor is optional in the suite
+	if number < 0 {
+		return nil, rpcerror.InvalidArgument("NEGATIVE_NUMBER",
+			rpcerror.Violation("number", fmt.Sprintf("must be >= 0, received a negative number: %v", number)))
+	}
+
+	return &calculatorpb.SquareRootResponse{
+		NumberRoot: math.Sqrt(float64(number)),
+	}, nil
+
+}
@@ -0,0 +1,220 @@
+// Package client dials our servers for the greetclient and calculatorclient
+// packages, with the TLS, authentication, retry, balancing, compression and
+// keepalive options they share.
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/christiangda/grpc-go-course/compression"
+	"github.com/christiangda/grpc-go-course/idempotency"
+	"github.com/christiangda/grpc-go-course/loadbalancing"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+	"github.com/christiangda/grpc-go-course/streaming"
+	"github.com/christiangda/grpc-go-course/transport"
+)
+
+// Conn is a connection to our servers
+type Conn struct {
+	*grpc.ClientConn
+	// Backoff paces the retries of unary calls and the reconnections of
+	// resumable streams
+	Backoff resume.Backoff
+}
+
+// Option configures Dial
+type Option func(*options) error
+
+type options struct {
+	creds         credentials.TransportCredentials
+	token         string
+	insecureToken bool
+	retry         bool
+	backoff       resume.Backoff
+	balancing     loadbalancing.Config
+	compression   compression.Config
+	keepalive     streaming.ClientKeepalive
+	dialOpts      []grpc.DialOption
+}
+
+// WithTLS connects with TLS trusting the certificate authority in caFile
+func WithTLS(caFile string) Option {
+	return func(o *options) error {
+		creds, err := credentials.NewClientTLSFromFile(caFile, "")
+		if err != nil {
+			return err
+		}
+		o.creds = creds
+		return nil
+	}
+}
+
+// WithTransportCredentials connects with creds
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(o *options) error {
+		o.creds = creds
+		return nil
+	}
+}
+
+// WithToken sends token as "authorization: Bearer <token>" on every call,
+// the calls fail unless the connection uses TLS
+func WithToken(token string) Option {
+	return func(o *options) error {
+		o.token = token
+		return nil
+	}
+}
+
+// WithInsecureToken sends token as WithToken does, also over connections
+// without TLS, for servers on a loopback address or a unix socket
+func WithInsecureToken(token string) Option {
+	return func(o *options) error {
+		o.token = token
+		o.insecureToken = true
+		return nil
+	}
+}
+
+// WithRetry retries the unary calls failing with Unavailable, or throttled
+// with a RetryInfo, waiting as b says or as long as the server asked. Every
+// attempt of a call sends the same idempotency key, a random one unless the
+// context has one, so the server runs it once.
+func WithRetry(b resume.Backoff) Option {
+	return func(o *options) error {
+		o.retry = true
+		o.backoff = b
+		return nil
+	}
+}
+
+// WithBalancing balances the calls over the addresses of the target as cfg
+// says, loadbalancing.DefaultConfig by default
+func WithBalancing(cfg loadbalancing.Config) Option {
+	return func(o *options) error {
+		o.balancing = cfg
+		return nil
+	}
+}
+
+// WithCompression compresses the requests as cfg says
+func WithCompression(cfg compression.Config) Option {
+	return func(o *options) error {
+		o.compression = cfg
+		return cfg.Validate()
+	}
+}
+
+// WithKeepalive pings the server as k says,
+// streaming.DefaultClientKeepalive by default
+func WithKeepalive(k streaming.ClientKeepalive) Option {
+	return func(o *options) error {
+		o.keepalive = k
+		return nil
+	}
+}
+
+// WithDialOptions adds grpc options, applied after the others
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) error {
+		o.dialOpts = append(o.dialOpts, opts...)
+		return nil
+	}
+}
+
+// Dial connects to target: host:port, a comma separated list of them,
+// dns:///name, unix:///path, file:///path of a targets file or
+// memory://name. Without WithTLS the connection is not encrypted.
+func Dial(target string, opts ...Option) (*Conn, error) {
+	o := &options{
+		creds:     insecure.NewCredentials(),
+		backoff:   resume.DefaultBackoff,
+		balancing: loadbalancing.DefaultConfig,
+		keepalive: streaming.DefaultClientKeepalive,
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	lbOpts, err := o.balancing.DialOptions()
+	if err != nil {
+		return nil, err
+	}
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(o.creds)}
+	dialOpts = append(dialOpts, lbOpts...)
+	dialOpts = append(dialOpts, o.keepalive.DialOptions()...)
+	dialOpts = append(dialOpts, o.compression.DialOptions()...)
+	dialOpts = append(dialOpts, transport.DialOptions(target)...)
+	if o.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenCredentials{token: o.token, insecure: o.insecureToken}))
+	}
+	if o.retry {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(retryInterceptor(o.backoff)))
+	}
+	dialOpts = append(dialOpts, o.dialOpts...)
+
+	cc, err := grpc.Dial(loadbalancing.Target(target), dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{ClientConn: cc, Backoff: o.backoff}, nil
+}
+
+// tokenCredentials sends a bearer token, only over TLS unless insecure
+type tokenCredentials struct {
+	token    string
+	insecure bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return !t.insecure
+}
+
+// retryable reports whether a unary call failing with err may be retried
+func retryable(err error) bool {
+	switch rpcerror.FromError(err).(*rpcerror.Error).Code {
+	case codes.Unavailable:
+		return true
+	case codes.ResourceExhausted:
+		_, ok := rpcerror.RetryDelay(err)
+		return ok
+	}
+	return false
+}
+
+func retryInterceptor(b resume.Backoff) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !idempotency.HasKey(ctx) {
+			ctx = idempotency.WithKey(ctx, idempotency.NewKey())
+		}
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !retryable(err) || (b.MaxAttempts > 0 && attempt > b.MaxAttempts) {
+				return err
+			}
+
+			delay := b.Delay(attempt)
+			if d, ok := rpcerror.RetryDelay(err); ok && d > delay {
+				delay = d
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return err
+			}
+		}
+	}
+}
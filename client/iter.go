@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// errStopped ends a stream whose iteration stopped early
+var errStopped = errors.New("iteration stopped")
+
+// Resume yields the messages of a resumable server stream opened with open,
+// reopening it as b says when it breaks, then the error ending it, if any.
+// Stopping the iteration cancels the stream.
+func Resume[T resume.Message](ctx context.Context, b resume.Backoff, open func(ctx context.Context, token string) (resume.Stream[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		err := resume.Receive(ctx, b, open, func(msg T) error {
+			if !yield(msg, nil) {
+				return errStopped
+			}
+			return nil
+		})
+		if err != nil && err != errStopped {
+			var zero T
+			yield(zero, rpcerror.FromError(err))
+		}
+	}
+}
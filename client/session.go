@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"io"
+	"iter"
+	"sync"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// BidiStream is a bidirectional stream of generated code
+type BidiStream[Req, Res any] interface {
+	Send(Req) error
+	Recv() (Res, error)
+	CloseSend() error
+	Context() context.Context
+}
+
+// Session sends the inputs of a bidirectional stream and delivers its
+// outputs on a channel
+type Session[In, Out any] struct {
+	send      func(In) error
+	closeSend func() error
+	out       chan Out
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewSession starts receiving from stream, converting requests from In with
+// toReq and responses to Out with fromRes
+func NewSession[In, Out, Req, Res any](stream BidiStream[Req, Res], toReq func(In) Req, fromRes func(Res) Out) *Session[In, Out] {
+	s := &Session[In, Out]{
+		send:      func(in In) error { return stream.Send(toReq(in)) },
+		closeSend: stream.CloseSend,
+		out:       make(chan Out),
+	}
+	go func() {
+		defer close(s.out)
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				s.fail(err)
+				return
+			}
+			// nobody drains Out once the context of the session is done
+			select {
+			case s.out <- fromRes(res):
+			case <-stream.Context().Done():
+				s.fail(status.FromContextError(stream.Context().Err()).Err())
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// fail records err as the error ending the stream
+func (s *Session[In, Out]) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = rpcerror.FromError(err)
+}
+
+// Send sends in, it returns io.EOF when the stream ended and Err tells why
+// once Out is closed
+func (s *Session[In, Out]) Send(in In) error {
+	if err := s.send(in); err != nil {
+		if err == io.EOF {
+			return err
+		}
+		return rpcerror.FromError(err)
+	}
+	return nil
+}
+
+// Close tells the server there is nothing more to send, Out is closed once
+// it answered everything
+func (s *Session[In, Out]) Close() error {
+	return s.closeSend()
+}
+
+// Out delivers the outputs until the stream ends, it must be drained or the
+// context of the session canceled
+func (s *Session[In, Out]) Out() <-chan Out {
+	return s.out
+}
+
+// Err is the error that ended the stream, nil when it ended normally, once
+// Out is closed
+func (s *Session[In, Out]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// All yields the outputs then the error ending the stream, if any
+func (s *Session[In, Out]) All() iter.Seq2[Out, error] {
+	return func(yield func(Out, error) bool) {
+		for out := range s.out {
+			if !yield(out, nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			var zero Out
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Package compression negotiates the compression of messages between our
+// servers and clients. Importing it registers the gzip and zstd compressors,
+// so clients advertise them in grpc-accept-encoding and servers answer with
+// the first one of their preference the client accepts.
+package compression
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // registers gzip
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	protov1 "github.com/golang/protobuf/proto"
+)
+
+// The supported algorithms, Identity means no compression
+const (
+	Gzip     = "gzip"
+	Zstd     = "zstd"
+	Identity = encoding.Identity
+)
+
+// Config is the compression of a server or a client
+type Config struct {
+	// Algorithms in order of preference. Servers answer with the first one
+	// the client accepts, clients compress requests with the first one the
+	// server did not reject. Empty disables compression.
+	Algorithms []string `yaml:"algorithms"`
+	// MinSize is the size in bytes under which messages are sent
+	// uncompressed. Streams decide on their first message.
+	MinSize int `yaml:"min_size"`
+}
+
+// Validate checks every algorithm is registered
+func (c Config) Validate() error {
+	for _, name := range c.Algorithms {
+		if name != Identity && encoding.GetCompressor(name) == nil {
+			return fmt.Errorf("unknown compression %q, want %s or %s", name, Gzip, Zstd)
+		}
+	}
+	return nil
+}
+
+// RegisterFlags binds the fields of c to command line flags
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Func("compression", "comma separated compressions in order of preference: gzip, zstd", func(s string) error {
+		c.Algorithms = nil
+		for _, name := range strings.Split(s, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				c.Algorithms = append(c.Algorithms, name)
+			}
+		}
+		return c.Validate()
+	})
+	fs.IntVar(&c.MinSize, "compression-min-size", c.MinSize, "messages smaller than this many bytes are sent uncompressed")
+}
+
+// Off is a call option sending the request of a call uncompressed
+func Off() grpc.CallOption {
+	return grpc.UseCompressor(Identity)
+}
+
+// size returns the encoded size of m, -1 when m is not a message
+func size(m interface{}) int {
+	if msg, ok := m.(protov1.Message); ok {
+		return proto.Size(protov1.MessageV2(msg))
+	}
+	return -1
+}
+
+// ServerOptions returns the interceptors choosing the compression of the
+// responses of a server
+func (c Config) ServerOptions() []grpc.ServerOption {
+	if len(c.Algorithms) == 0 {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(c.unaryServer),
+		grpc.ChainStreamInterceptor(c.streamServer),
+	}
+}
+
+// negotiate sets the compression of the responses of the call in ctx for a
+// first message of n bytes
+func (c Config) negotiate(ctx context.Context, n int) {
+	name := Identity
+	if n >= c.MinSize {
+		accepted, _ := grpc.ClientSupportedCompressors(ctx)
+	pick:
+		for _, want := range c.Algorithms {
+			for _, have := range accepted {
+				if want == have {
+					name = want
+					break pick
+				}
+			}
+		}
+	}
+	// it only fails once the headers are sent, the call then keeps its
+	// compression
+	grpc.SetSendCompressor(ctx, name)
+}
+
+func (c Config) unaryServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err == nil {
+		c.negotiate(ctx, size(res))
+	}
+	return res, err
+}
+
+func (c Config) streamServer(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &negotiatingStream{ServerStream: ss, config: c})
+}
+
+type negotiatingStream struct {
+	grpc.ServerStream
+	config Config
+	once   sync.Once
+}
+
+func (s *negotiatingStream) SendMsg(m interface{}) error {
+	s.once.Do(func() { s.config.negotiate(s.Context(), size(m)) })
+	return s.ServerStream.SendMsg(m)
+}
+
+// DialOptions returns the interceptors compressing the requests of a client.
+// A grpc.UseCompressor call option, or Off, overrides them for a call.
+func (c Config) DialOptions() []grpc.DialOption {
+	if len(c.Algorithms) == 0 {
+		return nil
+	}
+	cc := &clientCompression{config: c, rejected: map[string]bool{}}
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(cc.unary),
+		grpc.WithChainStreamInterceptor(cc.stream),
+	}
+}
+
+// clientCompression remembers the algorithms a server rejected
+type clientCompression struct {
+	config   Config
+	mu       sync.Mutex
+	rejected map[string]bool
+}
+
+func (c *clientCompression) algorithm() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, name := range c.config.Algorithms {
+		if !c.rejected[name] {
+			return name
+		}
+	}
+	return Identity
+}
+
+// isRejection reports whether err is a server refusing the compression
+func isRejection(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.Unimplemented && strings.Contains(st.Message(), "grpc-encoding")
+}
+
+func hasCompressor(opts []grpc.CallOption) bool {
+	for _, o := range opts {
+		if _, ok := o.(grpc.CompressorCallOption); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *clientCompression) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if hasCompressor(opts) || size(req) < c.config.MinSize {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	name := c.algorithm()
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.UseCompressor(name))...)
+	if name != Identity && isRejection(err) {
+		// the request was not processed, try again without this algorithm
+		c.mu.Lock()
+		c.rejected[name] = true
+		c.mu.Unlock()
+		return c.unary(ctx, method, req, reply, cc, invoker, opts...)
+	}
+	return err
+}
+
+func (c *clientCompression) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if hasCompressor(opts) {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	// the size of the messages is unknown when the stream opens, streams of
+	// small requests should be opened with Off
+	return streamer(ctx, desc, cc, method, append(opts, grpc.UseCompressor(c.algorithm()))...)
+}
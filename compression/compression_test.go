@@ -0,0 +1,192 @@
+package compression_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/christiangda/grpc-go-course/compression"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/greetservice"
+)
+
+const (
+	// streamMessages is the messages sent on each stream
+	streamMessages = 1000
+	// pageSize is the greetings listed by each ListGreetings call
+	pageSize = 1000
+	// minSize is the compression threshold, streams decide on their first
+	// message
+	minSize = 64
+)
+
+// The benchmarks report the bytes the client sends and receives on the wire
+// for each compression, HTTP/2 framing included:
+//
+//	go test -run '^$' -bench . ./compression
+
+func BenchmarkLongGreet(b *testing.B) {
+	benchmarkWire(b, func(c greetpb.GreetServiceClient) error {
+		return longGreet(c, streamMessages)
+	})
+}
+
+func BenchmarkGreetEveryone(b *testing.B) {
+	benchmarkWire(b, func(c greetpb.GreetServiceClient) error {
+		return greetEveryone(c, streamMessages)
+	})
+}
+
+func BenchmarkListGreetings(b *testing.B) {
+	benchmarkWire(b, func(c greetpb.GreetServiceClient) error {
+		_, err := c.ListGreetings(context.Background(), &greetpb.ListGreetingsRequest{PageSize: pageSize})
+		return err
+	})
+}
+
+// benchmarkWire runs call b.N times with every compression, against a greet
+// service holding a page of greetings in its history
+func benchmarkWire(b *testing.B, call func(greetpb.GreetServiceClient) error) {
+	// the greet service logs every call
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	for _, algorithm := range []string{compression.Identity, compression.Gzip, compression.Zstd} {
+		b.Run(algorithm, func(b *testing.B) {
+			cfg := compression.Config{MinSize: minSize}
+			if algorithm != compression.Identity {
+				cfg.Algorithms = []string{algorithm}
+			}
+			c, lis := newClient(b, cfg)
+			// the connection setup and the history are not part of the
+			// measures
+			if err := longGreet(c, pageSize); err != nil {
+				b.Fatal(err)
+			}
+
+			read, written := lis.read.Load(), lis.written.Load()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := call(c); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(lis.read.Load()-read)/float64(b.N), "sent-B/op")
+			b.ReportMetric(float64(lis.written.Load()-written)/float64(b.N), "received-B/op")
+		})
+	}
+}
+
+// newClient serves the greet service with cfg in process and returns a
+// client compressing with cfg too, with the listener counting their traffic
+func newClient(b *testing.B, cfg compression.Config) (greetpb.GreetServiceClient, *countingListener) {
+	b.Helper()
+	svc, err := greetservice.New(greetservice.DefaultConfig)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { svc.Close() })
+
+	l := bufconn.Listen(1 << 20)
+	lis := &countingListener{Listener: l}
+	s := grpc.NewServer(cfg.ServerOptions()...)
+	greetpb.RegisterGreetServiceServer(s, svc)
+	go s.Serve(lis)
+	b.Cleanup(s.Stop)
+
+	dial := func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }
+	cc, err := grpc.Dial("bufconn", append(cfg.DialOptions(), grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { cc.Close() })
+	return greetpb.NewGreetServiceClient(cc), lis
+}
+
+func greeting(i int) *greetpb.Greeting {
+	return &greetpb.Greeting{
+		FirstName: fmt.Sprintf("Christian %d", i),
+		LastName:  "Gonzalez",
+	}
+}
+
+func longGreet(c greetpb.GreetServiceClient, n int) error {
+	stream, err := c.LongGreet(context.Background())
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err := stream.Send(&greetpb.LongGreetRequest{Greeting: greeting(i)}); err != nil {
+			break
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func greetEveryone(c greetpb.GreetServiceClient, n int) error {
+	stream, err := c.GreetEveryone(context.Background())
+	if err != nil {
+		return err
+	}
+	go func() {
+		for i := 0; i < n; i++ {
+			if err := stream.Send(&greetpb.GreetEveryoneRequest{Greeting: greeting(i)}); err != nil {
+				break
+			}
+		}
+		stream.CloseSend()
+	}()
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// countingListener counts the bytes read and written by the server on every
+// connection
+type countingListener struct {
+	net.Listener
+	read    atomic.Int64
+	written atomic.Int64
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &countingConn{Conn: conn, l: l}, nil
+}
+
+type countingConn struct {
+	net.Conn
+	l *countingListener
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.l.read.Add(int64(n))
+	return n, err
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.l.written.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,79 @@
+package compression
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// zstdCompressor implements encoding.Compressor, encoders and decoders are
+// pooled since they are expensive to create
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func (c *zstdCompressor) Name() string {
+	return Zstd
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc, ok := c.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		if enc, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	} else {
+		enc.Reset(w)
+	}
+	return &zstdWriter{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, ok := c.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		if dec, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	} else if err := dec.Reset(r); err != nil {
+		return nil, err
+	}
+	return &zstdReader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return err
+}
+
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+// Read returns the decoder to the pool once the message is read
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
@@ -0,0 +1,42 @@
+// Package descriptors embeds the descriptor set of our services, written by
+// generate.sh, so tools can discover them even when a server does not serve
+// reflection. The same file works with grpcurl:
+//
+//	grpcurl -protoset descriptors/services.protoset localhost:50051 list
+package descriptors
+
+import (
+	_ "embed" // for the protoset
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+//go:embed services.protoset
+var protoset []byte
+
+// Protoset returns the serialized FileDescriptorSet, with every import
+func Protoset() []byte {
+	return protoset
+}
+
+// Set returns the FileDescriptorSet
+func Set() (*descriptorpb.FileDescriptorSet, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(protoset, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Files returns the files of the set linked together, to look services and
+// messages up by name
+func Files() (*protoregistry.Files, error) {
+	set, err := Set()
+	if err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(set)
+}
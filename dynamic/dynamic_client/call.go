@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails" // to print error details
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// caller invokes a method with dynamic messages
+type caller struct {
+	cc      *grpc.ClientConn
+	src     source
+	data    string
+	verbose bool
+}
+
+func (c *caller) call(ctx context.Context, method string) error {
+	md, err := c.findMethod(ctx, method)
+	if err != nil {
+		return err
+	}
+	types := resolver{dynamicpb.NewTypes(c.src.files())}
+	unmarshal := protojson.UnmarshalOptions{Resolver: types}
+	marshal := protojson.MarshalOptions{Multiline: true, Indent: "  ", Resolver: types}
+
+	desc := &grpc.StreamDesc{
+		StreamName:    string(md.Name()),
+		ClientStreams: md.IsStreamingClient(),
+		ServerStreams: md.IsStreamingServer(),
+	}
+	fullMethod := fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.cc.NewStream(ctx, desc, fullMethod)
+	if err != nil {
+		return err
+	}
+
+	// requests are sent while responses are received, for bidi streams
+	sendErr := make(chan error, 1)
+	go func() {
+		err := c.send(stream, md, unmarshal)
+		if err != nil && err != io.EOF {
+			// io.EOF means the server ended the call, the status is received
+			cancel()
+		}
+		sendErr <- err
+	}()
+
+	for {
+		res := dynamicpb.NewMessage(md.Output())
+		err := stream.RecvMsg(res)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.printMetadata(stream)
+			select {
+			case sErr := <-sendErr:
+				if sErr != nil && sErr != io.EOF {
+					return sErr
+				}
+			default:
+			}
+			return err
+		}
+		b, err := marshal.Marshal(res)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+	c.printMetadata(stream)
+
+	// a request the server never read does not fail the call
+	if err := <-sendErr; err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// send sends the -d request, or the NDJSON requests of stdin for client
+// streams, then closes the sending side
+func (c *caller) send(stream grpc.ClientStream, md protoreflect.MethodDescriptor, unmarshal protojson.UnmarshalOptions) error {
+	next := c.requests(md.IsStreamingClient())
+	for n := 1; ; n++ {
+		body, err := next()
+		if err == io.EOF {
+			return stream.CloseSend()
+		}
+		if err != nil {
+			return err
+		}
+		req := dynamicpb.NewMessage(md.Input())
+		if err := unmarshal.Unmarshal([]byte(body), req); err != nil {
+			return fmt.Errorf("request %d is not a valid %s: %v", n, md.Input().FullName(), err)
+		}
+		if err := stream.SendMsg(req); err != nil {
+			return err
+		}
+	}
+}
+
+// requests returns the JSON bodies to send one by one, then io.EOF
+func (c *caller) requests(streaming bool) func() (string, error) {
+	if streaming {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		return func() (string, error) {
+			for scanner.Scan() {
+				if line := strings.TrimSpace(scanner.Text()); line != "" {
+					return line, nil
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.EOF
+		}
+	}
+
+	sent := false
+	return func() (string, error) {
+		if sent {
+			return "", io.EOF
+		}
+		sent = true
+		if c.data != "-" {
+			return c.data, nil
+		}
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	}
+}
+
+// findMethod accepts package.Service/Method and package.Service.Method
+func (c *caller) findMethod(ctx context.Context, method string) (protoreflect.MethodDescriptor, error) {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", "."))
+	sd, err := findService(ctx, c.src, string(name.Parent()))
+	if err != nil {
+		return nil, err
+	}
+	md := sd.Methods().ByName(name.Name())
+	if md == nil {
+		return nil, fmt.Errorf("service %s has no method %s", sd.FullName(), name.Name())
+	}
+	return md, nil
+}
+
+func (c *caller) printMetadata(stream grpc.ClientStream) {
+	if !c.verbose {
+		return
+	}
+	if h, err := stream.Header(); err == nil {
+		printMD("header", h)
+	}
+	printMD("trailer", stream.Trailer())
+}
+
+func printMD(kind string, md metadata.MD) {
+	for k, vs := range md {
+		for _, v := range vs {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", kind, k, v)
+		}
+	}
+}
+
+// printError prints err with the details of its status as JSON
+func printError(err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "ERROR:\n  Code: %v\n  Message: %s\n", st.Code(), st.Message())
+	if len(st.Proto().GetDetails()) == 0 {
+		return
+	}
+	b, mErr := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(st.Proto())
+	if mErr == nil {
+		fmt.Fprintf(os.Stderr, "  Status: %s\n", b)
+	}
+}
+
+// resolver finds the message types of the server first, then the ones linked
+// in the binary like the error details
+type resolver struct {
+	*dynamicpb.Types
+}
+
+func (r resolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	if mt, err := r.Types.FindMessageByName(name); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(name)
+}
+
+func (r resolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	if mt, err := r.Types.FindMessageByURL(url); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByURL(url)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/christiangda/grpc-go-course/compression"
+	"github.com/christiangda/grpc-go-course/streaming"
+	"github.com/christiangda/grpc-go-course/transport"
+)
+
+const usage = `Calls any method of a gRPC server from its descriptors.
+
+Usage:
+  dynamic_client [flags] list [SERVICE]   list the services, or the methods of SERVICE
+  dynamic_client [flags] describe SYMBOL  describe a service, method or message
+  dynamic_client [flags] call METHOD      call METHOD, as package.Service/Method
+
+Unary and server streaming calls send the -d JSON body. Client and bidi
+streaming calls read one JSON request per line from stdin. Responses are
+printed as JSON.
+
+Flags:
+`
+
+// headers collects the repeated -H flags
+type headers []string
+
+func (h *headers) String() string     { return strings.Join(*h, ", ") }
+func (h *headers) Set(v string) error { *h = append(*h, v); return nil }
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "address of the server, host:port or unix:///path")
+	useTLS := flag.Bool("tls", false, "connect with TLS")
+	caFile := flag.String("ca", "ssl/ca.crt", "certificate authority trusted with -tls")
+	protoset := flag.String("protoset", "", "FileDescriptorSet describing the services, \"embedded\" for the one built in, instead of server reflection")
+	data := flag.String("d", "{}", "JSON request of unary and server streaming calls, - reads it from stdin")
+	timeout := flag.Duration("timeout", 0, "deadline of the call, 0 for none")
+	verbose := flag.Bool("v", false, "print the response headers and trailers")
+	compress := flag.String("compress", "", "compress the requests with gzip or zstd, the responses are compressed as the server chooses")
+	keepalive := streaming.DefaultClientKeepalive
+	keepalive.RegisterFlags(flag.CommandLine)
+	var hdrs headers
+	flag.Var(&hdrs, "H", "request metadata as \"key: value\", can be repeated")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(0)
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := grpc.WithTransportCredentials(insecure.NewCredentials())
+	if *useTLS {
+		creds, sslErr := credentials.NewClientTLSFromFile(*caFile, "")
+		if sslErr != nil {
+			log.Fatalf("Error while loading CA trust certificate: %v", sslErr)
+		}
+		opts = grpc.WithTransportCredentials(creds)
+	}
+	dialOpts := []grpc.DialOption{opts}
+	if *compress != "" {
+		if err := (compression.Config{Algorithms: []string{*compress}}).Validate(); err != nil {
+			log.Fatal(err)
+		}
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(*compress)))
+	}
+	dialOpts = append(dialOpts, keepalive.DialOptions()...)
+	dialOpts = append(dialOpts, transport.DialOptions(*addr)...)
+	cc, err := grpc.Dial(*addr, dialOpts...)
+	if err != nil {
+		log.Fatalf("could not connect: %v", err)
+	}
+	defer cc.Close()
+
+	var src source
+	if *protoset != "" {
+		if src, err = newFileSource(*protoset); err != nil {
+			log.Fatalf("could not load descriptors: %v", err)
+		}
+	} else {
+		src = newReflectionSource(cc)
+	}
+
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	md := metadata.MD{}
+	for _, h := range hdrs {
+		k, v, ok := strings.Cut(h, ":")
+		if !ok {
+			log.Fatalf("header %q is not \"key: value\"", h)
+		}
+		md.Append(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+	// the headers also go with the reflection calls, for servers requiring auth
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	switch {
+	case args[0] == "list" && len(args) <= 2:
+		err = list(ctx, src, args[1:])
+	case args[0] == "describe" && len(args) == 2:
+		err = describe(ctx, src, args[1])
+	case args[0] == "call" && len(args) == 2:
+		c := &caller{cc: cc, src: src, data: *data, verbose: *verbose}
+		err = c.call(ctx, args[1])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		printError(err)
+		os.Exit(1)
+	}
+}
+
+// list prints the services, or the methods of a service
+func list(ctx context.Context, src source, args []string) error {
+	if len(args) == 0 {
+		names, err := src.services(ctx)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	sd, err := findService(ctx, src, args[0])
+	if err != nil {
+		return err
+	}
+	for i := 0; i < sd.Methods().Len(); i++ {
+		fmt.Println(sd.Methods().Get(i).FullName())
+	}
+	return nil
+}
+
+// describe prints a service, method or message like in its .proto file
+func describe(ctx context.Context, src source, symbol string) error {
+	d, err := src.find(ctx, protoreflect.FullName(strings.ReplaceAll(symbol, "/", ".")))
+	if err != nil {
+		return err
+	}
+
+	switch d := d.(type) {
+	case protoreflect.ServiceDescriptor:
+		fmt.Printf("service %s {\n", d.FullName())
+		for i := 0; i < d.Methods().Len(); i++ {
+			fmt.Printf("  %s\n", signature(d.Methods().Get(i)))
+		}
+		fmt.Println("}")
+	case protoreflect.MethodDescriptor:
+		fmt.Println(signature(d))
+	case protoreflect.MessageDescriptor:
+		fmt.Printf("message %s {\n", d.FullName())
+		for i := 0; i < d.Fields().Len(); i++ {
+			f := d.Fields().Get(i)
+			fmt.Printf("  %s%s %s = %d;\n", label(f), typeName(f), f.Name(), f.Number())
+		}
+		fmt.Println("}")
+	case protoreflect.EnumDescriptor:
+		fmt.Printf("enum %s {\n", d.FullName())
+		for i := 0; i < d.Values().Len(); i++ {
+			v := d.Values().Get(i)
+			fmt.Printf("  %s = %d;\n", v.Name(), v.Number())
+		}
+		fmt.Println("}")
+	default:
+		return fmt.Errorf("%s is a %T, not a service, method, message or enum", symbol, d)
+	}
+	return nil
+}
+
+func signature(md protoreflect.MethodDescriptor) string {
+	stream := func(b bool) string {
+		if b {
+			return "stream "
+		}
+		return ""
+	}
+	return fmt.Sprintf("rpc %s(%s%s) returns (%s%s);", md.Name(),
+		stream(md.IsStreamingClient()), md.Input().FullName(),
+		stream(md.IsStreamingServer()), md.Output().FullName())
+}
+
+func label(f protoreflect.FieldDescriptor) string {
+	switch {
+	case f.IsMap():
+		return ""
+	case f.Cardinality() == protoreflect.Repeated:
+		return "repeated "
+	case f.HasOptionalKeyword():
+		return "optional "
+	}
+	return ""
+}
+
+func typeName(f protoreflect.FieldDescriptor) string {
+	switch {
+	case f.IsMap():
+		return fmt.Sprintf("map<%s, %s>", typeName(f.MapKey()), typeName(f.MapValue()))
+	case f.Message() != nil:
+		return string(f.Message().FullName())
+	case f.Enum() != nil:
+		return string(f.Enum().FullName())
+	}
+	return f.Kind().String()
+}
+
+func findService(ctx context.Context, src source, name string) (protoreflect.ServiceDescriptor, error) {
+	d, err := src.find(ctx, protoreflect.FullName(name))
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", name)
+	}
+	return sd, nil
+}
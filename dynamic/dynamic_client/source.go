@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/christiangda/grpc-go-course/descriptors"
+)
+
+// source finds the descriptors of the services of a server
+type source interface {
+	// services lists the full names of the services
+	services(ctx context.Context) ([]string, error)
+	// find returns the descriptor of a service, method or message
+	find(ctx context.Context, name protoreflect.FullName) (protoreflect.Descriptor, error)
+	// files returns the files found so far, to resolve message types
+	files() *protoregistry.Files
+}
+
+// fileSource reads the descriptors from a FileDescriptorSet
+type fileSource struct {
+	reg *protoregistry.Files
+}
+
+// newFileSource reads the set at path, "embedded" is the set of the
+// descriptors package
+func newFileSource(path string) (*fileSource, error) {
+	b := descriptors.Protoset()
+	if path != "embedded" {
+		var err error
+		if b, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, set); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+	reg, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("linking %s: %v", path, err)
+	}
+	return &fileSource{reg: reg}, nil
+}
+
+func (s *fileSource) services(ctx context.Context) ([]string, error) {
+	var names []string
+	s.reg.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			names = append(names, string(fd.Services().Get(i).FullName()))
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *fileSource) find(ctx context.Context, name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	return s.reg.FindDescriptorByName(name)
+}
+
+func (s *fileSource) files() *protoregistry.Files {
+	return s.reg
+}
+
+// reflectionMethods are the reflection services tried in order, both use the
+// same messages on the wire
+var reflectionMethods = []string{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+// reflectionSource asks the server through the reflection service
+type reflectionSource struct {
+	cc  *grpc.ClientConn
+	reg *protoregistry.Files
+	// protos are the files received but not linked yet, by name
+	protos map[string]*descriptorpb.FileDescriptorProto
+}
+
+func newReflectionSource(cc *grpc.ClientConn) *reflectionSource {
+	return &reflectionSource{
+		cc:     cc,
+		reg:    &protoregistry.Files{},
+		protos: map[string]*descriptorpb.FileDescriptorProto{},
+	}
+}
+
+// ask sends req, falling back to v1alpha on servers without v1
+func (s *reflectionSource) ask(ctx context.Context, req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	var err error
+	for _, method := range reflectionMethods {
+		var res *rpb.ServerReflectionResponse
+		res, err = s.askWith(ctx, method, req)
+		if status.Code(err) != codes.Unimplemented {
+			return res, err
+		}
+	}
+	return nil, fmt.Errorf("server reflection is not available, use -protoset: %v", err)
+}
+
+func (s *reflectionSource) askWith(ctx context.Context, method string, req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.cc.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, method)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	res := &rpb.ServerReflectionResponse{}
+	if err := stream.RecvMsg(res); err != nil {
+		return nil, err
+	}
+	if e := res.GetErrorResponse(); e != nil {
+		return nil, status.Error(codes.Code(e.GetErrorCode()), e.GetErrorMessage())
+	}
+	return res, nil
+}
+
+func (s *reflectionSource) services(ctx context.Context) ([]string, error) {
+	res, err := s.ask(ctx, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, svc := range res.GetListServicesResponse().GetService() {
+		names = append(names, svc.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *reflectionSource) find(ctx context.Context, name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := s.reg.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+
+	res, err := s.ask(ctx, symbolRequest(name))
+	if status.Code(err) == codes.NotFound && name.Parent() != "" {
+		// some servers only know methods through their service
+		res, err = s.ask(ctx, symbolRequest(name.Parent()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, b := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		fdp := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(b, fdp); err != nil {
+			return nil, err
+		}
+		s.protos[fdp.GetName()] = fdp
+		files = append(files, fdp.GetName())
+	}
+	for _, f := range files {
+		if err := s.link(ctx, f); err != nil {
+			return nil, err
+		}
+	}
+	return s.reg.FindDescriptorByName(name)
+}
+
+// link adds file to the registry once its dependencies are, fetching the
+// ones the server did not send
+func (s *reflectionSource) link(ctx context.Context, file string) error {
+	if _, err := s.reg.FindFileByPath(file); err == nil {
+		return nil
+	}
+	fdp, ok := s.protos[file]
+	if !ok {
+		res, err := s.ask(ctx, &rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: file},
+		})
+		if err != nil {
+			return fmt.Errorf("fetching %s: %v", file, err)
+		}
+		for _, b := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			dep := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(b, dep); err != nil {
+				return err
+			}
+			s.protos[dep.GetName()] = dep
+		}
+		if fdp, ok = s.protos[file]; !ok {
+			return fmt.Errorf("server did not send %s", file)
+		}
+	}
+
+	for _, dep := range fdp.GetDependency() {
+		if err := s.link(ctx, dep); err != nil {
+			return err
+		}
+	}
+	fd, err := protodesc.NewFile(fdp, s.reg)
+	if err != nil {
+		return fmt.Errorf("linking %s: %v", file, err)
+	}
+	return s.reg.RegisterFile(fd)
+}
+
+func (s *reflectionSource) files() *protoregistry.Files {
+	return s.reg
+}
+
+func symbolRequest(name protoreflect.FullName) *rpb.ServerReflectionRequest {
+	return &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: string(name)},
+	}
+}
@@ -0,0 +1,413 @@
+// Package fault injects latency, errors, dropped messages and aborted
+// streams into the calls of a server, to see how its callers cope with them.
+// The rules can be replaced while the server runs, through the AdminService.
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+const (
+	// DefaultMessage is the message of the injected errors without one
+	DefaultMessage = "fault injected"
+	// Reason is the ErrorInfo reason of the injected RESOURCE_EXHAUSTED
+	// errors, which throttle the callers
+	Reason = "FAULT_INJECTED"
+	// DefaultRetryDelay is the RetryInfo of the injected
+	// RESOURCE_EXHAUSTED errors without a RetryDelay
+	DefaultRetryDelay = time.Second
+)
+
+// Config is the set of fault rules of a server, off unless Enabled
+type Config struct {
+	Enabled bool   `yaml:"enabled"`
+	Rules   []Rule `yaml:"rules"`
+}
+
+// Rule selects calls and the faults injected into them. The first rule
+// matching a call applies, the calls matching none run untouched.
+type Rule struct {
+	// Methods are full method names, as greet.GreetService/Greet, or
+	// greet.GreetService/* for every method of a service, empty matches
+	// every method
+	Methods []string `yaml:"methods"`
+	// Percentage of the matching calls affected, 0 means every one
+	Percentage float64 `yaml:"percentage"`
+	// Headers are metadata the calls must carry, an empty value matches
+	// any value of the key
+	Headers map[string]string `yaml:"headers"`
+
+	// Delay is added before the call runs
+	Delay time.Duration `yaml:"delay"`
+	// Code fails the call with this status, by name as UNAVAILABLE or by
+	// number, instead of running it. With AbortAfter, streams run and fail
+	// once they reach AbortAfter messages.
+	Code    string `yaml:"code"`
+	Message string `yaml:"message"`
+	// DropPercentage of the messages a stream sends are silently discarded
+	DropPercentage float64 `yaml:"drop_percentage"`
+	// AbortAfter fails streams once they sent this many messages, dropped
+	// ones included, or received this many for client streams, which send a
+	// single response, with Code or UNAVAILABLE, 0 for never
+	AbortAfter int `yaml:"abort_after"`
+	// RetryDelay is how long a call failed with RESOURCE_EXHAUSTED is told
+	// to wait before retrying, DefaultRetryDelay when 0
+	RetryDelay time.Duration `yaml:"retry_delay"`
+}
+
+// rule is a validated Rule
+type rule struct {
+	Rule
+	code codes.Code
+}
+
+// Validate checks every rule of cfg
+func (cfg Config) Validate() error {
+	_, err := cfg.compile()
+	return err
+}
+
+func (cfg Config) compile() ([]rule, error) {
+	rules := make([]rule, 0, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		compiled, err := r.compile()
+		if err != nil {
+			return nil, fmt.Errorf("fault rule %d: %v", i+1, err)
+		}
+		rules = append(rules, compiled)
+	}
+	return rules, nil
+}
+
+func (r Rule) compile() (rule, error) {
+	compiled := rule{Rule: r}
+	switch {
+	case r.Percentage < 0 || r.Percentage > 100:
+		return compiled, fmt.Errorf("percentage %v is not between 0 and 100", r.Percentage)
+	case r.DropPercentage < 0 || r.DropPercentage > 100:
+		return compiled, fmt.Errorf("drop_percentage %v is not between 0 and 100", r.DropPercentage)
+	case r.Delay < 0:
+		return compiled, fmt.Errorf("negative delay %v", r.Delay)
+	case r.AbortAfter < 0:
+		return compiled, fmt.Errorf("negative abort_after %d", r.AbortAfter)
+	case r.RetryDelay < 0:
+		return compiled, fmt.Errorf("negative retry_delay %v", r.RetryDelay)
+	}
+	for _, m := range r.Methods {
+		if strings.Count(strings.TrimPrefix(m, "/"), "/") != 1 {
+			return compiled, fmt.Errorf("method %q is not package.Service/Method", m)
+		}
+	}
+	if r.Code != "" {
+		code, err := parseCode(r.Code)
+		if err != nil {
+			return compiled, fmt.Errorf("unknown status code %q", r.Code)
+		}
+		compiled.code = code
+	}
+	if r.Delay == 0 && compiled.code == codes.OK && r.DropPercentage == 0 && r.AbortAfter == 0 {
+		return compiled, errors.New("no delay, code, drop_percentage nor abort_after")
+	}
+	return compiled, nil
+}
+
+// parseCode parses a status code given by name, as UNAVAILABLE, or number
+func parseCode(s string) (codes.Code, error) {
+	var code codes.Code
+	if _, err := strconv.Atoi(s); err != nil {
+		s = strconv.Quote(strings.ToUpper(s))
+	}
+	if err := code.UnmarshalJSON([]byte(s)); err != nil {
+		return 0, err
+	}
+	return code, nil
+}
+
+// matches tells whether the call of method with md is selected by r, the
+// percentage aside
+func (r rule) matches(method string, md metadata.MD) bool {
+	if len(r.Methods) > 0 {
+		found := false
+		for _, m := range r.Methods {
+			if matchMethod(strings.TrimPrefix(m, "/"), method) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for k, want := range r.Headers {
+		values := md.Get(k)
+		if len(values) == 0 {
+			return false
+		}
+		if want != "" && !contains(values, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchMethod matches method, without its leading slash, against pattern
+func matchMethod(pattern, method string) bool {
+	if service, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(method, service+"/")
+	}
+	return pattern == method
+}
+
+func contains(values []string, v string) bool {
+	for _, item := range values {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// err is the status injected by r, RESOURCE_EXHAUSTED carries a RetryInfo
+// like the errors of a throttling server
+func (r rule) err(code codes.Code) error {
+	msg := r.Message
+	if msg == "" {
+		msg = DefaultMessage
+	}
+	if code == codes.ResourceExhausted {
+		delay := r.RetryDelay
+		if delay == 0 {
+			delay = DefaultRetryDelay
+		}
+		return rpcerror.Throttled(Reason, delay, "%s", msg)
+	}
+	return status.Error(code, msg)
+}
+
+// Injector applies the fault rules to the calls of a server
+type Injector struct {
+	mu      sync.RWMutex
+	enabled bool
+	config  Config
+	rules   []rule
+	// random draws the calls and messages affected by the percentages,
+	// replaced by the tests
+	random func() float64
+}
+
+// New returns an injector applying cfg
+func New(cfg Config) (*Injector, error) {
+	i := &Injector{random: rand.Float64}
+	if err := i.Set(cfg); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// LoadFile returns an injector applying the YAML configuration at path, a
+// disabled one when path is empty
+func LoadFile(path string) (*Injector, error) {
+	var cfg Config
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(b, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", path, err)
+		}
+	}
+	return New(cfg)
+}
+
+// Config returns the configuration in effect
+func (i *Injector) Config() Config {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	cfg := i.config
+	cfg.Enabled = i.enabled
+	return cfg
+}
+
+// Set replaces the configuration, for the calls starting from now
+func (i *Injector) Set(cfg Config) error {
+	rules, err := cfg.compile()
+	if err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.enabled = cfg.Enabled
+	i.config = cfg
+	i.rules = rules
+	return nil
+}
+
+// Enable turns the rules on or off, keeping them
+func (i *Injector) Enable(enabled bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.enabled = enabled
+}
+
+// pick returns the rule applying to the call of method, nil for none
+func (i *Injector) pick(ctx context.Context, method string) *rule {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if !i.enabled {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	method = strings.TrimPrefix(method, "/")
+	for n := range i.rules {
+		r := &i.rules[n]
+		if !r.matches(method, md) {
+			continue
+		}
+		if r.Percentage == 0 || i.random()*100 < r.Percentage {
+			return r
+		}
+		// a rule skipped by its percentage lets the call run untouched
+		return nil
+	}
+	return nil
+}
+
+// delay waits for the delay of r, or until ctx is done
+func (r *rule) delay(ctx context.Context) error {
+	if r.Delay == 0 {
+		return nil
+	}
+	t := time.NewTimer(r.Delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// UnaryServerInterceptor delays or fails the unary calls selected by the
+// rules, drops and aborts only apply to streams
+func (i *Injector) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		r := i.pick(ctx, info.FullMethod)
+		if r == nil {
+			return handler(ctx, req)
+		}
+		logging.Debugf("Injecting fault into %s", info.FullMethod)
+		if err := r.delay(ctx); err != nil {
+			return nil, err
+		}
+		if r.code != codes.OK {
+			return nil, r.err(r.code)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor delays, fails, drops the messages of or aborts the
+// streams selected by the rules
+func (i *Injector) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		r := i.pick(ss.Context(), info.FullMethod)
+		if r == nil {
+			return handler(srv, ss)
+		}
+		logging.Debugf("Injecting fault into %s", info.FullMethod)
+		if err := r.delay(ss.Context()); err != nil {
+			return err
+		}
+		if r.code != codes.OK && r.AbortAfter == 0 {
+			return r.err(r.code)
+		}
+
+		fs := &faultyStream{ServerStream: ss, rule: r, random: i.random, countSent: info.IsServerStream}
+		err := handler(srv, fs)
+		// handlers may wrap or swallow the error of the aborted stream
+		if aborted := fs.abortErr(); aborted != nil {
+			return aborted
+		}
+		return err
+	}
+}
+
+// faultyStream drops the messages sent and aborts after the messages of its
+// rule, the ones sent unless the method only streams the requests
+type faultyStream struct {
+	grpc.ServerStream
+	rule      *rule
+	random    func() float64
+	countSent bool
+
+	mu       sync.Mutex
+	messages int
+	aborted  error
+}
+
+// count counts a message and returns the abort error once the stream has
+// reached its messages
+func (s *faultyStream) count() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aborted != nil {
+		return s.aborted
+	}
+	if s.rule.AbortAfter == 0 {
+		return nil
+	}
+	if s.messages >= s.rule.AbortAfter {
+		code := s.rule.code
+		if code == codes.OK {
+			code = codes.Unavailable
+		}
+		s.aborted = s.rule.err(code)
+		return s.aborted
+	}
+	s.messages++
+	return nil
+}
+
+func (s *faultyStream) abortErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.aborted
+}
+
+func (s *faultyStream) SendMsg(m interface{}) error {
+	if s.countSent {
+		if err := s.count(); err != nil {
+			return err
+		}
+	}
+	if s.rule.DropPercentage > 0 && s.random()*100 < s.rule.DropPercentage {
+		return nil
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *faultyStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil || s.countSent {
+		return err
+	}
+	// the end of the requests is not a message
+	return s.count()
+}
@@ -0,0 +1,268 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// newInjector returns an enabled injector of rules drawing from a seeded
+// source
+func newInjector(t *testing.T, rules ...Rule) *Injector {
+	t.Helper()
+	i, err := New(Config{Enabled: true, Rules: rules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	r := rand.New(rand.NewSource(1))
+	i.random = func() float64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return r.Float64()
+	}
+	return i
+}
+
+// unary makes n calls of method with md and returns how many failed
+func unary(i *Injector, method string, md metadata.MD, n int) int {
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+	failed := 0
+	for j := 0; j < n; j++ {
+		if _, err := i.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler); err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+func TestTargeting(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		method string
+		md     metadata.MD
+		// min and max are the calls of 1000 failed
+		min, max int
+	}{
+		{"every call", Rule{Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", nil, 1000, 1000},
+		{"method", Rule{Methods: []string{"greet.GreetService/Greet"}, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", nil, 1000, 1000},
+		{"other method", Rule{Methods: []string{"greet.GreetService/Greet"}, Code: "UNAVAILABLE"}, "/greet.GreetService/GreetWithDeadLine", nil, 0, 0},
+		{"service", Rule{Methods: []string{"calculator.CalculatorService/*"}, Code: "UNAVAILABLE"}, "/calculator.CalculatorService/Sum", nil, 1000, 1000},
+		{"other service", Rule{Methods: []string{"calculator.CalculatorService/*"}, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", nil, 0, 0},
+		{"percentage", Rule{Percentage: 30, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", nil, 250, 350},
+		{"header", Rule{Headers: map[string]string{"x-fault": "slow"}, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", metadata.Pairs("x-fault", "slow"), 1000, 1000},
+		{"header among values", Rule{Headers: map[string]string{"x-fault": "slow"}, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", metadata.Pairs("x-fault", "fast", "x-fault", "slow"), 1000, 1000},
+		{"header value", Rule{Headers: map[string]string{"x-fault": "slow"}, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", metadata.Pairs("x-fault", "fast"), 0, 0},
+		{"header missing", Rule{Headers: map[string]string{"x-fault": "slow"}, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", nil, 0, 0},
+		{"any header value", Rule{Headers: map[string]string{"x-fault": ""}, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", metadata.Pairs("x-fault", "fast"), 1000, 1000},
+		{"header and percentage", Rule{Percentage: 50, Headers: map[string]string{"x-fault": "slow"}, Code: "UNAVAILABLE"}, "/greet.GreetService/Greet", metadata.Pairs("x-fault", "slow"), 450, 550},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := newInjector(t, tt.rule)
+			if failed := unary(i, tt.method, tt.md, 1000); failed < tt.min || failed > tt.max {
+				t.Errorf("%d calls of 1000 failed, want %d to %d", failed, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestFirstMatchingRuleApplies(t *testing.T) {
+	// the calls skipped by the percentage of the first rule run untouched
+	i := newInjector(t,
+		Rule{Methods: []string{"greet.GreetService/Greet"}, Percentage: 50, Code: "UNAVAILABLE"},
+		Rule{Code: "INTERNAL"},
+	)
+	if failed := unary(i, "/greet.GreetService/Greet", nil, 1000); failed < 450 || failed > 550 {
+		t.Errorf("%d calls of 1000 failed, want about 500", failed)
+	}
+	if failed := unary(i, "/calculator.CalculatorService/Sum", nil, 10); failed != 10 {
+		t.Errorf("%d calls of 10 failed by the second rule", failed)
+	}
+}
+
+func TestUnaryFaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule
+		code  codes.Code
+		delay time.Duration
+		// retryDelay is the RetryInfo of the error, if any
+		retryDelay time.Duration
+	}{
+		{"code", Rule{Code: "unavailable", Message: "down"}, codes.Unavailable, 0, 0},
+		{"code number", Rule{Code: "14"}, codes.Unavailable, 0, 0},
+		{"throttled", Rule{Code: "RESOURCE_EXHAUSTED"}, codes.ResourceExhausted, 0, DefaultRetryDelay},
+		{"throttled with delay", Rule{Code: "RESOURCE_EXHAUSTED", RetryDelay: 500 * time.Millisecond}, codes.ResourceExhausted, 0, 500 * time.Millisecond},
+		{"delay", Rule{Delay: 20 * time.Millisecond}, codes.OK, 20 * time.Millisecond, 0},
+		{"delay and code", Rule{Delay: 20 * time.Millisecond, Code: "ABORTED"}, codes.Aborted, 20 * time.Millisecond, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := newInjector(t, tt.rule)
+			start := time.Now()
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+			_, err := i.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/greet.GreetService/Greet"}, handler)
+			if status.Code(err) != tt.code {
+				t.Errorf("got %v, want %v", err, tt.code)
+			}
+			if elapsed := time.Since(start); elapsed < tt.delay {
+				t.Errorf("failed after %v, want a delay of %v", elapsed, tt.delay)
+			}
+			delay, ok := rpcerror.RetryDelay(err)
+			if ok != (tt.retryDelay > 0) || delay != tt.retryDelay {
+				t.Errorf("got retry delay %v, %v, want %v", delay, ok, tt.retryDelay)
+			}
+		})
+	}
+}
+
+// fakeStream counts the messages sent and serves requests received
+type fakeStream struct {
+	grpc.ServerStream
+	sent     int
+	requests int
+}
+
+func (s *fakeStream) Context() context.Context { return context.Background() }
+
+func (s *fakeStream) SendMsg(m interface{}) error {
+	s.sent++
+	return nil
+}
+
+func (s *fakeStream) RecvMsg(m interface{}) error {
+	if s.requests == 0 {
+		return io.EOF
+	}
+	s.requests--
+	return nil
+}
+
+// stream runs a call described by info through the interceptor of i, the
+// handler receives every request then sends responses, and returns the
+// messages sent and received by the handler
+func stream(i *Injector, info *grpc.StreamServerInfo, requests, responses int) (sent, received int, err error) {
+	ss := &fakeStream{requests: requests}
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		for {
+			if err := stream.RecvMsg(nil); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			received++
+		}
+		for j := 0; j < responses; j++ {
+			if err := stream.SendMsg(nil); err != nil {
+				// as the services, which may wrap the errors of the stream
+				return errors.New("failed sending")
+			}
+		}
+		return nil
+	}
+	err = i.StreamServerInterceptor()(nil, ss, info, handler)
+	return ss.sent, received, err
+}
+
+var (
+	serverStream = &grpc.StreamServerInfo{FullMethod: "/greet.GreetService/GreetManyTimes", IsServerStream: true}
+	clientStream = &grpc.StreamServerInfo{FullMethod: "/greet.GreetService/LongGreet", IsClientStream: true}
+	bidiStream   = &grpc.StreamServerInfo{FullMethod: "/greet.GreetService/GreetEveryone", IsClientStream: true, IsServerStream: true}
+)
+
+func TestStreamFaults(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		info *grpc.StreamServerInfo
+		// requests and responses are the messages of the handler
+		requests, responses int
+		// sent and received are the messages that went through, out of
+		// them, before the stream ended with code
+		sent, received int
+		code           codes.Code
+	}{
+		{"code", Rule{Code: "UNAVAILABLE"}, serverStream, 1, 10, 0, 0, codes.Unavailable},
+		{"abort after sent", Rule{AbortAfter: 5, Code: "ABORTED"}, serverStream, 1, 10, 5, 1, codes.Aborted},
+		{"abort beyond sent", Rule{AbortAfter: 10, Code: "ABORTED"}, serverStream, 1, 10, 10, 1, codes.OK},
+		{"abort unavailable", Rule{AbortAfter: 5}, serverStream, 1, 10, 5, 1, codes.Unavailable},
+		{"abort bidi after sent", Rule{AbortAfter: 3}, bidiStream, 5, 5, 3, 5, codes.Unavailable},
+		{"abort after received", Rule{AbortAfter: 3}, clientStream, 5, 1, 0, 3, codes.Unavailable},
+		{"abort beyond received", Rule{AbortAfter: 5}, clientStream, 5, 1, 1, 5, codes.OK},
+		{"drop every message", Rule{DropPercentage: 100}, serverStream, 1, 10, 0, 1, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := newInjector(t, tt.rule)
+			sent, received, err := stream(i, tt.info, tt.requests, tt.responses)
+			if status.Code(err) != tt.code && !(tt.code == codes.OK && err == nil) {
+				t.Errorf("got %v, want %v", err, tt.code)
+			}
+			if sent != tt.sent || received != tt.received {
+				t.Errorf("sent %d and received %d messages, want %d and %d", sent, received, tt.sent, tt.received)
+			}
+		})
+	}
+}
+
+func TestDropPercentage(t *testing.T) {
+	i := newInjector(t, Rule{DropPercentage: 20})
+	sent, _, err := stream(i, serverStream, 1, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent < 750 || sent > 850 {
+		t.Errorf("%d messages of 1000 sent, want about 800", sent)
+	}
+}
+
+func TestEnable(t *testing.T) {
+	i := newInjector(t, Rule{Code: "UNAVAILABLE"})
+	i.Enable(false)
+	if failed := unary(i, "/greet.GreetService/Greet", nil, 10); failed != 0 {
+		t.Errorf("%d calls failed with the rules disabled", failed)
+	}
+	if cfg := i.Config(); cfg.Enabled || len(cfg.Rules) != 1 {
+		t.Errorf("got config %+v, want the rule disabled", cfg)
+	}
+	i.Enable(true)
+	if failed := unary(i, "/greet.GreetService/Greet", nil, 10); failed != 10 {
+		t.Errorf("%d calls of 10 failed with the rules enabled", failed)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+	}{
+		{"no fault", Rule{Methods: []string{"greet.GreetService/Greet"}}},
+		{"percentage", Rule{Percentage: 101, Code: "UNAVAILABLE"}},
+		{"drop percentage", Rule{DropPercentage: -1}},
+		{"negative delay", Rule{Delay: -time.Second}},
+		{"negative abort", Rule{AbortAfter: -1}},
+		{"method", Rule{Methods: []string{"Greet"}, Code: "UNAVAILABLE"}},
+		{"code", Rule{Code: "BROKEN"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := (Config{Rules: []Rule{tt.rule}}).Validate(); err == nil {
+				t.Errorf("rule %+v accepted", tt.rule)
+			}
+		})
+	}
+}
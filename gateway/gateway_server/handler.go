@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails" // resolves the error details when rendering them
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// metadataPrefix marks the HTTP headers forwarded as gRPC metadata
+const metadataPrefix = "Grpc-Metadata-"
+
+// forwardedHeaders are forwarded as gRPC metadata under their own name
+var forwardedHeaders = []string{"Accept-Language", "Authorization"}
+
+var marshaler = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// gateway serves the routes of the gRPC services over HTTP/JSON
+type gateway struct {
+	routes []*route
+}
+
+func (gw *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	methodAllowed := false
+	for _, rt := range gw.routes {
+		params, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			methodAllowed = true
+			continue
+		}
+		rt.serve(w, r, params)
+		return
+	}
+
+	if methodAllowed {
+		writeError(w, status.Errorf(codes.Unimplemented, "method %v not allowed on %v", r.Method, r.URL.Path), http.StatusMethodNotAllowed)
+		return
+	}
+	writeError(w, status.Errorf(codes.NotFound, "no route for %v", r.URL.Path), http.StatusNotFound)
+}
+
+func (rt *route) serve(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	log.Printf("%v %v -> %v", r.Method, r.URL.Path, rt.fullMethod)
+
+	in, err := rt.request(r, params)
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, err.Error()), 0)
+		return
+	}
+
+	ctx := metadata.NewOutgoingContext(r.Context(), outgoingMetadata(r.Header))
+	if rt.rpc.IsStreamingServer() {
+		rt.serveStream(ctx, w, r, in)
+		return
+	}
+
+	out := dynamicpb.NewMessage(rt.rpc.Output())
+	if err := rt.conn.Invoke(ctx, rt.fullMethod, in, out); err != nil {
+		writeError(w, err, 0)
+		return
+	}
+	b, err := marshaler.Marshal(out)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "marshaling response: %v", err), 0)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// serveStream writes every message of a server stream as a line of JSON, or
+// as a server-sent event when the client accepts text/event-stream
+func (rt *route) serveStream(ctx context.Context, w http.ResponseWriter, r *http.Request, in proto.Message) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := rt.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, rt.fullMethod)
+	if err == nil {
+		err = stream.SendMsg(in)
+	}
+	if err == nil {
+		err = stream.CloseSend()
+	}
+	if err != nil {
+		writeError(w, err, 0)
+		return
+	}
+
+	sse := strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+	flusher, _ := w.(http.Flusher)
+	started := false
+
+	for {
+		out := dynamicpb.NewMessage(rt.rpc.Output())
+		err := stream.RecvMsg(out)
+		if err == io.EOF {
+			if !started {
+				// a stream without messages still gets its headers
+				writeStreamHeader(w, sse)
+			}
+			return
+		}
+		if err != nil && !started {
+			// nothing was written, the error gets its own HTTP status
+			writeError(w, err, 0)
+			return
+		}
+		if !started {
+			writeStreamHeader(w, sse)
+			started = true
+		}
+
+		if err != nil {
+			b, _ := marshaler.Marshal(status.Convert(err).Proto())
+			writeStreamEvent(w, sse, "error", b)
+			return
+		}
+		b, err := marshaler.Marshal(out)
+		if err != nil {
+			b, _ = marshaler.Marshal(status.Newf(codes.Internal, "marshaling response: %v", err).Proto())
+			writeStreamEvent(w, sse, "error", b)
+			return
+		}
+		writeStreamEvent(w, sse, "result", b)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func writeStreamHeader(w http.ResponseWriter, sse bool) {
+	if sse {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// writeStreamEvent writes {"result": ...} / {"error": ...} lines for NDJSON
+// and "result" / "error" events for SSE
+func writeStreamEvent(w http.ResponseWriter, sse bool, kind string, b []byte) {
+	if sse {
+		fmt.Fprintf(w, "event: %v\ndata: %s\n\n", kind, b)
+		return
+	}
+	fmt.Fprintf(w, "{%q: %s}\n", kind, b)
+}
+
+// writeError writes err as a google.rpc.Status. httpStatus overrides the
+// HTTP status derived from the gRPC code when it is not 0.
+func writeError(w http.ResponseWriter, err error, httpStatus int) {
+	st := status.Convert(err)
+	if httpStatus == 0 {
+		httpStatus = httpStatusOf(st.Code())
+	}
+
+	b, mErr := marshaler.Marshal(st.Proto())
+	if mErr != nil {
+		b = []byte(fmt.Sprintf(`{"code": %d, "message": %q}`, st.Code(), st.Message()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(b)
+}
+
+func outgoingMetadata(h http.Header) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range h {
+		if strings.HasPrefix(key, metadataPrefix) {
+			md.Append(strings.TrimPrefix(key, metadataPrefix), values...)
+		}
+	}
+	for _, key := range forwardedHeaders {
+		if values := h.Values(key); len(values) > 0 {
+			md.Append(key, values...)
+		}
+	}
+	return md
+}
+
+// httpStatusOf maps gRPC codes to HTTP statuses as described in
+// https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
+func httpStatusOf(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// statusSchema is the name of the google.rpc.Status schema of the errors
+const statusSchema = "google.rpc.Status"
+
+// openAPI builds an OpenAPI 3 document describing the routes of the
+// gateway from the descriptors of their methods
+func (gw *gateway) openAPI(title, version string) ([]byte, error) {
+	schemas := map[string]interface{}{
+		statusSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"code":    map[string]interface{}{"type": "integer", "format": "int32"},
+				"message": map[string]interface{}{"type": "string"},
+				"details": map[string]interface{}{
+					"type":  "array",
+					"items": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"@type": map[string]interface{}{"type": "string"}}, "additionalProperties": true},
+				},
+			},
+		},
+	}
+	paths := map[string]map[string]interface{}{}
+
+	for _, rt := range gw.routes {
+		op := map[string]interface{}{
+			"operationId": fmt.Sprintf("%v_%v", rt.rpc.Parent().Name(), rt.rpc.Name()),
+			"tags":        []string{string(rt.rpc.Parent().Name())},
+			"responses": map[string]interface{}{
+				"200":     map[string]interface{}{"description": "A successful response.", "content": responseContent(rt, schemas)},
+				"default": map[string]interface{}{"description": "An error response.", "content": jsonContent(ref(statusSchema))},
+			},
+		}
+
+		var params []interface{}
+		bound := map[string]bool{}
+		for _, seg := range rt.segments {
+			if name, ok := variable(seg); ok {
+				bound[name] = true
+				params = append(params, map[string]interface{}{
+					"name": name, "in": "path", "required": true,
+					"schema": fieldSchema(findField(rt.rpc.Input(), name), schemas),
+				})
+			}
+		}
+		if rt.body != "*" {
+			fields := rt.rpc.Input().Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				if bound[string(fd.Name())] || string(fd.Name()) == rt.body || fd.Message() != nil || fd.IsMap() {
+					continue
+				}
+				params = append(params, map[string]interface{}{
+					"name": string(fd.Name()), "in": "query",
+					"schema": fieldSchema(fd, schemas),
+				})
+			}
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		switch rt.body {
+		case "":
+		case "*":
+			op["requestBody"] = map[string]interface{}{"required": true, "content": jsonContent(messageSchema(rt.rpc.Input(), schemas))}
+		default:
+			op["requestBody"] = map[string]interface{}{"required": true, "content": jsonContent(fieldSchema(findField(rt.rpc.Input(), rt.body), schemas))}
+		}
+
+		// OpenAPI templates use the same {name} syntax as google.api.http
+		if paths[rt.template] == nil {
+			paths[rt.template] = map[string]interface{}{}
+		}
+		paths[rt.template][strings.ToLower(rt.method)] = op
+	}
+
+	doc := map[string]interface{}{
+		"openapi":    "3.0.3",
+		"info":       map[string]interface{}{"title": title, "version": version},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func responseContent(rt *route, schemas map[string]interface{}) map[string]interface{} {
+	out := messageSchema(rt.rpc.Output(), schemas)
+	if !rt.rpc.IsStreamingServer() {
+		return jsonContent(out)
+	}
+
+	// each line or event carries either a result or an error
+	line := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"result": out,
+			"error":  ref(statusSchema),
+		},
+	}
+	return map[string]interface{}{
+		"application/x-ndjson": map[string]interface{}{"schema": line},
+		"text/event-stream":    map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+	}
+}
+
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// messageSchema adds the schema of md, and of the messages it uses, to
+// schemas and returns a reference to it
+func messageSchema(md protoreflect.MessageDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	name := string(md.FullName())
+	switch name {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return map[string]interface{}{"type": "string"}
+	case "google.protobuf.Any", "google.protobuf.Struct", "google.protobuf.Value":
+		return map[string]interface{}{"type": "object", "additionalProperties": true}
+	}
+	if _, ok := schemas[name]; ok {
+		return ref(name)
+	}
+
+	props := map[string]interface{}{}
+	schema := map[string]interface{}{"type": "object", "properties": props}
+	// registered before the fields so recursive messages terminate
+	schemas[name] = schema
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		props[fd.JSONName()] = fieldSchema(fd, schemas)
+	}
+	return ref(name)
+}
+
+func fieldSchema(fd protoreflect.FieldDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	if fd.IsMap() {
+		return map[string]interface{}{"type": "object", "additionalProperties": singularSchema(fd.MapValue(), schemas)}
+	}
+	if fd.IsList() {
+		return map[string]interface{}{"type": "array", "items": singularSchema(fd, schemas)}
+	}
+	return singularSchema(fd, schemas)
+}
+
+func singularSchema(fd protoreflect.FieldDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageSchema(fd.Message(), schemas)
+	case protoreflect.EnumKind:
+		var names []string
+		values := fd.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		sort.Strings(names)
+		return map[string]interface{}{"type": "string", "enum": names}
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.StringKind:
+		return map[string]interface{}{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64-bit integers are JSON strings in the proto3 JSON mapping
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	default:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	}
+}
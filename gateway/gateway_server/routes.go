@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// route maps an HTTP method and path template to a gRPC method, following
+// the google.api.http annotation of the method
+type route struct {
+	method     string
+	template   string
+	segments   []string
+	body       string
+	rpc        protoreflect.MethodDescriptor
+	fullMethod string
+	conn       *grpc.ClientConn
+}
+
+// routesOf returns the routes of every annotated method of sd, served by conn
+func routesOf(sd protoreflect.ServiceDescriptor, conn *grpc.ClientConn) ([]*route, error) {
+	var routes []*route
+
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		opts, ok := md.Options().(*descriptorpb.MethodOptions)
+		if !ok || opts == nil || !proto.HasExtension(opts, annotations.E_Http) {
+			continue
+		}
+		if md.IsStreamingClient() {
+			return nil, fmt.Errorf("%v: client streaming methods can't be served over HTTP", md.FullName())
+		}
+
+		rule := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule)
+		for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			rt, err := newRoute(md, r, conn)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", md.FullName(), err)
+			}
+			routes = append(routes, rt)
+		}
+	}
+
+	return routes, nil
+}
+
+func newRoute(md protoreflect.MethodDescriptor, rule *annotations.HttpRule, conn *grpc.ClientConn) (*route, error) {
+	rt := &route{
+		body:       rule.GetBody(),
+		rpc:        md,
+		fullMethod: fmt.Sprintf("/%v/%v", md.Parent().FullName(), md.Name()),
+		conn:       conn,
+	}
+
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		rt.method, rt.template = http.MethodGet, p.Get
+	case *annotations.HttpRule_Post:
+		rt.method, rt.template = http.MethodPost, p.Post
+	case *annotations.HttpRule_Put:
+		rt.method, rt.template = http.MethodPut, p.Put
+	case *annotations.HttpRule_Delete:
+		rt.method, rt.template = http.MethodDelete, p.Delete
+	case *annotations.HttpRule_Patch:
+		rt.method, rt.template = http.MethodPatch, p.Patch
+	case *annotations.HttpRule_Custom:
+		rt.method, rt.template = p.Custom.GetKind(), p.Custom.GetPath()
+	default:
+		return nil, fmt.Errorf("http rule without pattern")
+	}
+
+	if !strings.HasPrefix(rt.template, "/") {
+		return nil, fmt.Errorf("path %q must start with /", rt.template)
+	}
+	rt.segments = strings.Split(strings.TrimPrefix(rt.template, "/"), "/")
+	for _, seg := range rt.segments {
+		if name, ok := variable(seg); ok {
+			if strings.Contains(name, "=") {
+				return nil, fmt.Errorf("path %q: variable patterns are not supported", rt.template)
+			}
+			if findField(md.Input(), name) == nil {
+				return nil, fmt.Errorf("path %q: unknown field %q", rt.template, name)
+			}
+		}
+	}
+	if rt.body != "" && rt.body != "*" && findField(md.Input(), rt.body) == nil {
+		return nil, fmt.Errorf("unknown body field %q", rt.body)
+	}
+
+	return rt, nil
+}
+
+// variable returns the field path of a "{field.path}" segment
+func variable(seg string) (string, bool) {
+	if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+		return seg[1 : len(seg)-1], true
+	}
+	return "", false
+}
+
+// match returns the path variables of path when it matches the template
+func (rt *route) match(path string) (map[string]string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != len(rt.segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, seg := range rt.segments {
+		if name, ok := variable(seg); ok {
+			if parts[i] == "" {
+				return nil, false
+			}
+			params[name] = parts[i]
+		} else if seg != parts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// request builds the input message of the method from the body, the path
+// variables and, for fields not bound otherwise, the query string
+func (rt *route) request(r *http.Request, params map[string]string) (*dynamicpb.Message, error) {
+	in := dynamicpb.NewMessage(rt.rpc.Input())
+
+	if rt.body != "" {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(b)) > 0 {
+			target := protoreflect.Message(in)
+			if rt.body != "*" {
+				target = in.Mutable(findField(in.Descriptor(), rt.body)).Message()
+			}
+			if err := protojson.Unmarshal(b, target.Interface()); err != nil {
+				return nil, fmt.Errorf("invalid body: %v", err)
+			}
+		}
+	}
+
+	for name, value := range params {
+		if err := setField(in, name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if rt.body != "*" {
+		for name, values := range r.URL.Query() {
+			if _, ok := params[name]; ok {
+				continue
+			}
+			if err := setField(in, name, values...); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return in, nil
+}
+
+// findField resolves a dotted path of proto or JSON field names
+func findField(md protoreflect.MessageDescriptor, path string) protoreflect.FieldDescriptor {
+	var fd protoreflect.FieldDescriptor
+	for _, name := range strings.Split(path, ".") {
+		if md == nil {
+			return nil
+		}
+		fd = md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = md.Fields().ByJSONName(name)
+		}
+		if fd == nil {
+			return nil
+		}
+		md = fd.Message()
+	}
+	return fd
+}
+
+func setField(m protoreflect.Message, path string, values ...string) error {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		fd := findField(m.Descriptor(), name)
+		if fd == nil || fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("unknown field %q", path)
+		}
+		m = m.Mutable(fd).Message()
+	}
+
+	fd := findField(m.Descriptor(), names[len(names)-1])
+	if fd == nil || fd.IsMap() || fd.Message() != nil {
+		return fmt.Errorf("unknown field %q", path)
+	}
+	for _, s := range values {
+		v, err := parseScalar(fd, s)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for field %q: %v", s, path, err)
+		}
+		if fd.IsList() {
+			m.Mutable(fd).List().Append(v)
+		} else {
+			m.Set(fd, v)
+		}
+	}
+	return nil
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(s)
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported kind %v", fd.Kind())
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	// register the descriptors of the services we serve
+	_ "github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	_ "github.com/christiangda/grpc-go-course/greet/greetpb"
+)
+
+func main() {
+	httpAddr := flag.String("http-addr", "0.0.0.0:8080", "address the REST gateway listens on")
+	greetEndpoint := flag.String("greet-endpoint", "localhost:50051", "address of the greet server")
+	greetCA := flag.String("greet-ca", "ssl/ca.crt", "CA certificate of the greet server, empty for an insecure connection")
+	calculatorEndpoint := flag.String("calculator-endpoint", "localhost:50051", "address of the calculator server")
+	calculatorCA := flag.String("calculator-ca", "", "CA certificate of the calculator server, empty for an insecure connection")
+	flag.Parse()
+
+	fmt.Println("REST Gateway")
+
+	gw := &gateway{}
+	for _, backend := range []struct {
+		service  protoreflect.FullName
+		endpoint string
+		caFile   string
+	}{
+		{"greet.GreetService", *greetEndpoint, *greetCA},
+		{"calculator.CalculatorService", *calculatorEndpoint, *calculatorCA},
+	} {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(backend.service)
+		if err != nil {
+			log.Fatalf("Unknown service %v: %v", backend.service, err)
+		}
+
+		opts := grpc.WithInsecure()
+		if backend.caFile != "" {
+			creds, sslErr := credentials.NewClientTLSFromFile(backend.caFile, "")
+			if sslErr != nil {
+				log.Fatalf("Error while loading CA trust certificate: %v\n", sslErr)
+			}
+			opts = grpc.WithTransportCredentials(creds)
+		}
+		cc, err := grpc.Dial(backend.endpoint, opts)
+		if err != nil {
+			log.Fatalf("Could not connect to: %v", err)
+		}
+		defer cc.Close()
+
+		routes, err := routesOf(d.(protoreflect.ServiceDescriptor), cc)
+		if err != nil {
+			log.Fatalf("Invalid http annotations: %v", err)
+		}
+		gw.routes = append(gw.routes, routes...)
+	}
+
+	spec, err := gw.openAPI("grpc-go-course REST gateway", "v1")
+	if err != nil {
+		log.Fatalf("Failed building the OpenAPI spec: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gw)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	})
+
+	for _, rt := range gw.routes {
+		log.Printf("Serving %v %v -> %v", rt.method, rt.template, rt.fullMethod)
+	}
+	if err := http.ListenAndServe(*httpAddr, mux); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
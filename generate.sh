@@ -1,4 +1,10 @@
 #!/bin/bash
 
-protoc greet/greetpb/greet.proto --go_out=plugins=grpc:.
-protoc calculator/calculatorpb/calculator.proto --go_out=plugins=grpc:.
+# third_party/googleapis holds the google/rpc protos imported by our services
+protoc -I . validate/validatepb/validate.proto --go_out=paths=source_relative:.
+protoc -I . -I third_party/googleapis greet/greetpb/greet.proto --go_out=plugins=grpc:.
+protoc -I . -I third_party/googleapis calculator/calculatorpb/calculator.proto --go_out=plugins=grpc:.
+protoc -I . admin/adminpb/admin.proto --go_out=plugins=grpc,paths=source_relative:.
+
+# descriptors of every service, embedded by the descriptors package and usable with grpcurl -protoset
+protoc -I . -I third_party/googleapis --include_imports --include_source_info --descriptor_set_out=descriptors/services.protoset greet/greetpb/greet.proto calculator/calculatorpb/calculator.proto admin/adminpb/admin.proto
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -9,14 +10,27 @@ import (
 
 	"google.golang.org/grpc/credentials"
 
+	"github.com/christiangda/grpc-go-course/compression"
 	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/loadbalancing"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+	"github.com/christiangda/grpc-go-course/streaming"
+	"github.com/christiangda/grpc-go-course/transport"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func main() {
+	addr := flag.String("addr", "localhost:50051", "server addresses separated by commas, dns:///name, unix:///path or file:///path of a targets file")
+	lb := loadbalancing.DefaultConfig
+	lb.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
 	fmt.Println("hello I'm a client")
 
 	opts := grpc.WithInsecure()
@@ -31,7 +45,18 @@ func main() {
 		opts = grpc.WithTransportCredentials(creds)
 	}
 
-	cc, err := grpc.Dial("localhost:50051", opts)
+	// requests of 1KiB and more are compressed, with gzip if the server
+	// does not know zstd
+	compress := compression.Config{Algorithms: []string{compression.Zstd, compression.Gzip}, MinSize: 1024}
+	dialOpts := append(compress.DialOptions(), opts)
+	dialOpts = append(dialOpts, streaming.DefaultClientKeepalive.DialOptions()...)
+	lbOpts, err := lb.DialOptions()
+	if err != nil {
+		log.Fatalf("Invalid balancing: %v", err)
+	}
+	dialOpts = append(dialOpts, lbOpts...)
+	dialOpts = append(dialOpts, transport.DialOptions(*addr)...)
+	cc, err := grpc.Dial(loadbalancing.Target(*addr), dialOpts...)
 	if err != nil {
 		log.Fatalf("Could not connect to: %v", err)
 	}
@@ -41,13 +66,20 @@ func main() {
 	//fmt.Println("Created client: %f", c)
 	doUnary(c)
 
+	//doLocalizedUnary(c)
+
 	//doServerStreaming(c)
+	//doResumableServerStreaming(c)
 
 	//doClientStreaming(c)
+	//doCompressedClientStreaming(c)
+	//doStructuredClientStreaming(c)
 
 	//doBiDiStreaming(c)
 	//doUnaryWithDeadLine(c, 5*time.Second) // should complete
 	//doUnaryWithDeadLine(c, 1*time.Second) // should timeout
+
+	//doListGreetings(c)
 }
 
 func doUnary(c greetpb.GreetServiceClient) {
@@ -65,6 +97,24 @@ func doUnary(c greetpb.GreetServiceClient) {
 	log.Printf("Response from Greet: %v", res.Result)
 }
 
+func doLocalizedUnary(c greetpb.GreetServiceClient) {
+	fmt.Println("Starting to do a localized unary RPC...")
+	req := &greetpb.GreetRequest{
+		Greeting: &greetpb.Greeting{
+			FirstName: "Christian",
+			LastName:  "Gonzalez",
+			Formality: greetpb.Greeting_FORMAL,
+		},
+	}
+	// the locale is taken from the metadata since the Greeting has none
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "es-VE, es;q=0.9, en;q=0.8")
+	res, err := c.Greet(ctx, req)
+	if err != nil {
+		log.Fatalf("error while calling Greet RPC: %v", err)
+	}
+	log.Printf("Response from Greet: %v", res.Result)
+}
+
 func doServerStreaming(c greetpb.GreetServiceClient) {
 	fmt.Println("Starting to do a server streaming RPC...")
 	req := &greetpb.GreetManyTimesRequest{
@@ -72,6 +122,9 @@ func doServerStreaming(c greetpb.GreetServiceClient) {
 			FirstName: "Christian",
 			LastName:  "Gonzalez",
 		},
+		Count:    10,
+		Interval: durationpb.New(time.Second),
+		Jitter:   durationpb.New(250 * time.Millisecond),
 	}
 	resStream, err := c.GreetManyTimes(context.Background(), req)
 	if err != nil {
@@ -90,6 +143,28 @@ func doServerStreaming(c greetpb.GreetServiceClient) {
 	}
 }
 
+func doResumableServerStreaming(c greetpb.GreetServiceClient) {
+	fmt.Println("Starting to do a resumable server streaming RPC...")
+	req := &greetpb.GreetManyTimesRequest{
+		Greeting: &greetpb.Greeting{
+			FirstName: "Christian",
+			LastName:  "Gonzalez",
+		},
+	}
+	// if the connection drops the stream is reopened from the last greeting received
+	open := func(ctx context.Context, token string) (resume.Stream[*greetpb.GreetManyTimesResponse], error) {
+		req.ResumeToken = token
+		return c.GreetManyTimes(ctx, req)
+	}
+	err := resume.Receive(context.Background(), resume.DefaultBackoff, open, func(msg *greetpb.GreetManyTimesResponse) error {
+		log.Printf("Response from GreetManyTimes: %v", msg.GetResult())
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("error while reading stream: %v", err)
+	}
+}
+
 func doClientStreaming(c greetpb.GreetServiceClient) {
 	fmt.Println("Starting to do a Client streaming RPC...")
 
@@ -133,6 +208,72 @@ func doClientStreaming(c greetpb.GreetServiceClient) {
 	fmt.Printf("Long Greet Response: %v\n", resp)
 }
 
+func doCompressedClientStreaming(c greetpb.GreetServiceClient) {
+	fmt.Println("Starting to do a gzip compressed Client streaming RPC...")
+
+	// the compression of the connection is overridden for this call
+	stream, err := c.LongGreet(context.Background(), grpc.UseCompressor(compression.Gzip))
+	if err != nil {
+		log.Fatalf("error while calling LongGreet RPC: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		req := &greetpb.LongGreetRequest{
+			Greeting: &greetpb.Greeting{
+				FirstName: fmt.Sprintf("Christian %d", i),
+				LastName:  "Gonzalez",
+			},
+		}
+		if err := stream.Send(req); err != nil {
+			log.Printf("Error while sending: %v", err)
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Fatalf("Error while receiving response from LongGreet: %v", err)
+	}
+	fmt.Printf("Long Greet Response: %v\n", resp)
+}
+
+func doStructuredClientStreaming(c greetpb.GreetServiceClient) {
+	fmt.Println("Starting to do a Client streaming RPC with a structured response...")
+
+	stream, err := c.LongGreet(context.Background())
+	if err != nil {
+		log.Fatalf("error while calling LongGreet RPC: %v", err)
+	}
+
+	names := [][2]string{{"Christian", "Gonzalez"}, {"Maria Elena", "Crespo"}, {"Sebastian", "Gonzalez"}}
+	for i, name := range names {
+		req := &greetpb.LongGreetRequest{
+			Greeting: &greetpb.Greeting{
+				FirstName: name[0],
+				LastName:  name[1],
+			},
+			// only the first message chooses the response
+			Structured: i == 0,
+		}
+		if err := stream.Send(req); err != nil {
+			log.Printf("Error while sending: %v", err)
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		// a stream longer than the server accepts fails with ResourceExhausted
+		log.Fatalf("Error while receiving response from LongGreet: %v", rpcerror.Describe(err))
+	}
+	summary := resp.GetSummary()
+	for _, greeting := range summary.GetGreetings() {
+		fmt.Println(greeting)
+	}
+	fmt.Printf("By last name: %v, from %v to %v\n", summary.GetLastNameCounts(),
+		summary.GetFirstTime().AsTime().Format(time.RFC3339Nano), summary.GetLastTime().AsTime().Format(time.RFC3339Nano))
+}
+
 func doBiDiStreaming(c greetpb.GreetServiceClient) {
 	fmt.Println("Starting to do a BiDi streaming RPC...")
 
@@ -165,12 +306,15 @@ func doBiDiStreaming(c greetpb.GreetServiceClient) {
 	}
 
 	waitc := make(chan struct{})
-	// we send a bunch of messages to the client (go rutines)
+	// we send a bunch of messages to the client (go rutines), Send blocks
+	// while the server is not keeping up so there is no need to pace them
 	go func() {
 		for _, req := range request {
 			fmt.Printf("Sending message: %v\n", req)
-			stream.Send(req)
-			time.Sleep(1000 * time.Millisecond)
+			if err := stream.Send(req); err != nil {
+				log.Printf("Error while sending: %v", err)
+				break
+			}
 		}
 		stream.CloseSend()
 	}()
@@ -213,7 +357,7 @@ func doUnaryWithDeadLine(c greetpb.GreetServiceClient, timeout time.Duration) {
 			if statusErr.Code() == codes.DeadlineExceeded {
 				fmt.Println("Timeout was hit! Deadline was exceeded")
 			} else {
-				fmt.Printf("unexpected error: %v\n", statusErr)
+				fmt.Printf("unexpected error: %v\n", rpcerror.Describe(statusErr.Err()))
 			}
 		} else {
 			log.Fatalf("error while calling GreetWithDeadLine RPC: %v", err)
@@ -223,3 +367,30 @@ func doUnaryWithDeadLine(c greetpb.GreetServiceClient, timeout time.Duration) {
 	}
 	log.Printf("Response from GreetWithDeadLine: %v", res.GetResult())
 }
+
+func doListGreetings(c greetpb.GreetServiceClient) {
+	fmt.Println("Starting to list the greeting history...")
+	req := &greetpb.ListGreetingsRequest{
+		PageSize: 5,
+		Name:     "Christian",
+	}
+	for {
+		res, err := c.ListGreetings(context.Background(), req)
+		if err != nil {
+			log.Fatalf("error while calling ListGreetings RPC: %v", err)
+		}
+		for _, g := range res.GetGreetings() {
+			log.Printf("%d %v %v from %v: %v", g.GetId(), g.GetCreateTime().AsTime().Format(time.RFC3339), g.GetRpc(), g.GetCaller(), g.GetResult())
+		}
+		if res.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+
+	stats, err := c.GetGreetingStats(context.Background(), &greetpb.GetGreetingStatsRequest{})
+	if err != nil {
+		log.Fatalf("error while calling GetGreetingStats RPC: %v", err)
+	}
+	log.Printf("Greeting stats: %d greetings to %d people, by RPC %v", stats.GetTotal(), stats.GetUniqueNames(), stats.GetByRpc())
+}
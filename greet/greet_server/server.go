@@ -1,147 +1,201 @@
 package main
 
 import (
-	"context"
+	"flag"
 	"fmt"
-	"io"
 	"log"
 	"net"
-	"strconv"
-	"time"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/status"
-
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/christiangda/grpc-go-course/admin"
+	"github.com/christiangda/grpc-go-course/bridge"
+	"github.com/christiangda/grpc-go-course/cache"
+	"github.com/christiangda/grpc-go-course/compression"
+	"github.com/christiangda/grpc-go-course/fault"
 	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/greetservice"
+	"github.com/christiangda/grpc-go-course/idempotency"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/streaming"
+	"github.com/christiangda/grpc-go-course/transport"
+	"github.com/christiangda/grpc-go-course/validate"
 	"google.golang.org/grpc"
 )
 
-type server struct{}
-
-func (s *server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
-	log.Printf("Greet function was invoked with %v", req)
-	firstName := req.GetGreeting().GetFirstName()
-	lastName := req.GetGreeting().GetLastName()
-	result := "Hello " + firstName + " " + lastName
-	res := &greetpb.GreetResponse{
-		Result: result,
-	}
-	return res, nil
-}
-
-func (s *server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
-	log.Printf("GreetManyTimes function was invoked with %v", req)
-	firstName := req.GetGreeting().GetFirstName()
-	lastName := req.GetGreeting().GetLastName()
-	for i := 0; i < 10; i++ {
-		result := "Hello " + firstName + " " + lastName + " number " + strconv.Itoa(i)
-		res := &greetpb.GreetManyTimesResponse{
-			Result: result,
-		}
-		stream.Send(res)
-		time.Sleep(1000 * time.Millisecond)
+func main() {
+	cfg := greetservice.DefaultConfig
+	flag.StringVar(&cfg.DefaultLocale, "default-locale", cfg.DefaultLocale, "locale of the greetings when the caller's one is not supported")
+	flag.StringVar(&cfg.LocalesDir, "locales", cfg.LocalesDir, "directory of *.json greeting templates, overriding the built-in ones")
+	web := flag.Bool("web", false, "also serve the gRPC-Web and Connect protocols on the gRPC port")
+	corsOrigins := flag.String("cors-origins", "", "comma separated origins allowed to call the server from a browser, * for any")
+	flag.IntVar(&cfg.MaxGreetCount, "max-greet-count", cfg.MaxGreetCount, "maximum count of a GreetManyTimes request")
+	flag.DurationVar(&cfg.MaxGreetInterval, "max-greet-interval", cfg.MaxGreetInterval, "maximum interval and jitter of a GreetManyTimes request")
+	flag.IntVar(&cfg.MaxLongGreetCount, "max-long-greet-count", cfg.MaxLongGreetCount, "maximum greetings of a LongGreet stream, 0 for no limit")
+	flag.IntVar(&cfg.MaxLongGreetSize, "max-long-greet-size", cfg.MaxLongGreetSize, "maximum bytes of greetings in a LongGreet response, 0 for no limit")
+	flag.IntVar(&cfg.Pipeline.Workers, "stream-workers", cfg.Pipeline.Workers, "messages of a GreetEveryone stream processed at once")
+	flag.IntVar(&cfg.Pipeline.Depth, "stream-depth", cfg.Pipeline.Depth, "messages of a GreetEveryone stream received ahead of the responses sent")
+	var flowControl streaming.FlowControl
+	flowControl.RegisterFlags(flag.CommandLine)
+	keepalive := streaming.DefaultKeepalive
+	keepalive.RegisterFlags(flag.CommandLine)
+	var compress compression.Config
+	compress.RegisterFlags(flag.CommandLine)
+	cacheConfig := cache.DefaultConfig
+	cacheConfig.RegisterFlags(flag.CommandLine)
+	idempotencyConfig := idempotency.DefaultConfig
+	idempotencyConfig.RegisterFlags(flag.CommandLine)
+	flag.StringVar(&cfg.History, "history", cfg.History, "greeting history store: memory, bolt:<path> or sqlite:<path>")
+	flag.IntVar(&cfg.HistoryRetention.MaxRecords, "history-max-records", cfg.HistoryRetention.MaxRecords, "greetings kept in the history, 0 for no limit")
+	flag.DurationVar(&cfg.HistoryRetention.MaxAge, "history-max-age", cfg.HistoryRetention.MaxAge, "how long greetings are kept in the history, 0 for ever")
+	listen := flag.String("listen", "0.0.0.0:50051", "comma separated addresses served: host:port, unix:///path or unix:relative/path")
+	socketMode := transport.DefaultSocketMode
+	transport.SocketModeFlag(flag.CommandLine, &socketMode)
+	reflectionOn := flag.Bool("reflection", true, "serve the gRPC reflection service, v1 and v1alpha")
+	faultsFile := flag.String("faults", "", "YAML file of fault injection rules, off unless it sets enabled: true")
+	adminListen := flag.String("admin-listen", "", "address of channelz and the AdminService, authenticated with the ADMIN_TOKEN environment variable, empty for none")
+	logLevel := logging.Info
+	flag.TextVar(&logLevel, "log-level", logLevel, "minimum level of the messages logged: debug, info, warning or error")
+	flag.Parse()
+	logging.SetLevel(logLevel)
+	grpclog.SetLoggerV2(logging.GRPCLogger{})
+	// the servers sharing the key resume the streams of each other, and
+	// their own streams across restarts
+	if key := os.Getenv("RESUME_KEY"); key != "" {
+		resume.SetKey([]byte(key))
 	}
-	return nil
-}
 
-func (s *server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
-	log.Printf("LongGreet function was invoked with a streaming request")
-	result := ""
-	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			// We have finished reading the client stream
-			return stream.SendAndClose(&greetpb.LongGreetResponse{
-				Result: result,
-			})
-		}
-		if err != nil {
-			log.Fatalf("Error while reading client stream: %v", err)
-		}
-
-		firstName := req.GetGreeting().GetFirstName()
-		lastName := req.GetGreeting().GetLastName()
+	fmt.Println("Hello World")
 
-		result += "Hello " + firstName + " " + lastName + "! "
+	greetServer, err := greetservice.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed creating the greet service: %v", err)
 	}
 
-	return nil
-}
-
-func (s *server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
-	log.Printf("GreetEveryone function was invoked a streaming request\n")
-
-	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			return nil
-		}
-		if err != nil {
-			log.Fatalf("Error while reading client stream: %v", err)
-			return err
-		}
-
-		firstName := req.GetGreeting().GetFirstName()
-		lastName := req.GetGreeting().GetLastName()
-
-		result := "Hello " + firstName + " " + lastName + "! "
-
-		sendErr := stream.Send(&greetpb.GreetEveryoneResponse{
-			Result: result,
-		})
-		if sendErr != nil {
-			log.Fatalf("Error while sending data to client stream: %v", sendErr)
-			return sendErr
-		}
+	listeners, err := transport.ListenAll(transport.SplitList(*listen), socketMode)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
 	}
-	return nil
-}
-
-func (s *server) GreetWithDeadLine(ctx context.Context, req *greetpb.GreetWithDeadLineRequest) (*greetpb.GreetWithDeadLineResponse, error) {
-	log.Printf("GreetWithDeadLine function was invoked with %v", req)
 
-	for i := 0; i < 3; i++ {
-		if ctx.Err() == context.Canceled {
-			fmt.Println("The client cancel the request!")
-			return nil, status.Error(codes.DeadlineExceeded, "The client cancel the request")
-		}
-		time.Sleep(1 * time.Second)
+	faults, err := fault.LoadFile(*faultsFile)
+	if err != nil {
+		log.Fatalf("Failed loading the fault injection rules: %v", err)
 	}
 
-	firstName := req.GetGreeting().GetFirstName()
-	lastName := req.GetGreeting().GetLastName()
-	result := "Hello " + firstName + " " + lastName
-	res := &greetpb.GreetWithDeadLineResponse{
-		Result: result,
+	responseCache, err := cache.New(cacheConfig)
+	if err != nil {
+		log.Fatalf("Failed creating the response cache: %v", err)
 	}
-	return res, nil
-}
-
-func main() {
-	fmt.Println("Hello World")
 
-	lis, err := net.Listen("tcp", "0.0.0.0:50051")
+	idempotent, err := idempotency.New(idempotencyConfig)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		log.Fatalf("Failed creating the idempotency store: %v", err)
 	}
 
-	opts := []grpc.ServerOption{}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(validate.UnaryServerInterceptor(), faults.UnaryServerInterceptor(), idempotent.UnaryServerInterceptor(), responseCache.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(validate.StreamServerInterceptor(), faults.StreamServerInterceptor(), responseCache.StreamServerInterceptor()),
+	}
+	opts = append(opts, flowControl.ServerOptions()...)
+	opts = append(opts, keepalive.ServerOptions()...)
+	opts = append(opts, compress.ServerOptions()...)
 	tls := true
+	certFile := "ssl/server.crt"
+	keyFile := "ssl/server.pem"
+	var creds credentials.TransportCredentials
 	if tls {
-		certFile := "ssl/server.crt"
-		keyFile := "ssl/server.pem"
-		creds, sslErr := credentials.NewServerTLSFromFile(certFile, keyFile)
+		var sslErr error
+		creds, sslErr = credentials.NewServerTLSFromFile(certFile, keyFile)
 		if sslErr != nil {
 			log.Fatalf("Failed loading certificates: %v\n", sslErr)
 			return
 		}
 		opts = append(opts, grpc.Creds(creds))
 	}
+	var adminServer *grpc.Server
+	if *adminListen != "" {
+		// the bearer token of the admin port is only sent over TLS
+		if creds == nil {
+			log.Fatalf("The admin port is only served with TLS")
+		}
+		adminServer, err = admin.Attach(admin.Options{
+			Token:  os.Getenv("ADMIN_TOKEN"),
+			Config: cfg,
+			Creds:  creds,
+			Faults: faults,
+			Cache:  responseCache,
+		}, &opts)
+		if err != nil {
+			log.Fatalf("Failed creating the admin server: %v", err)
+		}
+	}
 	s := grpc.NewServer(opts...)
-	greetpb.RegisterGreetServiceServer(s, &server{})
+	greetpb.RegisterGreetServiceServer(s, greetServer)
+
+	// clients balancing over several servers skip the ones not serving
+	healthpb.RegisterHealthServer(s, health.NewServer())
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	if *reflectionOn {
+		reflection.Register(s)
+	}
+
+	serve := s.Serve
+	var hs *http.Server
+	if *web {
+		hs = bridge.NewServer(s, bridge.Options{AllowedOrigins: transport.SplitList(*corsOrigins)}, keepalive.ApplyHTTP2(flowControl.HTTP2Server()))
+		serve = func(lis net.Listener) error {
+			if tls {
+				return hs.ServeTLS(lis, certFile, keyFile)
+			}
+			return hs.Serve(lis)
+		}
+	}
+
+	for _, lis := range listeners {
+		log.Printf("Serving on %v", transport.Addr(lis))
+		go func(lis net.Listener) {
+			if err := serve(lis); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("failed to serve: %v", err)
+			}
+		}(lis)
+	}
+	if adminServer != nil {
+		adminLis, err := transport.Listen(*adminListen, socketMode)
+		if err != nil {
+			log.Fatalf("Failed to listen on the admin port: %v", err)
+		}
+		log.Printf("Serving admin on %v", transport.Addr(adminLis))
+		go func() {
+			if err := adminServer.Serve(adminLis); err != nil {
+				log.Fatalf("failed to serve admin: %v", err)
+			}
+		}()
+	}
+
+	// Wait for Control C to exit
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	<-ch
+
+	fmt.Println("Stopping the server")
+	if hs != nil {
+		hs.Close()
+	}
+	if adminServer != nil {
+		adminServer.Stop()
+	}
+	s.GracefulStop()
+	// the history store is flushed and closed once no call uses it
+	if err := greetServer.Close(); err != nil {
+		log.Printf("Failed closing the greet service: %v", err)
 	}
 }
@@ -0,0 +1,225 @@
+// Package greetclient calls the greet service with plain Go types: streams
+// are iterators and channels, errors are *rpcerror.Error.
+//
+//	c, err := greetclient.Dial("localhost:50051", client.WithTLS("ssl/ca.crt"))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	for greeting, err := range c.GreetManyTimes(ctx, greetclient.Name("Christian", "Gonzalez"), greetclient.Pacing{Count: 5}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(greeting)
+//	}
+package greetclient
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/christiangda/grpc-go-course/client"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// ErrLimitExceeded is returned by LongGreet and LongGreetSummary when the
+// server does not accept more greetings
+var ErrLimitExceeded = &rpcerror.Error{Code: codes.ResourceExhausted, Reason: "LONG_GREET_LIMIT_EXCEEDED"}
+
+// Client calls the greet service
+type Client struct {
+	c       greetpb.GreetServiceClient
+	backoff resume.Backoff
+	close   func() error
+}
+
+// Dial connects to the greet service at target, see client.Dial
+func Dial(target string, opts ...client.Option) (*Client, error) {
+	cc, err := client.Dial(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{c: greetpb.NewGreetServiceClient(cc.ClientConn), backoff: cc.Backoff, close: cc.Close}, nil
+}
+
+// New calls the greet service over cc, which the caller closes
+func New(cc *grpc.ClientConn) *Client {
+	return &Client{c: greetpb.NewGreetServiceClient(cc), backoff: resume.DefaultBackoff, close: func() error { return nil }}
+}
+
+// Close closes the connection opened by Dial
+func (c *Client) Close() error {
+	return c.close()
+}
+
+// Name returns the greeting of a person, in the locale of the caller
+func Name(first, last string) *greetpb.Greeting {
+	return &greetpb.Greeting{FirstName: first, LastName: last}
+}
+
+// Greet returns the greeting of g
+func (c *Client) Greet(ctx context.Context, g *greetpb.Greeting) (string, error) {
+	res, err := c.c.Greet(ctx, &greetpb.GreetRequest{Greeting: g})
+	if err != nil {
+		return "", rpcerror.FromError(err)
+	}
+	return res.GetResult(), nil
+}
+
+// GreetWithDeadline returns the greeting of g, the server takes 3 seconds
+func (c *Client) GreetWithDeadline(ctx context.Context, g *greetpb.Greeting) (string, error) {
+	res, err := c.c.GreetWithDeadLine(ctx, &greetpb.GreetWithDeadLineRequest{Greeting: g})
+	if err != nil {
+		return "", rpcerror.FromError(err)
+	}
+	return res.GetResult(), nil
+}
+
+// Pacing is how many greetings GreetManyTimes yields and how fast, zero
+// fields keep the defaults of the server
+type Pacing struct {
+	Count    int
+	Interval time.Duration
+	Jitter   time.Duration
+}
+
+// GreetManyTimes yields the greetings of g as the server sends them, then
+// the error ending the stream, if any. Broken streams are resumed.
+func (c *Client) GreetManyTimes(ctx context.Context, g *greetpb.Greeting, p Pacing) iter.Seq2[string, error] {
+	req := &greetpb.GreetManyTimesRequest{Greeting: g, Count: int32(p.Count)}
+	if p.Interval > 0 {
+		req.Interval = durationpb.New(p.Interval)
+	}
+	if p.Jitter > 0 {
+		req.Jitter = durationpb.New(p.Jitter)
+	}
+	open := func(ctx context.Context, token string) (resume.Stream[*greetpb.GreetManyTimesResponse], error) {
+		req.ResumeToken = token
+		return c.c.GreetManyTimes(ctx, req)
+	}
+	return func(yield func(string, error) bool) {
+		for res, err := range client.Resume(ctx, c.backoff, open) {
+			if !yield(res.GetResult(), err) {
+				return
+			}
+		}
+	}
+}
+
+// LongGreet sends every greeting of greetings in one stream and returns
+// their concatenation
+func (c *Client) LongGreet(ctx context.Context, greetings iter.Seq[*greetpb.Greeting]) (string, error) {
+	res, err := c.longGreet(ctx, greetings, false)
+	return res.GetResult(), err
+}
+
+// LongGreetSummary sends every greeting of greetings in one stream and
+// returns their summary
+func (c *Client) LongGreetSummary(ctx context.Context, greetings iter.Seq[*greetpb.Greeting]) (*greetpb.LongGreetSummary, error) {
+	res, err := c.longGreet(ctx, greetings, true)
+	return res.GetSummary(), err
+}
+
+func (c *Client) longGreet(ctx context.Context, greetings iter.Seq[*greetpb.Greeting], structured bool) (*greetpb.LongGreetResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.c.LongGreet(ctx)
+	if err != nil {
+		return nil, rpcerror.FromError(err)
+	}
+
+	first := true
+	for g := range greetings {
+		// io.EOF means the server ended the stream, CloseAndRecv tells why
+		if err := stream.Send(&greetpb.LongGreetRequest{Greeting: g, Structured: first && structured}); err != nil {
+			break
+		}
+		first = false
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, rpcerror.FromError(err)
+	}
+	return res, nil
+}
+
+// Everyone is a GreetEveryone stream, greeting each person sent
+type Everyone = client.Session[*greetpb.Greeting, string]
+
+// GreetEveryone opens a GreetEveryone stream, the greetings are delivered
+// by Out in the order the people were sent. Canceling ctx ends it.
+func (c *Client) GreetEveryone(ctx context.Context) (*Everyone, error) {
+	stream, err := c.c.GreetEveryone(ctx)
+	if err != nil {
+		return nil, rpcerror.FromError(err)
+	}
+	return client.NewSession(stream,
+		func(g *greetpb.Greeting) *greetpb.GreetEveryoneRequest {
+			return &greetpb.GreetEveryoneRequest{Greeting: g}
+		},
+		func(res *greetpb.GreetEveryoneResponse) string { return res.GetResult() },
+	), nil
+}
+
+// Filter selects greetings of the history, zero fields select everything
+type Filter struct {
+	// Name matches the first or last name, case insensitive
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+func (f Filter) times() (from, to *timestamppb.Timestamp) {
+	if !f.From.IsZero() {
+		from = timestamppb.New(f.From)
+	}
+	if !f.To.IsZero() {
+		to = timestamppb.New(f.To)
+	}
+	return from, to
+}
+
+// ListGreetings yields the greetings of the history matching f, newest
+// first, fetching them page by page
+func (c *Client) ListGreetings(ctx context.Context, f Filter) iter.Seq2[*greetpb.GreetingRecord, error] {
+	return func(yield func(*greetpb.GreetingRecord, error) bool) {
+		req := &greetpb.ListGreetingsRequest{Name: f.Name}
+		req.StartTime, req.EndTime = f.times()
+		for {
+			res, err := c.c.ListGreetings(ctx, req)
+			if err != nil {
+				yield(nil, rpcerror.FromError(err))
+				return
+			}
+			for _, g := range res.GetGreetings() {
+				if !yield(g, nil) {
+					return
+				}
+			}
+			if res.GetNextPageToken() == "" {
+				return
+			}
+			req.PageToken = res.GetNextPageToken()
+		}
+	}
+}
+
+// Stats returns the statistics of the greetings of the history matching f
+func (c *Client) Stats(ctx context.Context, f Filter) (*greetpb.GetGreetingStatsResponse, error) {
+	req := &greetpb.GetGreetingStatsRequest{Name: f.Name}
+	req.StartTime, req.EndTime = f.times()
+	res, err := c.c.GetGreetingStats(ctx, req)
+	if err != nil {
+		return nil, rpcerror.FromError(err)
+	}
+	return res, nil
+}
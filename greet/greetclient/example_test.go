@@ -0,0 +1,133 @@
+package greetclient_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/christiangda/grpc-go-course/greet/greetclient"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/greetservice"
+	"github.com/christiangda/grpc-go-course/transport"
+)
+
+// serve starts the greet service on the in-process listener addr
+func serve(addr string) (stop func()) {
+	svc, err := greetservice.New(greetservice.DefaultConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	lis, err := transport.Listen(addr, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := grpc.NewServer()
+	greetpb.RegisterGreetServiceServer(s, svc)
+	go s.Serve(lis)
+	return func() {
+		s.Stop()
+		svc.Close()
+	}
+}
+
+func Example() {
+	stop := serve("memory://greet-example")
+	defer stop()
+
+	c, err := greetclient.Dial("memory://greet-example")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	greeting, err := c.Greet(context.Background(), greetclient.Name("Christian", "Gonzalez"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(greeting)
+	// Output: Hello Christian Gonzalez
+}
+
+func ExampleClient_GreetManyTimes() {
+	stop := serve("memory://greet-many-times-example")
+	defer stop()
+
+	c, err := greetclient.Dial("memory://greet-many-times-example")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	pacing := greetclient.Pacing{Count: 3, Interval: time.Millisecond}
+	for greeting, err := range c.GreetManyTimes(context.Background(), greetclient.Name("Christian", "Gonzalez"), pacing) {
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(greeting)
+	}
+	// Output:
+	// Hello Christian Gonzalez number 0
+	// Hello Christian Gonzalez number 1
+	// Hello Christian Gonzalez number 2
+}
+
+func ExampleClient_LongGreetSummary() {
+	stop := serve("memory://long-greet-example")
+	defer stop()
+
+	c, err := greetclient.Dial("memory://long-greet-example")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	people := []*greetpb.Greeting{
+		greetclient.Name("Christian", "Gonzalez"),
+		greetclient.Name("Ada", "Lovelace"),
+	}
+	summary, err := c.LongGreetSummary(context.Background(), slices.Values(people))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, greeting := range summary.GetGreetings() {
+		fmt.Println(greeting)
+	}
+	// Output:
+	// Hello Christian Gonzalez
+	// Hello Ada Lovelace
+}
+
+func ExampleClient_GreetEveryone() {
+	stop := serve("memory://greet-everyone-example")
+	defer stop()
+
+	c, err := greetclient.Dial("memory://greet-everyone-example")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	everyone, err := c.GreetEveryone(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	go func() {
+		everyone.Send(greetclient.Name("Christian", "Gonzalez"))
+		everyone.Send(greetclient.Name("Ada", "Lovelace"))
+		everyone.Close()
+	}()
+	for greeting := range everyone.Out() {
+		fmt.Println(strings.TrimSpace(greeting))
+	}
+	if err := everyone.Err(); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// Hello Christian Gonzalez!
+	// Hello Ada Lovelace!
+}
@@ -6,6 +6,10 @@ package greetpb
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import _ "github.com/christiangda/grpc-go-course/validate/validatepb"
+import _ "google.golang.org/genproto/googleapis/api/annotations"
+import durationpb "google.golang.org/protobuf/types/known/durationpb"
+import timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 
 import (
 	context "golang.org/x/net/context"
@@ -23,19 +27,46 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type Greeting_Formality int32
+
+const (
+	Greeting_INFORMAL Greeting_Formality = 0
+	Greeting_FORMAL   Greeting_Formality = 1
+)
+
+var Greeting_Formality_name = map[int32]string{
+	0: "INFORMAL",
+	1: "FORMAL",
+}
+var Greeting_Formality_value = map[string]int32{
+	"INFORMAL": 0,
+	"FORMAL":   1,
+}
+
+func (x Greeting_Formality) String() string {
+	return proto.EnumName(Greeting_Formality_name, int32(x))
+}
+func (Greeting_Formality) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{0, 0}
+}
+
 type Greeting struct {
-	FirstName            string   `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName             string   `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	FirstName string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// BCP 47 language tag of the greeting, e.g. "es-VE". When empty the
+	// accept-language metadata of the call is used instead.
+	Locale               string             `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	Formality            Greeting_Formality `protobuf:"varint,4,opt,name=formality,proto3,enum=greet.Greeting_Formality" json:"formality,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *Greeting) Reset()         { *m = Greeting{} }
 func (m *Greeting) String() string { return proto.CompactTextString(m) }
 func (*Greeting) ProtoMessage()    {}
 func (*Greeting) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{0}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{0}
 }
 func (m *Greeting) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Greeting.Unmarshal(m, b)
@@ -69,6 +100,20 @@ func (m *Greeting) GetLastName() string {
 	return ""
 }
 
+func (m *Greeting) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *Greeting) GetFormality() Greeting_Formality {
+	if m != nil {
+		return m.Formality
+	}
+	return Greeting_INFORMAL
+}
+
 type GreetRequest struct {
 	Greeting             *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -80,7 +125,7 @@ func (m *GreetRequest) Reset()         { *m = GreetRequest{} }
 func (m *GreetRequest) String() string { return proto.CompactTextString(m) }
 func (*GreetRequest) ProtoMessage()    {}
 func (*GreetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{1}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{1}
 }
 func (m *GreetRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GreetRequest.Unmarshal(m, b)
@@ -118,7 +163,7 @@ func (m *GreetResponse) Reset()         { *m = GreetResponse{} }
 func (m *GreetResponse) String() string { return proto.CompactTextString(m) }
 func (*GreetResponse) ProtoMessage()    {}
 func (*GreetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{2}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{2}
 }
 func (m *GreetResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GreetResponse.Unmarshal(m, b)
@@ -146,17 +191,25 @@ func (m *GreetResponse) GetResult() string {
 }
 
 type GreetManyTimesRequest struct {
-	Greeting             *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	// resume_token of the last response received, to continue a broken stream
+	ResumeToken string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// number of greetings to send, 10 when unset
+	Count int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// time between two greetings, one second when unset
+	Interval *durationpb.Duration `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	// upper bound of a random delay added to every interval
+	Jitter               *durationpb.Duration `protobuf:"bytes,5,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *GreetManyTimesRequest) Reset()         { *m = GreetManyTimesRequest{} }
 func (m *GreetManyTimesRequest) String() string { return proto.CompactTextString(m) }
 func (*GreetManyTimesRequest) ProtoMessage()    {}
 func (*GreetManyTimesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{3}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{3}
 }
 func (m *GreetManyTimesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GreetManyTimesRequest.Unmarshal(m, b)
@@ -183,8 +236,37 @@ func (m *GreetManyTimesRequest) GetGreeting() *Greeting {
 	return nil
 }
 
+func (m *GreetManyTimesRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *GreetManyTimesRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GreetManyTimesRequest) GetInterval() *durationpb.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return nil
+}
+
+func (m *GreetManyTimesRequest) GetJitter() *durationpb.Duration {
+	if m != nil {
+		return m.Jitter
+	}
+	return nil
+}
+
 type GreetManyTimesResponse struct {
 	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	ResumeToken          string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -194,7 +276,7 @@ func (m *GreetManyTimesResponse) Reset()         { *m = GreetManyTimesResponse{}
 func (m *GreetManyTimesResponse) String() string { return proto.CompactTextString(m) }
 func (*GreetManyTimesResponse) ProtoMessage()    {}
 func (*GreetManyTimesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{4}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{4}
 }
 func (m *GreetManyTimesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GreetManyTimesResponse.Unmarshal(m, b)
@@ -221,18 +303,28 @@ func (m *GreetManyTimesResponse) GetResult() string {
 	return ""
 }
 
+func (m *GreetManyTimesResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
 type LongGreetRequest struct {
-	Greeting             *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	// answer with a summary instead of the concatenated result, read from the
+	// first message of the stream
+	Structured           bool     `protobuf:"varint,2,opt,name=structured,proto3" json:"structured,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LongGreetRequest) Reset()         { *m = LongGreetRequest{} }
 func (m *LongGreetRequest) String() string { return proto.CompactTextString(m) }
 func (*LongGreetRequest) ProtoMessage()    {}
 func (*LongGreetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{5}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{5}
 }
 func (m *LongGreetRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LongGreetRequest.Unmarshal(m, b)
@@ -259,18 +351,27 @@ func (m *LongGreetRequest) GetGreeting() *Greeting {
 	return nil
 }
 
+func (m *LongGreetRequest) GetStructured() bool {
+	if m != nil {
+		return m.Structured
+	}
+	return false
+}
+
 type LongGreetResponse struct {
-	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// set unless the stream asked for a structured response
+	Result               string            `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Summary              *LongGreetSummary `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *LongGreetResponse) Reset()         { *m = LongGreetResponse{} }
 func (m *LongGreetResponse) String() string { return proto.CompactTextString(m) }
 func (*LongGreetResponse) ProtoMessage()    {}
 func (*LongGreetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{6}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{6}
 }
 func (m *LongGreetResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LongGreetResponse.Unmarshal(m, b)
@@ -297,6 +398,78 @@ func (m *LongGreetResponse) GetResult() string {
 	return ""
 }
 
+func (m *LongGreetResponse) GetSummary() *LongGreetSummary {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+type LongGreetSummary struct {
+	// in the order they were received
+	Greetings []string `protobuf:"bytes,1,rep,name=greetings,proto3" json:"greetings,omitempty"`
+	// number of greetings per last name
+	LastNameCounts map[string]int64 `protobuf:"bytes,2,rep,name=last_name_counts,json=lastNameCounts,proto3" json:"last_name_counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// when the first and the last greetings were received
+	FirstTime            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=first_time,json=firstTime,proto3" json:"first_time,omitempty"`
+	LastTime             *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_time,json=lastTime,proto3" json:"last_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *LongGreetSummary) Reset()         { *m = LongGreetSummary{} }
+func (m *LongGreetSummary) String() string { return proto.CompactTextString(m) }
+func (*LongGreetSummary) ProtoMessage()    {}
+func (*LongGreetSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{7}
+}
+func (m *LongGreetSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LongGreetSummary.Unmarshal(m, b)
+}
+func (m *LongGreetSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LongGreetSummary.Marshal(b, m, deterministic)
+}
+func (dst *LongGreetSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LongGreetSummary.Merge(dst, src)
+}
+func (m *LongGreetSummary) XXX_Size() int {
+	return xxx_messageInfo_LongGreetSummary.Size(m)
+}
+func (m *LongGreetSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_LongGreetSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LongGreetSummary proto.InternalMessageInfo
+
+func (m *LongGreetSummary) GetGreetings() []string {
+	if m != nil {
+		return m.Greetings
+	}
+	return nil
+}
+
+func (m *LongGreetSummary) GetLastNameCounts() map[string]int64 {
+	if m != nil {
+		return m.LastNameCounts
+	}
+	return nil
+}
+
+func (m *LongGreetSummary) GetFirstTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.FirstTime
+	}
+	return nil
+}
+
+func (m *LongGreetSummary) GetLastTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.LastTime
+	}
+	return nil
+}
+
 type GreetEveryoneRequest struct {
 	Greeting             *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -308,7 +481,7 @@ func (m *GreetEveryoneRequest) Reset()         { *m = GreetEveryoneRequest{} }
 func (m *GreetEveryoneRequest) String() string { return proto.CompactTextString(m) }
 func (*GreetEveryoneRequest) ProtoMessage()    {}
 func (*GreetEveryoneRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{7}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{8}
 }
 func (m *GreetEveryoneRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GreetEveryoneRequest.Unmarshal(m, b)
@@ -346,7 +519,7 @@ func (m *GreetEveryoneResponse) Reset()         { *m = GreetEveryoneResponse{} }
 func (m *GreetEveryoneResponse) String() string { return proto.CompactTextString(m) }
 func (*GreetEveryoneResponse) ProtoMessage()    {}
 func (*GreetEveryoneResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{8}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{9}
 }
 func (m *GreetEveryoneResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GreetEveryoneResponse.Unmarshal(m, b)
@@ -384,7 +557,7 @@ func (m *GreetWithDeadLineRequest) Reset()         { *m = GreetWithDeadLineReque
 func (m *GreetWithDeadLineRequest) String() string { return proto.CompactTextString(m) }
 func (*GreetWithDeadLineRequest) ProtoMessage()    {}
 func (*GreetWithDeadLineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{9}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{10}
 }
 func (m *GreetWithDeadLineRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GreetWithDeadLineRequest.Unmarshal(m, b)
@@ -422,7 +595,7 @@ func (m *GreetWithDeadLineResponse) Reset()         { *m = GreetWithDeadLineResp
 func (m *GreetWithDeadLineResponse) String() string { return proto.CompactTextString(m) }
 func (*GreetWithDeadLineResponse) ProtoMessage()    {}
 func (*GreetWithDeadLineResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_greet_a2b77d57f14145f7, []int{10}
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{11}
 }
 func (m *GreetWithDeadLineResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GreetWithDeadLineResponse.Unmarshal(m, b)
@@ -449,6 +622,341 @@ func (m *GreetWithDeadLineResponse) GetResult() string {
 	return ""
 }
 
+type GreetingRecord struct {
+	Id        int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName string `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// name of the RPC that produced the greeting, e.g. "GreetManyTimes"
+	Rpc    string `protobuf:"bytes,4,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	Result string `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	// address of the client
+	Caller               string                 `protobuf:"bytes,6,opt,name=caller,proto3" json:"caller,omitempty"`
+	CreateTime           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GreetingRecord) Reset()         { *m = GreetingRecord{} }
+func (m *GreetingRecord) String() string { return proto.CompactTextString(m) }
+func (*GreetingRecord) ProtoMessage()    {}
+func (*GreetingRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{12}
+}
+func (m *GreetingRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GreetingRecord.Unmarshal(m, b)
+}
+func (m *GreetingRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GreetingRecord.Marshal(b, m, deterministic)
+}
+func (dst *GreetingRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GreetingRecord.Merge(dst, src)
+}
+func (m *GreetingRecord) XXX_Size() int {
+	return xxx_messageInfo_GreetingRecord.Size(m)
+}
+func (m *GreetingRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_GreetingRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GreetingRecord proto.InternalMessageInfo
+
+func (m *GreetingRecord) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GreetingRecord) GetFirstName() string {
+	if m != nil {
+		return m.FirstName
+	}
+	return ""
+}
+
+func (m *GreetingRecord) GetLastName() string {
+	if m != nil {
+		return m.LastName
+	}
+	return ""
+}
+
+func (m *GreetingRecord) GetRpc() string {
+	if m != nil {
+		return m.Rpc
+	}
+	return ""
+}
+
+func (m *GreetingRecord) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *GreetingRecord) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+func (m *GreetingRecord) GetCreateTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+type ListGreetingsRequest struct {
+	// defaults to 20, at most 100
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// only greetings whose first or last name match, case insensitive
+	Name                 string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	StartTime            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ListGreetingsRequest) Reset()         { *m = ListGreetingsRequest{} }
+func (m *ListGreetingsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGreetingsRequest) ProtoMessage()    {}
+func (*ListGreetingsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{13}
+}
+func (m *ListGreetingsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGreetingsRequest.Unmarshal(m, b)
+}
+func (m *ListGreetingsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGreetingsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListGreetingsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGreetingsRequest.Merge(dst, src)
+}
+func (m *ListGreetingsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListGreetingsRequest.Size(m)
+}
+func (m *ListGreetingsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGreetingsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGreetingsRequest proto.InternalMessageInfo
+
+func (m *ListGreetingsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListGreetingsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListGreetingsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ListGreetingsRequest) GetStartTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *ListGreetingsRequest) GetEndTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+type ListGreetingsResponse struct {
+	// newest first
+	Greetings []*GreetingRecord `protobuf:"bytes,1,rep,name=greetings,proto3" json:"greetings,omitempty"`
+	// empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGreetingsResponse) Reset()         { *m = ListGreetingsResponse{} }
+func (m *ListGreetingsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGreetingsResponse) ProtoMessage()    {}
+func (*ListGreetingsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{14}
+}
+func (m *ListGreetingsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGreetingsResponse.Unmarshal(m, b)
+}
+func (m *ListGreetingsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGreetingsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListGreetingsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGreetingsResponse.Merge(dst, src)
+}
+func (m *ListGreetingsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListGreetingsResponse.Size(m)
+}
+func (m *ListGreetingsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGreetingsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGreetingsResponse proto.InternalMessageInfo
+
+func (m *ListGreetingsResponse) GetGreetings() []*GreetingRecord {
+	if m != nil {
+		return m.Greetings
+	}
+	return nil
+}
+
+func (m *ListGreetingsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetGreetingStatsRequest struct {
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	StartTime            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetGreetingStatsRequest) Reset()         { *m = GetGreetingStatsRequest{} }
+func (m *GetGreetingStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGreetingStatsRequest) ProtoMessage()    {}
+func (*GetGreetingStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{15}
+}
+func (m *GetGreetingStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGreetingStatsRequest.Unmarshal(m, b)
+}
+func (m *GetGreetingStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGreetingStatsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetGreetingStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGreetingStatsRequest.Merge(dst, src)
+}
+func (m *GetGreetingStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGreetingStatsRequest.Size(m)
+}
+func (m *GetGreetingStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGreetingStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGreetingStatsRequest proto.InternalMessageInfo
+
+func (m *GetGreetingStatsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetGreetingStatsRequest) GetStartTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *GetGreetingStatsRequest) GetEndTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+type GetGreetingStatsResponse struct {
+	Total int64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// number of greetings per RPC name
+	ByRpc map[string]int64 `protobuf:"bytes,2,rep,name=by_rpc,json=byRpc,proto3" json:"by_rpc,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// number of distinct first and last name pairs
+	UniqueNames          int64                  `protobuf:"varint,3,opt,name=unique_names,json=uniqueNames,proto3" json:"unique_names,omitempty"`
+	FirstTime            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=first_time,json=firstTime,proto3" json:"first_time,omitempty"`
+	LastTime             *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_time,json=lastTime,proto3" json:"last_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetGreetingStatsResponse) Reset()         { *m = GetGreetingStatsResponse{} }
+func (m *GetGreetingStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGreetingStatsResponse) ProtoMessage()    {}
+func (*GetGreetingStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_greet_4c9a93d3e88a37ce, []int{16}
+}
+func (m *GetGreetingStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGreetingStatsResponse.Unmarshal(m, b)
+}
+func (m *GetGreetingStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGreetingStatsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetGreetingStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGreetingStatsResponse.Merge(dst, src)
+}
+func (m *GetGreetingStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGreetingStatsResponse.Size(m)
+}
+func (m *GetGreetingStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGreetingStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGreetingStatsResponse proto.InternalMessageInfo
+
+func (m *GetGreetingStatsResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *GetGreetingStatsResponse) GetByRpc() map[string]int64 {
+	if m != nil {
+		return m.ByRpc
+	}
+	return nil
+}
+
+func (m *GetGreetingStatsResponse) GetUniqueNames() int64 {
+	if m != nil {
+		return m.UniqueNames
+	}
+	return 0
+}
+
+func (m *GetGreetingStatsResponse) GetFirstTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.FirstTime
+	}
+	return nil
+}
+
+func (m *GetGreetingStatsResponse) GetLastTime() *timestamppb.Timestamp {
+	if m != nil {
+		return m.LastTime
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Greeting)(nil), "greet.Greeting")
 	proto.RegisterType((*GreetRequest)(nil), "greet.GreetRequest")
@@ -457,10 +965,19 @@ func init() {
 	proto.RegisterType((*GreetManyTimesResponse)(nil), "greet.GreetManyTimesResponse")
 	proto.RegisterType((*LongGreetRequest)(nil), "greet.LongGreetRequest")
 	proto.RegisterType((*LongGreetResponse)(nil), "greet.LongGreetResponse")
+	proto.RegisterType((*LongGreetSummary)(nil), "greet.LongGreetSummary")
+	proto.RegisterMapType((map[string]int64)(nil), "greet.LongGreetSummary.LastNameCountsEntry")
 	proto.RegisterType((*GreetEveryoneRequest)(nil), "greet.GreetEveryoneRequest")
 	proto.RegisterType((*GreetEveryoneResponse)(nil), "greet.GreetEveryoneResponse")
 	proto.RegisterType((*GreetWithDeadLineRequest)(nil), "greet.GreetWithDeadLineRequest")
 	proto.RegisterType((*GreetWithDeadLineResponse)(nil), "greet.GreetWithDeadLineResponse")
+	proto.RegisterType((*GreetingRecord)(nil), "greet.GreetingRecord")
+	proto.RegisterType((*ListGreetingsRequest)(nil), "greet.ListGreetingsRequest")
+	proto.RegisterType((*ListGreetingsResponse)(nil), "greet.ListGreetingsResponse")
+	proto.RegisterType((*GetGreetingStatsRequest)(nil), "greet.GetGreetingStatsRequest")
+	proto.RegisterType((*GetGreetingStatsResponse)(nil), "greet.GetGreetingStatsResponse")
+	proto.RegisterMapType((map[string]int64)(nil), "greet.GetGreetingStatsResponse.ByRpcEntry")
+	proto.RegisterEnum("greet.Greeting_Formality", Greeting_Formality_name, Greeting_Formality_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -485,6 +1002,9 @@ type GreetServiceClient interface {
 	GreetEveryone(ctx context.Context, opts ...grpc.CallOption) (GreetService_GreetEveryoneClient, error)
 	// Unary with dead line
 	GreetWithDeadLine(ctx context.Context, in *GreetWithDeadLineRequest, opts ...grpc.CallOption) (*GreetWithDeadLineResponse, error)
+	// History of the greetings produced by the server
+	ListGreetings(ctx context.Context, in *ListGreetingsRequest, opts ...grpc.CallOption) (*ListGreetingsResponse, error)
+	GetGreetingStats(ctx context.Context, in *GetGreetingStatsRequest, opts ...grpc.CallOption) (*GetGreetingStatsResponse, error)
 }
 
 type greetServiceClient struct {
@@ -610,6 +1130,24 @@ func (c *greetServiceClient) GreetWithDeadLine(ctx context.Context, in *GreetWit
 	return out, nil
 }
 
+func (c *greetServiceClient) ListGreetings(ctx context.Context, in *ListGreetingsRequest, opts ...grpc.CallOption) (*ListGreetingsResponse, error) {
+	out := new(ListGreetingsResponse)
+	err := c.cc.Invoke(ctx, "/greet.GreetService/ListGreetings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greetServiceClient) GetGreetingStats(ctx context.Context, in *GetGreetingStatsRequest, opts ...grpc.CallOption) (*GetGreetingStatsResponse, error) {
+	out := new(GetGreetingStatsResponse)
+	err := c.cc.Invoke(ctx, "/greet.GreetService/GetGreetingStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GreetServiceServer is the server API for GreetService service.
 type GreetServiceServer interface {
 	// unary
@@ -622,6 +1160,9 @@ type GreetServiceServer interface {
 	GreetEveryone(GreetService_GreetEveryoneServer) error
 	// Unary with dead line
 	GreetWithDeadLine(context.Context, *GreetWithDeadLineRequest) (*GreetWithDeadLineResponse, error)
+	// History of the greetings produced by the server
+	ListGreetings(context.Context, *ListGreetingsRequest) (*ListGreetingsResponse, error)
+	GetGreetingStats(context.Context, *GetGreetingStatsRequest) (*GetGreetingStatsResponse, error)
 }
 
 func RegisterGreetServiceServer(s *grpc.Server, srv GreetServiceServer) {
@@ -737,6 +1278,42 @@ func _GreetService_GreetWithDeadLine_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GreetService_ListGreetings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGreetingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreetServiceServer).ListGreetings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/greet.GreetService/ListGreetings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreetServiceServer).ListGreetings(ctx, req.(*ListGreetingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GreetService_GetGreetingStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGreetingStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreetServiceServer).GetGreetingStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/greet.GreetService/GetGreetingStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreetServiceServer).GetGreetingStats(ctx, req.(*GetGreetingStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _GreetService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "greet.GreetService",
 	HandlerType: (*GreetServiceServer)(nil),
@@ -749,6 +1326,14 @@ var _GreetService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GreetWithDeadLine",
 			Handler:    _GreetService_GreetWithDeadLine_Handler,
 		},
+		{
+			MethodName: "ListGreetings",
+			Handler:    _GreetService_ListGreetings_Handler,
+		},
+		{
+			MethodName: "GetGreetingStats",
+			Handler:    _GreetService_GetGreetingStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -771,32 +1356,84 @@ var _GreetService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "greet/greetpb/greet.proto",
 }
 
-func init() { proto.RegisterFile("greet/greetpb/greet.proto", fileDescriptor_greet_a2b77d57f14145f7) }
-
-var fileDescriptor_greet_a2b77d57f14145f7 = []byte{
-	// 374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x4f, 0x4f, 0xf2, 0x40,
-	0x10, 0xc6, 0xdf, 0xbe, 0x06, 0xa4, 0xe3, 0x5f, 0x46, 0x44, 0x28, 0x10, 0x49, 0x2f, 0x92, 0x90,
-	0x00, 0x01, 0x6f, 0x1e, 0x4c, 0x10, 0xe5, 0x82, 0x46, 0xd1, 0x44, 0xe3, 0xc5, 0x14, 0x1d, 0x6b,
-	0x13, 0x68, 0xb1, 0x5d, 0x48, 0xf8, 0x04, 0x7e, 0x6d, 0xc3, 0xb2, 0x0b, 0xa5, 0x80, 0x4d, 0x7a,
-	0x69, 0xbb, 0xfb, 0x3c, 0xfb, 0x7b, 0xb6, 0x3b, 0x93, 0x85, 0xac, 0xe9, 0x12, 0xb1, 0x2a, 0x7f,
-	0x0e, 0x7b, 0xb3, 0x77, 0x65, 0xe8, 0x3a, 0xcc, 0xc1, 0x18, 0x1f, 0xe8, 0x37, 0x90, 0x68, 0x4f,
-	0x3f, 0x2c, 0xdb, 0xc4, 0x02, 0xc0, 0xa7, 0xe5, 0x7a, 0xec, 0xcd, 0x36, 0x06, 0x94, 0x51, 0x8a,
-	0x4a, 0x49, 0xed, 0xaa, 0x7c, 0xe6, 0xce, 0x18, 0x10, 0xe6, 0x40, 0xed, 0x1b, 0x52, 0xfd, 0xcf,
-	0xd5, 0xc4, 0x74, 0x62, 0x2a, 0xea, 0x17, 0xb0, 0xcb, 0x39, 0x5d, 0xfa, 0x1e, 0x91, 0xc7, 0xb0,
-	0x0c, 0x09, 0x53, 0x70, 0x39, 0x69, 0xa7, 0x7e, 0x50, 0x99, 0xc5, 0xcb, 0xb8, 0xee, 0xdc, 0xa0,
-	0x9f, 0xc1, 0x9e, 0x58, 0xec, 0x0d, 0x1d, 0xdb, 0x23, 0x4c, 0x43, 0xdc, 0x25, 0x6f, 0xd4, 0x67,
-	0x62, 0x17, 0x62, 0xa4, 0xb7, 0xe0, 0x98, 0x1b, 0x6f, 0x0d, 0x7b, 0xf2, 0x64, 0x0d, 0xc8, 0x8b,
-	0x14, 0x57, 0x83, 0x74, 0x90, 0x12, 0x92, 0x7b, 0x09, 0x87, 0x1d, 0xc7, 0x36, 0xa3, 0xff, 0x61,
-	0x19, 0x92, 0x3e, 0x40, 0x48, 0xda, 0x15, 0xa4, 0xb8, 0xf1, 0x7a, 0x4c, 0xee, 0xc4, 0xb1, 0x29,
-	0x52, 0x62, 0x55, 0x1c, 0xd5, 0x02, 0x12, 0x92, 0xda, 0x86, 0x0c, 0x5f, 0xf0, 0x6c, 0xb1, 0xaf,
-	0x16, 0x19, 0x1f, 0x1d, 0x2b, 0x62, 0x72, 0x03, 0xb2, 0x6b, 0x40, 0x7f, 0xa7, 0xd7, 0x7f, 0xb6,
-	0x44, 0x03, 0x3d, 0x92, 0x3b, 0xb6, 0xde, 0x09, 0xcf, 0x21, 0xc6, 0xc7, 0x78, 0xe4, 0x4f, 0x12,
-	0x1b, 0xd2, 0x52, 0xcb, 0x93, 0x33, 0xb8, 0xfe, 0x0f, 0x1f, 0x60, 0x7f, 0xb9, 0xb4, 0x98, 0xf7,
-	0x3b, 0x83, 0x7d, 0xa3, 0x15, 0x36, 0xa8, 0x12, 0x58, 0x53, 0xb0, 0x09, 0xea, 0xbc, 0x74, 0x78,
-	0x22, 0xfc, 0xc1, 0x6e, 0xd0, 0x32, 0xab, 0x82, 0x64, 0x94, 0x14, 0xbc, 0x17, 0x0d, 0x2e, 0x8b,
-	0x81, 0x39, 0x7f, 0x6e, 0xa0, 0xce, 0x5a, 0x7e, 0xbd, 0xb8, 0xe0, 0xd5, 0x14, 0x7c, 0x81, 0xe4,
-	0xca, 0x21, 0xe3, 0xa9, 0x7f, 0xe1, 0x9a, 0x3a, 0x6a, 0xc5, 0xcd, 0x06, 0x49, 0x6f, 0xaa, 0xaf,
-	0xdb, 0xe2, 0xbe, 0xe8, 0xc5, 0xf9, 0x55, 0xd1, 0xf8, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xe0, 0x9f,
-	0x71, 0xee, 0x47, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("greet/greetpb/greet.proto", fileDescriptor_greet_4c9a93d3e88a37ce) }
+
+var fileDescriptor_greet_4c9a93d3e88a37ce = []byte{
+	// 1208 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xcf, 0x6e, 0xdb, 0xc6,
+	0x13, 0x36, 0x29, 0x51, 0x16, 0x47, 0xb1, 0xc2, 0xac, 0xa5, 0x84, 0x66, 0x14, 0x47, 0x3f, 0xfe,
+	0x90, 0xc6, 0x75, 0x0b, 0x29, 0x96, 0x11, 0x34, 0x75, 0x4f, 0x76, 0xfe, 0xb5, 0xa8, 0xf3, 0x07,
+	0xb4, 0x8b, 0x02, 0x3d, 0x54, 0x58, 0x89, 0x6b, 0x95, 0x09, 0x45, 0x2a, 0xe4, 0x52, 0xa8, 0x72,
+	0x2a, 0x8a, 0xbe, 0x41, 0xdf, 0xa1, 0x40, 0x5f, 0xa2, 0xa7, 0xbe, 0x41, 0x8f, 0x05, 0x7a, 0xea,
+	0xb9, 0x57, 0x03, 0x3e, 0x15, 0xdc, 0x5d, 0x52, 0x14, 0x25, 0x57, 0x4e, 0x7c, 0x49, 0xb8, 0x33,
+	0xdf, 0xce, 0xcc, 0x7e, 0x3b, 0xf3, 0xad, 0x0c, 0x1b, 0x83, 0x80, 0x10, 0xda, 0x66, 0xff, 0x8e,
+	0x7a, 0xfc, 0xff, 0xd6, 0x28, 0xf0, 0xa9, 0x8f, 0x14, 0xb6, 0x30, 0x1a, 0x03, 0xdf, 0x1f, 0xb8,
+	0xa4, 0x8d, 0x47, 0x4e, 0x1b, 0x7b, 0x9e, 0x4f, 0x31, 0x75, 0x7c, 0x2f, 0xe4, 0x20, 0x63, 0x53,
+	0x78, 0xd9, 0xaa, 0x17, 0x9d, 0xb4, 0xed, 0x28, 0x60, 0x00, 0xe1, 0xbf, 0x9d, 0xf7, 0x53, 0x67,
+	0x48, 0x42, 0x8a, 0x87, 0x23, 0x01, 0x30, 0xc7, 0xd8, 0x75, 0x6c, 0x4c, 0x49, 0x3b, 0xf9, 0x18,
+	0xf5, 0xd2, 0x4f, 0x8e, 0x31, 0xff, 0x92, 0xa0, 0xfc, 0x34, 0x2e, 0xc6, 0xf1, 0x06, 0xe8, 0x43,
+	0x80, 0x13, 0x27, 0x08, 0x69, 0xd7, 0xc3, 0x43, 0xa2, 0x4b, 0x4d, 0x69, 0x4b, 0x3d, 0x80, 0xb3,
+	0x53, 0xbd, 0x64, 0x14, 0xcb, 0x92, 0x66, 0x5b, 0x2a, 0xf3, 0x3e, 0xc7, 0x43, 0x82, 0xee, 0x80,
+	0xea, 0xe2, 0x04, 0x29, 0x33, 0x64, 0xf9, 0xec, 0x54, 0x2f, 0x1a, 0xb2, 0x66, 0x5b, 0x65, 0x17,
+	0x0b, 0x58, 0x13, 0x4a, 0xae, 0xdf, 0xc7, 0x2e, 0xd1, 0x0b, 0x33, 0x98, 0xff, 0x5b, 0xc2, 0x8e,
+	0x3e, 0x01, 0xf5, 0xc4, 0x0f, 0x86, 0xd8, 0x75, 0xe8, 0x44, 0x2f, 0x36, 0xa5, 0xad, 0x6a, 0x67,
+	0xa3, 0xc5, 0xb9, 0x4a, 0xea, 0x6a, 0x3d, 0x49, 0x00, 0xd6, 0x14, 0x6b, 0xde, 0x01, 0x35, 0xb5,
+	0xa3, 0x2b, 0x50, 0xfe, 0xe2, 0xf9, 0x93, 0x17, 0xd6, 0xb3, 0xfd, 0x43, 0x6d, 0x05, 0x01, 0x94,
+	0xc4, 0xb7, 0x64, 0x3e, 0x84, 0x2b, 0x2c, 0x8e, 0x45, 0xde, 0x44, 0x24, 0xa4, 0x68, 0x17, 0xca,
+	0x03, 0x11, 0x97, 0x9d, 0xb0, 0xd2, 0xb9, 0x9a, 0x4b, 0x77, 0x50, 0x3a, 0x3b, 0xd5, 0xe5, 0xb2,
+	0x64, 0xa5, 0x40, 0xf3, 0x2e, 0xac, 0x89, 0x20, 0xe1, 0xc8, 0xf7, 0x42, 0x82, 0xae, 0x43, 0x29,
+	0x20, 0x61, 0xe4, 0x52, 0xce, 0x92, 0x25, 0x56, 0xe6, 0x4f, 0x32, 0xd4, 0x19, 0xf2, 0x19, 0xf6,
+	0x26, 0xc7, 0xf1, 0x7d, 0x5c, 0x26, 0x2f, 0xfa, 0x18, 0xae, 0xc4, 0x81, 0x87, 0xa4, 0x4b, 0xfd,
+	0xd7, 0xc4, 0x13, 0x44, 0xab, 0x67, 0xa7, 0xba, 0x62, 0x14, 0xb4, 0x1f, 0x8a, 0x56, 0x85, 0xbb,
+	0x8f, 0x63, 0x2f, 0xda, 0x04, 0xa5, 0xef, 0x47, 0x1e, 0x65, 0x5c, 0x2b, 0x9c, 0x6b, 0x53, 0xd6,
+	0x56, 0x2c, 0x6e, 0x46, 0xf7, 0xa1, 0xec, 0x78, 0x94, 0x04, 0x63, 0xec, 0x32, 0xa6, 0x2b, 0x31,
+	0xd3, 0xac, 0x87, 0x5a, 0x49, 0x0f, 0xb5, 0x1e, 0x89, 0x1e, 0xb3, 0x52, 0x28, 0xda, 0x81, 0xd2,
+	0x2b, 0x87, 0x52, 0x12, 0xe8, 0xca, 0xb2, 0x4d, 0x02, 0x68, 0x1e, 0xc1, 0xf5, 0x3c, 0x0b, 0xff,
+	0x4d, 0x1c, 0xfa, 0xdf, 0xa2, 0x93, 0xce, 0x1c, 0xcf, 0x1c, 0x80, 0x76, 0xe8, 0x7b, 0x83, 0x4b,
+	0xdf, 0x26, 0xda, 0x04, 0x08, 0x69, 0x10, 0xf5, 0x69, 0x14, 0x10, 0x9b, 0x65, 0x2a, 0x5b, 0x19,
+	0x8b, 0xf9, 0x2d, 0x5c, 0xcb, 0x24, 0x5a, 0x52, 0xf8, 0x0e, 0xac, 0x86, 0xd1, 0x70, 0x88, 0x83,
+	0x09, 0x8b, 0x54, 0xe9, 0xdc, 0x10, 0x05, 0xa4, 0x21, 0x8e, 0xb8, 0xdb, 0x4a, 0x70, 0xe6, 0x6f,
+	0x32, 0x68, 0x79, 0x2f, 0x6a, 0x80, 0x9a, 0x14, 0x18, 0xea, 0x52, 0xb3, 0xb0, 0xa5, 0x5a, 0x53,
+	0x03, 0xfa, 0x0a, 0xb4, 0x74, 0xdc, 0xba, 0xec, 0x36, 0x43, 0x5d, 0x6e, 0x16, 0xb6, 0x2a, 0x9d,
+	0x8f, 0xce, 0x49, 0xd7, 0x3a, 0x14, 0x33, 0xf8, 0x90, 0xa1, 0x1f, 0x7b, 0x34, 0x98, 0x58, 0x55,
+	0x77, 0xc6, 0x88, 0x3e, 0x4d, 0x06, 0x3e, 0x96, 0x0e, 0xd6, 0x36, 0x95, 0x8e, 0x31, 0x77, 0xbd,
+	0xc7, 0x89, 0xae, 0x08, 0x01, 0x88, 0xd7, 0xf1, 0xdc, 0xba, 0x38, 0xd9, 0x59, 0x5c, 0xba, 0x93,
+	0x49, 0x42, 0xbc, 0x34, 0xf6, 0x61, 0x7d, 0x41, 0x69, 0x48, 0x83, 0xc2, 0x6b, 0x32, 0x11, 0xe4,
+	0xc6, 0x9f, 0xa8, 0x06, 0xca, 0x18, 0xbb, 0x11, 0x97, 0x97, 0x82, 0xc5, 0x17, 0x7b, 0xf2, 0x03,
+	0xc9, 0xfc, 0x12, 0x6a, 0xec, 0xa8, 0x8f, 0xc7, 0x24, 0x98, 0xf8, 0x1e, 0xb9, 0xd4, 0x6c, 0xb7,
+	0xa1, 0x9e, 0x0b, 0xb6, 0x64, 0xc6, 0x5f, 0x80, 0xce, 0x36, 0x7c, 0xed, 0xd0, 0xef, 0x1e, 0x11,
+	0x6c, 0x1f, 0x3a, 0x97, 0xac, 0x60, 0x17, 0x36, 0x16, 0x04, 0x5c, 0x52, 0xc5, 0x9f, 0x12, 0x54,
+	0x93, 0x98, 0x16, 0xe9, 0xfb, 0x81, 0x8d, 0xaa, 0x20, 0x3b, 0x36, 0x83, 0x15, 0x2c, 0xd9, 0xb1,
+	0xd1, 0xad, 0x19, 0x39, 0xe7, 0x13, 0x95, 0x91, 0xf0, 0x9b, 0x59, 0x09, 0x67, 0xf2, 0x9c, 0x11,
+	0x6e, 0x0d, 0x0a, 0xc1, 0xa8, 0xcf, 0x2e, 0x56, 0xb5, 0xe2, 0xcf, 0x4c, 0x21, 0xca, 0xcc, 0x00,
+	0x5c, 0x87, 0x52, 0x1f, 0xbb, 0x2e, 0x09, 0xf4, 0x12, 0xb7, 0xf3, 0x15, 0xfa, 0x0c, 0x2a, 0xfd,
+	0x80, 0x60, 0x4a, 0x78, 0x8b, 0xac, 0x2e, 0x6d, 0x11, 0xe0, 0xf0, 0xd8, 0x60, 0xfe, 0x23, 0x41,
+	0xed, 0xd0, 0x09, 0x69, 0x72, 0xc2, 0x54, 0x46, 0xef, 0x82, 0x3a, 0xc2, 0x03, 0xd2, 0x0d, 0x9d,
+	0xb7, 0xfc, 0x85, 0x52, 0xf8, 0x0b, 0x65, 0x16, 0xb5, 0x95, 0xa6, 0x6d, 0x95, 0x63, 0xe7, 0x91,
+	0xf3, 0x96, 0xc4, 0x87, 0x67, 0xc0, 0xac, 0x9c, 0xb0, 0xad, 0x5c, 0x2b, 0x1b, 0x50, 0x9c, 0x9e,
+	0x3b, 0xf3, 0x74, 0x31, 0x6b, 0x3c, 0x17, 0x21, 0xc5, 0xc1, 0x85, 0xbb, 0x5b, 0x65, 0x68, 0x36,
+	0x17, 0xf7, 0xa1, 0x4c, 0x3c, 0x9b, 0x6f, 0x54, 0x96, 0x6e, 0x5c, 0x25, 0x9e, 0xcd, 0x0e, 0x4c,
+	0xa1, 0x9e, 0x3b, 0xaf, 0xb8, 0xff, 0xdd, 0xbc, 0x2e, 0x54, 0x3a, 0xf5, 0x5c, 0x4b, 0xf1, 0xeb,
+	0xcf, 0xca, 0xc5, 0x07, 0x70, 0xd5, 0x23, 0xdf, 0xd3, 0xee, 0x1c, 0x03, 0x6b, 0xb1, 0xf9, 0x65,
+	0xc2, 0x82, 0xf9, 0xab, 0x04, 0x37, 0x9e, 0x92, 0x34, 0xeb, 0x11, 0xc5, 0x34, 0x65, 0x3a, 0x61,
+	0x48, 0xba, 0x00, 0x43, 0xf2, 0xfb, 0x32, 0x54, 0xb8, 0x38, 0x43, 0xbf, 0xcb, 0xa0, 0xcf, 0xd7,
+	0x2a, 0x58, 0xaa, 0x81, 0x42, 0x7d, 0x8a, 0x5d, 0xd1, 0xfd, 0x7c, 0x81, 0xf6, 0xa1, 0xd4, 0x9b,
+	0x74, 0xe3, 0x3e, 0xe6, 0x5a, 0xb9, 0x9d, 0x10, 0x77, 0x4e, 0x98, 0xd6, 0xc1, 0xc4, 0x1a, 0xf5,
+	0xb9, 0x54, 0x2a, 0xbd, 0xf8, 0x3b, 0x7e, 0x97, 0x22, 0xcf, 0x79, 0x13, 0x11, 0x36, 0x26, 0x21,
+	0x2b, 0xb8, 0x60, 0x55, 0xb8, 0x2d, 0x9e, 0x94, 0xbc, 0x88, 0x16, 0xdf, 0x5b, 0x44, 0x95, 0x77,
+	0x10, 0xd1, 0x07, 0x00, 0xd3, 0x5a, 0xdf, 0x45, 0x3b, 0x3b, 0xbf, 0x28, 0xe2, 0x07, 0xd1, 0x11,
+	0x09, 0xc6, 0x4e, 0x9f, 0xa0, 0xcf, 0x41, 0x61, 0x6b, 0xb4, 0x9e, 0x6d, 0x2b, 0xd1, 0x05, 0x46,
+	0x6d, 0xd6, 0xc8, 0x79, 0x32, 0x6b, 0x3f, 0xfe, 0xf1, 0xf7, 0xcf, 0x72, 0x75, 0x4f, 0xda, 0x36,
+	0xd5, 0xf6, 0x78, 0x87, 0xff, 0xb6, 0x45, 0xaf, 0xa0, 0x3a, 0xfb, 0xea, 0xa3, 0x46, 0x76, 0x77,
+	0xfe, 0x27, 0x91, 0x71, 0xeb, 0x1c, 0xaf, 0x48, 0xb2, 0xc1, 0x92, 0xac, 0x9b, 0xd5, 0x34, 0x43,
+	0x7b, 0x88, 0xbd, 0xc9, 0x9e, 0xb4, 0x7d, 0x4f, 0x42, 0x07, 0xa0, 0xa6, 0x2f, 0x1e, 0x9a, 0x7b,
+	0x72, 0x93, 0x0c, 0xfa, 0xbc, 0x43, 0x04, 0x5f, 0xd9, 0x92, 0xd0, 0x4b, 0x58, 0x9b, 0x51, 0x7e,
+	0x74, 0x33, 0x5b, 0x50, 0xee, 0x71, 0x31, 0x1a, 0x8b, 0x9d, 0xd3, 0x78, 0xf7, 0x24, 0x14, 0xc1,
+	0xb5, 0x39, 0x25, 0x47, 0xb7, 0xb3, 0x1b, 0x17, 0x3c, 0x1a, 0x46, 0xf3, 0x7c, 0x80, 0x88, 0x7e,
+	0x8b, 0x51, 0x71, 0xc3, 0x44, 0x53, 0x2a, 0x6c, 0x82, 0x6d, 0xd7, 0xf1, 0xc8, 0x9e, 0xb4, 0x8d,
+	0x30, 0xac, 0xcd, 0x88, 0x47, 0x7a, 0x90, 0x45, 0x12, 0x6a, 0x34, 0x16, 0x3b, 0x45, 0xaa, 0x3a,
+	0x4b, 0x75, 0x15, 0xad, 0xa5, 0xa9, 0x58, 0xc4, 0x11, 0x68, 0xf9, 0xa9, 0x41, 0x9b, 0xe7, 0x8e,
+	0x13, 0x4f, 0x74, 0x7b, 0xc9, 0xb8, 0x99, 0x37, 0x59, 0xae, 0x3a, 0x5a, 0x9f, 0xc9, 0xd5, 0x0e,
+	0x63, 0xd0, 0x81, 0xfa, 0xcd, 0xaa, 0xf8, 0xd3, 0xa9, 0x57, 0x62, 0xb3, 0xb0, 0xfb, 0xef, 0x00,
+	0x82, 0x13, 0xb6, 0xfb, 0x52, 0x0d, 0x00, 0x00,
 }
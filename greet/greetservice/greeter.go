@@ -0,0 +1,143 @@
+package greetservice
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+)
+
+// the keys of the messages of a locale file
+const (
+	informalKey = "informal"
+	formalKey   = "formal"
+)
+
+//go:embed locales/*.json
+var builtinLocales embed.FS
+
+// locale is the content of a locale file. The templates take the first
+// name as %[1]s and the last name as %[2]s, so each locale picks its order.
+type locale struct {
+	Locale   string `json:"locale"`
+	Informal string `json:"informal"`
+	Formal   string `json:"formal"`
+}
+
+// greeter renders greetings in the language negotiated for each call
+type greeter struct {
+	catalog *catalog.Builder
+	matcher language.Matcher
+	tags    []language.Tag
+}
+
+// newGreeter loads the built-in locales and then the *.json files of dir,
+// which override the built-in ones of the same locale
+func newGreeter(defaultLocale string, dir string) (*greeter, error) {
+	def, err := language.Parse(defaultLocale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default locale %q: %v", defaultLocale, err)
+	}
+
+	builtin, err := fs.Sub(builtinLocales, "locales")
+	if err != nil {
+		return nil, err
+	}
+	locales := map[language.Tag]locale{}
+	if err := loadLocales(builtin, locales); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := loadLocales(os.DirFS(dir), locales); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := locales[def]; !ok {
+		return nil, fmt.Errorf("no templates for the default locale %v", def)
+	}
+
+	g := &greeter{
+		catalog: catalog.NewBuilder(catalog.Fallback(def)),
+		// the first tag is the one the matcher falls back to
+		tags: []language.Tag{def},
+	}
+	for tag, l := range locales {
+		if l.Formal == "" {
+			l.Formal = l.Informal
+		}
+		g.catalog.SetString(tag, informalKey, l.Informal)
+		g.catalog.SetString(tag, formalKey, l.Formal)
+		if tag != def {
+			g.tags = append(g.tags, tag)
+		}
+	}
+	g.matcher = language.NewMatcher(g.tags)
+
+	return g, nil
+}
+
+func loadLocales(fsys fs.FS, locales map[language.Tag]locale) error {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		var l locale
+		if err := json.Unmarshal(b, &l); err != nil {
+			return fmt.Errorf("invalid locale file %v: %v", filepath.Base(name), err)
+		}
+		tag, err := language.Parse(l.Locale)
+		if err != nil {
+			return fmt.Errorf("invalid locale %q in %v: %v", l.Locale, filepath.Base(name), err)
+		}
+		if l.Informal == "" {
+			return fmt.Errorf("locale file %v has no %q template", filepath.Base(name), informalKey)
+		}
+		locales[tag] = l
+	}
+	return nil
+}
+
+// greet renders the greeting in the locale of the Greeting, falling back to
+// the accept-language metadata of ctx and then to the default locale
+func (g *greeter) greet(ctx context.Context, greeting *greetpb.Greeting) string {
+	key := informalKey
+	if greeting.GetFormality() == greetpb.Greeting_FORMAL {
+		key = formalKey
+	}
+
+	p := message.NewPrinter(g.match(ctx, greeting.GetLocale()), message.Catalog(g.catalog))
+	return p.Sprintf(key, greeting.GetFirstName(), greeting.GetLastName())
+}
+
+func (g *greeter) match(ctx context.Context, locale string) language.Tag {
+	var wanted []language.Tag
+	if tag, err := language.Parse(locale); err == nil {
+		wanted = append(wanted, tag)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, header := range md.Get("accept-language") {
+			// unparsable entries are skipped, the rest is still used
+			tags, _, _ := language.ParseAcceptLanguage(header)
+			wanted = append(wanted, tags...)
+		}
+	}
+
+	_, i, _ := g.matcher.Match(wanted...)
+	return g.tags[i]
+}
@@ -0,0 +1,112 @@
+package greetservice
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/history"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+const defaultPageSize = 20
+
+// record stores a greeting in the history, failures are only logged so they
+// never break the greeting itself
+func (s *Server) record(ctx context.Context, rpc string, greeting *greetpb.Greeting, result string) {
+	r := &history.Record{
+		FirstName: greeting.GetFirstName(),
+		LastName:  greeting.GetLastName(),
+		RPC:       rpc,
+		Result:    result,
+		At:        time.Now(),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		r.Caller = p.Addr.String()
+	}
+	if err := s.history.Add(context.Background(), r); err != nil {
+		logging.Warningf("Failed recording greeting: %v", err)
+	}
+}
+
+func (s *Server) ListGreetings(ctx context.Context, req *greetpb.ListGreetingsRequest) (*greetpb.ListGreetingsResponse, error) {
+	logging.Infof("ListGreetings function was invoked with %v", req)
+
+	f := history.Filter{
+		Name: req.GetName(),
+		From: timeOf(req.GetStartTime()),
+		To:   timeOf(req.GetEndTime()),
+	}
+	if token := req.GetPageToken(); token != "" {
+		before, err := strconv.ParseInt(token, 10, 64)
+		if err != nil || before <= 0 {
+			return nil, rpcerror.InvalidArgument("INVALID_PAGE_TOKEN", rpcerror.Violation("page_token", "is not a token returned by a previous call"))
+		}
+		f.Before = before
+	}
+	limit := int(req.GetPageSize())
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+
+	records, err := s.history.List(ctx, f, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed listing greetings: %v", err)
+	}
+
+	res := &greetpb.ListGreetingsResponse{}
+	for _, r := range records {
+		res.Greetings = append(res.Greetings, &greetpb.GreetingRecord{
+			Id:         r.ID,
+			FirstName:  r.FirstName,
+			LastName:   r.LastName,
+			Rpc:        r.RPC,
+			Result:     r.Result,
+			Caller:     r.Caller,
+			CreateTime: timestamppb.New(r.At),
+		})
+	}
+	if len(records) == limit {
+		res.NextPageToken = strconv.FormatInt(records[len(records)-1].ID, 10)
+	}
+	return res, nil
+}
+
+func (s *Server) GetGreetingStats(ctx context.Context, req *greetpb.GetGreetingStatsRequest) (*greetpb.GetGreetingStatsResponse, error) {
+	logging.Infof("GetGreetingStats function was invoked with %v", req)
+
+	stats, err := s.history.Stats(ctx, history.Filter{
+		Name: req.GetName(),
+		From: timeOf(req.GetStartTime()),
+		To:   timeOf(req.GetEndTime()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed computing greeting stats: %v", err)
+	}
+
+	res := &greetpb.GetGreetingStatsResponse{
+		Total:       stats.Total,
+		ByRpc:       stats.ByRPC,
+		UniqueNames: stats.UniqueNames,
+	}
+	if stats.Total > 0 {
+		res.FirstTime = timestamppb.New(stats.First)
+		res.LastTime = timestamppb.New(stats.Last)
+	}
+	return res, nil
+}
+
+// timeOf converts an optional timestamp, nil is the zero time
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,77 @@
+package greetservice
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// LongGreetLimitReason is the ErrorInfo reason of a LongGreet stream
+// exceeding the limits of the server
+const LongGreetLimitReason = "LONG_GREET_LIMIT_EXCEEDED"
+
+// longGreetLimits bound the response of a LongGreet stream, zero means
+// unbounded
+type longGreetLimits struct {
+	maxCount int
+	maxSize  int
+}
+
+// longGreet aggregates the greetings of a LongGreet stream
+type longGreet struct {
+	limits     longGreetLimits
+	structured bool
+	count      int
+	size       int
+	result     []byte
+	summary    *greetpb.LongGreetSummary
+}
+
+func newLongGreet(limits longGreetLimits) *longGreet {
+	return &longGreet{limits: limits}
+}
+
+// add aggregates the greeting of req received at t, the first request
+// chooses the kind of response
+func (a *longGreet) add(req *greetpb.LongGreetRequest, greeting string, t time.Time) error {
+	if a.count == 0 && req.GetStructured() {
+		a.structured = true
+		a.summary = &greetpb.LongGreetSummary{
+			LastNameCounts: map[string]int64{},
+			FirstTime:      timestamppb.New(t),
+		}
+	}
+
+	a.count++
+	if a.limits.maxCount > 0 && a.count > a.limits.maxCount {
+		return rpcerror.New(codes.ResourceExhausted, LongGreetLimitReason, "LongGreet accepts at most %d greetings", a.limits.maxCount)
+	}
+	if !a.structured {
+		greeting += "! "
+	}
+	a.size += len(greeting)
+	if a.limits.maxSize > 0 && a.size > a.limits.maxSize {
+		return rpcerror.New(codes.ResourceExhausted, LongGreetLimitReason, "LongGreet responses are at most %d bytes of greetings", a.limits.maxSize)
+	}
+
+	if !a.structured {
+		a.result = append(a.result, greeting...)
+		return nil
+	}
+	a.summary.Greetings = append(a.summary.Greetings, greeting)
+	a.summary.LastNameCounts[req.GetGreeting().GetLastName()]++
+	a.summary.LastTime = timestamppb.New(t)
+	return nil
+}
+
+// response returns the aggregated greetings
+func (a *longGreet) response() *greetpb.LongGreetResponse {
+	if a.structured {
+		return &greetpb.LongGreetResponse{Summary: a.summary}
+	}
+	return &greetpb.LongGreetResponse{Result: string(a.result)}
+}
@@ -0,0 +1,96 @@
+package greetservice
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+const (
+	defaultGreetCount    = 10
+	defaultGreetInterval = time.Second
+)
+
+// pacingLimits are the server-enforced maximums of a GreetManyTimes request
+type pacingLimits struct {
+	maxCount    int
+	maxInterval time.Duration
+}
+
+// pacing is how many greetings GreetManyTimes sends and how fast
+type pacing struct {
+	count    int
+	interval time.Duration
+	jitter   time.Duration
+}
+
+// pacingOf reads the pacing of req, rejecting values beyond the limits
+func (l pacingLimits) pacingOf(req *greetpb.GreetManyTimesRequest) (pacing, error) {
+	p := pacing{
+		count:    int(req.GetCount()),
+		interval: defaultGreetInterval,
+	}
+	// the default count stays within the limit, a negative one is rejected
+	// rather than sending nothing
+	if p.count == 0 {
+		p.count = min(defaultGreetCount, l.maxCount)
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	switch {
+	case p.count < 0:
+		violations = append(violations, rpcerror.Violation("count", "must not be negative"))
+	case p.count > l.maxCount:
+		violations = append(violations, rpcerror.Violation("count", "must be at most "+strconv.Itoa(l.maxCount)))
+	}
+	if req.GetInterval() != nil {
+		p.interval = req.GetInterval().AsDuration()
+		if v := l.checkDuration("interval", p.interval); v != nil {
+			violations = append(violations, v)
+		}
+	}
+	if req.GetJitter() != nil {
+		p.jitter = req.GetJitter().AsDuration()
+		if v := l.checkDuration("jitter", p.jitter); v != nil {
+			violations = append(violations, v)
+		}
+	}
+	if len(violations) > 0 {
+		return pacing{}, rpcerror.InvalidArgument("INVALID_PACING", violations...)
+	}
+	return p, nil
+}
+
+func (l pacingLimits) checkDuration(field string, d time.Duration) *errdetails.BadRequest_FieldViolation {
+	switch {
+	case d < 0:
+		return rpcerror.Violation(field, "must not be negative")
+	case d > l.maxInterval:
+		return rpcerror.Violation(field, "must be at most "+l.maxInterval.String())
+	}
+	return nil
+}
+
+// wait blocks for an interval plus jitter, or until ctx is done
+func (p pacing) wait(ctx context.Context) error {
+	d := p.interval
+	if p.jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(p.jitter)))
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
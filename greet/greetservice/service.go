@@ -0,0 +1,204 @@
+// Package greetservice implements greetpb.GreetServiceServer so it can be
+// served by the greet server or together with other services.
+package greetservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/history"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+	"github.com/christiangda/grpc-go-course/streaming"
+)
+
+// Config is the configuration of the greet service
+type Config struct {
+	// DefaultLocale is the locale of the greetings when the caller's one is
+	// not supported
+	DefaultLocale string `yaml:"default_locale"`
+	// LocalesDir holds *.json greeting templates overriding the built-in ones
+	LocalesDir string `yaml:"locales_dir"`
+	// MaxGreetCount and MaxGreetInterval bound GreetManyTimes requests, the
+	// interval bound also applies to the jitter
+	MaxGreetCount    int           `yaml:"max_greet_count"`
+	MaxGreetInterval time.Duration `yaml:"max_greet_interval"`
+	// MaxLongGreetCount and MaxLongGreetSize bound the greetings and their
+	// bytes in a LongGreet response, 0 means unbounded
+	MaxLongGreetCount int `yaml:"max_long_greet_count"`
+	MaxLongGreetSize  int `yaml:"max_long_greet_size"`
+	// Pipeline processes the messages of GreetEveryone streams
+	Pipeline streaming.Pipeline `yaml:"pipeline"`
+	// History is the greeting history store: memory, bolt:<path> or
+	// sqlite:<path>
+	History          string            `yaml:"history"`
+	HistoryRetention history.Retention `yaml:"history_retention"`
+}
+
+// DefaultConfig is the configuration of the greet server flags
+var DefaultConfig = Config{
+	DefaultLocale:     "en",
+	MaxGreetCount:     1000,
+	MaxGreetInterval:  time.Minute,
+	MaxLongGreetCount: 10000,
+	MaxLongGreetSize:  1 << 20,
+	Pipeline:          streaming.Pipeline{Workers: 1, Depth: 16},
+	History:           "memory",
+	HistoryRetention:  history.Retention{MaxRecords: 100000, MaxAge: 30 * 24 * time.Hour},
+}
+
+// Server implements greetpb.GreetServiceServer
+type Server struct {
+	greeter         *greeter
+	history         history.Store
+	pacingLimits    pacingLimits
+	longGreetLimits longGreetLimits
+	pipeline        streaming.Pipeline
+}
+
+// New returns a Server configured by cfg, it must be closed to release the
+// history store
+func New(cfg Config) (*Server, error) {
+	if cfg.MaxGreetCount < 1 {
+		return nil, fmt.Errorf("max greet count %d is not positive", cfg.MaxGreetCount)
+	}
+	g, err := newGreeter(cfg.DefaultLocale, cfg.LocalesDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading greeting templates: %v", err)
+	}
+
+	store, err := history.Open(cfg.History, cfg.HistoryRetention)
+	if err != nil {
+		return nil, fmt.Errorf("opening greeting history: %v", err)
+	}
+
+	return &Server{
+		greeter:         g,
+		history:         store,
+		pacingLimits:    pacingLimits{maxCount: cfg.MaxGreetCount, maxInterval: cfg.MaxGreetInterval},
+		longGreetLimits: longGreetLimits{maxCount: cfg.MaxLongGreetCount, maxSize: cfg.MaxLongGreetSize},
+		pipeline:        cfg.Pipeline,
+	}, nil
+}
+
+// Close releases the history store
+func (s *Server) Close() error {
+	return s.history.Close()
+}
+
+func (s *Server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
+	logging.Infof("Greet function was invoked with %v", req)
+	result := s.greeter.greet(ctx, req.GetGreeting())
+	s.record(ctx, "Greet", req.GetGreeting(), result)
+	res := &greetpb.GreetResponse{
+		Result: result,
+	}
+	return res, nil
+}
+
+func (s *Server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+	logging.Infof("GreetManyTimes function was invoked with %v", req)
+	p, err := s.pacingLimits.pacingOf(req)
+	if err != nil {
+		return err
+	}
+	// the state is the number of greetings already sent
+	state, err := resume.State(req, 1)
+	if err != nil {
+		return err
+	}
+	start := 0
+	if state != nil {
+		if state[0] < 0 || state[0] > int64(p.count) {
+			return rpcerror.InvalidArgument(resume.Reason, rpcerror.Violation("resume_token", "has an unexpected state"))
+		}
+		start = int(state[0])
+	}
+
+	greeting := s.greeter.greet(stream.Context(), req.GetGreeting())
+	if start == 0 {
+		s.record(stream.Context(), "GreetManyTimes", req.GetGreeting(), greeting)
+	}
+	for i := start; i < p.count; i++ {
+		if i > start {
+			if err := p.wait(stream.Context()); err != nil {
+				return err
+			}
+		}
+		result := greeting + " number " + strconv.Itoa(i)
+		res := &greetpb.GreetManyTimesResponse{
+			Result:      result,
+			ResumeToken: resume.Token(req, int64(i+1)),
+		}
+		if err := stream.Send(res); err != nil {
+			logging.Warningf("Error while sending data to client stream: %v", err)
+			if _, ok := status.FromError(err); ok {
+				return err
+			}
+			return status.Errorf(codes.Unavailable, "Failed sending greeting: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
+	logging.Infof("LongGreet function was invoked with a streaming request")
+	aggregate := newLongGreet(s.longGreetLimits)
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			// We have finished reading the client stream
+			return stream.SendAndClose(aggregate.response())
+		}
+		if err != nil {
+			logging.Warningf("Error while reading client stream: %v", err)
+			return err
+		}
+
+		greeting := s.greeter.greet(stream.Context(), req.GetGreeting())
+		if err := aggregate.add(req, greeting, time.Now()); err != nil {
+			return err
+		}
+		s.record(stream.Context(), "LongGreet", req.GetGreeting(), greeting)
+	}
+}
+
+func (s *Server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
+	logging.Infof("GreetEveryone function was invoked a streaming request")
+
+	return streaming.Run(stream.Context(), s.pipeline, stream.Recv,
+		func(ctx context.Context, req *greetpb.GreetEveryoneRequest) (*greetpb.GreetEveryoneResponse, bool, error) {
+			greeting := s.greeter.greet(ctx, req.GetGreeting())
+			s.record(ctx, "GreetEveryone", req.GetGreeting(), greeting)
+			return &greetpb.GreetEveryoneResponse{Result: greeting + "! "}, true, nil
+		},
+		stream.Send,
+	)
+}
+
+func (s *Server) GreetWithDeadLine(ctx context.Context, req *greetpb.GreetWithDeadLineRequest) (*greetpb.GreetWithDeadLineResponse, error) {
+	logging.Infof("GreetWithDeadLine function was invoked with %v", req)
+
+	for i := 0; i < 3; i++ {
+		if ctx.Err() == context.Canceled {
+			logging.Infof("The client cancel the request!")
+			return nil, rpcerror.New(codes.DeadlineExceeded, "CLIENT_CANCELLED", "The client cancel the request")
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	result := s.greeter.greet(ctx, req.GetGreeting())
+	s.record(ctx, "GreetWithDeadLine", req.GetGreeting(), result)
+	res := &greetpb.GreetWithDeadLineResponse{
+		Result: result,
+	}
+	return res, nil
+}
@@ -0,0 +1,142 @@
+package history
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var greetingsBucket = []byte("greetings")
+
+// BoltStore keeps the history in a BoltDB file
+type BoltStore struct {
+	db        *bolt.DB
+	retention Retention
+}
+
+// OpenBoltStore opens, or creates, the BoltDB file at path
+func OpenBoltStore(path string, retention Retention) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(greetingsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltStore{db: db, retention: retention}, nil
+}
+
+// Add implements Store
+func (s *BoltStore) Add(ctx context.Context, r *Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(greetingsBucket)
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		r.ID = int64(id)
+		v, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(boltKey(r.ID), v); err != nil {
+			return err
+		}
+		return s.prune(b, r.ID)
+	})
+}
+
+// prune drops the records beyond retention, keys are sequential so the
+// oldest records are at the start of the bucket
+func (s *BoltStore) prune(b *bolt.Bucket, lastID int64) error {
+	oldest := time.Time{}
+	if s.retention.MaxAge > 0 {
+		oldest = time.Now().Add(-s.retention.MaxAge)
+	}
+
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.First() {
+		id := int64(binary.BigEndian.Uint64(k))
+		if s.retention.MaxRecords <= 0 || id > lastID-int64(s.retention.MaxRecords) {
+			var r Record
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			if oldest.IsZero() || !r.At.Before(oldest) {
+				return nil
+			}
+		}
+		if err := c.Delete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List implements Store
+func (s *BoltStore) List(ctx context.Context, f Filter, limit int) ([]Record, error) {
+	var records []Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(greetingsBucket).Cursor()
+
+		k, v := c.Last()
+		if f.Before > 0 {
+			// Seek lands on the first key >= Before, the page starts right before it
+			if k, _ = c.Seek(boltKey(f.Before)); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		}
+
+		for ; k != nil && len(records) < limit; k, v = c.Prev() {
+			var r Record
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			if f.match(r) {
+				records = append(records, r)
+			}
+		}
+		return nil
+	})
+	return records, err
+}
+
+// Stats implements Store
+func (s *BoltStore) Stats(ctx context.Context, f Filter) (Stats, error) {
+	stats := Stats{ByRPC: map[string]int64{}}
+	names := map[string]bool{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(greetingsBucket).ForEach(func(k, v []byte) error {
+			var r Record
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			if f.match(r) {
+				stats.add(r, names)
+			}
+			return nil
+		})
+	})
+	return stats, err
+}
+
+// Close implements Store
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func boltKey(id int64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(id))
+	return k
+}
@@ -0,0 +1,106 @@
+// Package history records the greetings produced by the greet server in a
+// pluggable store: in memory, BoltDB or SQLite.
+package history
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Record is a greeting produced by the server
+type Record struct {
+	ID        int64
+	FirstName string
+	LastName  string
+	// RPC is the name of the method that produced the greeting
+	RPC    string
+	Result string
+	// Caller is the address of the client
+	Caller string
+	At     time.Time
+}
+
+// Filter selects records. Zero fields match every record.
+type Filter struct {
+	// Name matches the first or the last name, case insensitive
+	Name string
+	From time.Time
+	To   time.Time
+	// Before only matches records with a lower ID, it is the page cursor
+	Before int64
+}
+
+func (f Filter) match(r Record) bool {
+	if f.Name != "" && !strings.EqualFold(r.FirstName, f.Name) && !strings.EqualFold(r.LastName, f.Name) {
+		return false
+	}
+	if !f.From.IsZero() && r.At.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && r.At.After(f.To) {
+		return false
+	}
+	return f.Before == 0 || r.ID < f.Before
+}
+
+// Stats summarizes the records matching a filter
+type Stats struct {
+	Total       int64
+	ByRPC       map[string]int64
+	UniqueNames int64
+	First       time.Time
+	Last        time.Time
+}
+
+func (s *Stats) add(r Record, names map[string]bool) {
+	s.Total++
+	s.ByRPC[r.RPC]++
+	if key := strings.ToLower(r.FirstName + "\x00" + r.LastName); !names[key] {
+		names[key] = true
+		s.UniqueNames++
+	}
+	if s.First.IsZero() || r.At.Before(s.First) {
+		s.First = r.At
+	}
+	if r.At.After(s.Last) {
+		s.Last = r.At
+	}
+}
+
+// Retention bounds what a store keeps, zero fields mean no bound
+type Retention struct {
+	MaxRecords int           `yaml:"max_records"`
+	MaxAge     time.Duration `yaml:"max_age"`
+}
+
+// Store keeps the history of greetings
+type Store interface {
+	// Add stores r, assigning its ID, and drops the records beyond retention
+	Add(ctx context.Context, r *Record) error
+	// List returns at most limit records matching f, newest first
+	List(ctx context.Context, f Filter, limit int) ([]Record, error)
+	// Stats summarizes the records matching f
+	Stats(ctx context.Context, f Filter) (Stats, error)
+	Close() error
+}
+
+// Open opens the store described by dsn, which is "memory", "bolt:<path>"
+// or "sqlite:<path>"
+func Open(dsn string, retention Retention) (Store, error) {
+	kind, path := dsn, ""
+	if i := strings.Index(dsn, ":"); i >= 0 {
+		kind, path = dsn[:i], dsn[i+1:]
+	}
+
+	switch kind {
+	case "memory":
+		return NewMemoryStore(retention), nil
+	case "bolt":
+		return OpenBoltStore(path, retention)
+	case "sqlite":
+		return OpenSQLiteStore(path, retention)
+	}
+	return nil, fmt.Errorf("unknown history store %q, want memory, bolt:<path> or sqlite:<path>", kind)
+}
@@ -0,0 +1,150 @@
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// stores opens each implementation of Store
+var stores = []struct {
+	name string
+	open func(t *testing.T, retention Retention) (Store, error)
+}{
+	{"memory", func(t *testing.T, retention Retention) (Store, error) {
+		return NewMemoryStore(retention), nil
+	}},
+	{"bolt", func(t *testing.T, retention Retention) (Store, error) {
+		return OpenBoltStore(filepath.Join(t.TempDir(), "history.db"), retention)
+	}},
+	{"sqlite", func(t *testing.T, retention Retention) (Store, error) {
+		return OpenSQLiteStore(filepath.Join(t.TempDir(), "history.sqlite"), retention)
+	}},
+}
+
+// eachStore runs test against an empty store of each implementation
+func eachStore(t *testing.T, retention Retention, test func(t *testing.T, s Store)) {
+	for _, store := range stores {
+		t.Run(store.name, func(t *testing.T) {
+			s, err := store.open(t, retention)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { s.Close() })
+			test(t, s)
+		})
+	}
+}
+
+// add stores a greeting of each name, made at the matching time of ats or
+// now
+func add(t *testing.T, s Store, names []string, ats ...time.Time) {
+	t.Helper()
+	for i, name := range names {
+		r := &Record{FirstName: name, LastName: "Lovelace", RPC: "Greet", Result: "Hello " + name, Caller: "127.0.0.1", At: time.Now()}
+		if i < len(ats) {
+			r.At = ats[i]
+		}
+		if err := s.Add(context.Background(), r); err != nil {
+			t.Fatal(err)
+		}
+		if r.ID == 0 {
+			t.Fatalf("record %d was not assigned an ID", i)
+		}
+	}
+}
+
+// ids returns the IDs of every record matching f, newest first
+func ids(t *testing.T, s Store, f Filter) []int64 {
+	t.Helper()
+	records, err := s.List(context.Background(), f, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []int64{}
+	for _, r := range records {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+func TestListPages(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		limit  int
+		pages  [][]int64
+	}{
+		{"every record", Filter{}, 3, [][]int64{{7, 6, 5}, {4, 3, 2}, {1}}},
+		{"exact pages", Filter{}, 7, [][]int64{{7, 6, 5, 4, 3, 2, 1}}},
+		{"by name", Filter{Name: "ada"}, 2, [][]int64{{7, 5}, {3, 1}}},
+		{"from a cursor", Filter{Before: 5}, 3, [][]int64{{4, 3, 2}, {1}}},
+		{"cursor past the end", Filter{Before: 100}, 4, [][]int64{{7, 6, 5, 4}, {3, 2, 1}}},
+	}
+	eachStore(t, Retention{}, func(t *testing.T, s Store) {
+		add(t, s, []string{"Ada", "Alan", "Ada", "Grace", "Ada", "Alan", "Ada"})
+		for _, tt := range tests {
+			f := tt.filter
+			var pages [][]int64
+			for {
+				records, err := s.List(context.Background(), f, tt.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(records) == 0 {
+					break
+				}
+				var page []int64
+				for _, r := range records {
+					page = append(page, r.ID)
+				}
+				pages = append(pages, page)
+				// the ID of the last record is the cursor of the next page
+				f.Before = records[len(records)-1].ID
+			}
+			if !reflect.DeepEqual(pages, tt.pages) {
+				t.Errorf("%s: got pages %v, want %v", tt.name, pages, tt.pages)
+			}
+		}
+	})
+}
+
+func TestRetention(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		retention Retention
+		ats       []time.Time
+		kept      []int64
+	}{
+		{"no bound", Retention{}, nil, []int64{5, 4, 3, 2, 1}},
+		{"max records", Retention{MaxRecords: 3}, nil, []int64{5, 4, 3}},
+		{"max age", Retention{MaxAge: time.Hour}, []time.Time{now.Add(-3 * time.Hour), now.Add(-2 * time.Hour), now.Add(-time.Minute)}, []int64{5, 4, 3}},
+		{"both", Retention{MaxRecords: 2, MaxAge: time.Hour}, []time.Time{now.Add(-3 * time.Hour)}, []int64{5, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eachStore(t, tt.retention, func(t *testing.T, s Store) {
+				add(t, s, []string{"Ada", "Alan", "Grace", "Ada", "Alan"}, tt.ats...)
+				if got := ids(t, s, Filter{}); !reflect.DeepEqual(got, tt.kept) {
+					t.Errorf("kept %v, want %v", got, tt.kept)
+				}
+				stats, err := s.Stats(context.Background(), Filter{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stats.Total != int64(len(tt.kept)) {
+					t.Errorf("stats count %d records, want %d", stats.Total, len(tt.kept))
+				}
+
+				// the IDs keep growing past the records pruned
+				add(t, s, []string{"Barbara"})
+				if got := ids(t, s, Filter{}); got[0] != 6 {
+					t.Errorf("the next record got ID %d, want 6", got[0])
+				}
+			})
+		})
+	}
+}
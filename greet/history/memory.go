@@ -0,0 +1,80 @@
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the history in memory, it is lost on restart
+type MemoryStore struct {
+	mu        sync.RWMutex
+	records   []Record
+	lastID    int64
+	retention Retention
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore(retention Retention) *MemoryStore {
+	return &MemoryStore{retention: retention}
+}
+
+// Add implements Store
+func (s *MemoryStore) Add(ctx context.Context, r *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	r.ID = s.lastID
+	s.records = append(s.records, *r)
+
+	// records are in insertion order, so the ones to drop are at the front
+	drop := 0
+	if s.retention.MaxRecords > 0 && len(s.records) > s.retention.MaxRecords {
+		drop = len(s.records) - s.retention.MaxRecords
+	}
+	if s.retention.MaxAge > 0 {
+		oldest := time.Now().Add(-s.retention.MaxAge)
+		for drop < len(s.records) && s.records[drop].At.Before(oldest) {
+			drop++
+		}
+	}
+	if drop > 0 {
+		s.records = append([]Record(nil), s.records[drop:]...)
+	}
+	return nil
+}
+
+// List implements Store
+func (s *MemoryStore) List(ctx context.Context, f Filter, limit int) ([]Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var records []Record
+	for i := len(s.records) - 1; i >= 0 && len(records) < limit; i-- {
+		if f.match(s.records[i]) {
+			records = append(records, s.records[i])
+		}
+	}
+	return records, nil
+}
+
+// Stats implements Store
+func (s *MemoryStore) Stats(ctx context.Context, f Filter) (Stats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := Stats{ByRPC: map[string]int64{}}
+	names := map[string]bool{}
+	for _, r := range s.records {
+		if f.match(r) {
+			stats.add(r, names)
+		}
+	}
+	return stats, nil
+}
+
+// Close implements Store
+func (s *MemoryStore) Close() error {
+	return nil
+}
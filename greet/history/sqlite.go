@@ -0,0 +1,155 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // registers the sqlite3 driver
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS greetings (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	first_name TEXT NOT NULL,
+	last_name TEXT NOT NULL,
+	rpc TEXT NOT NULL,
+	result TEXT NOT NULL,
+	caller TEXT NOT NULL,
+	at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS greetings_at ON greetings (at);
+`
+
+// SQLiteStore keeps the history in a SQLite database
+type SQLiteStore struct {
+	db        *sql.DB
+	retention Retention
+}
+
+// OpenSQLiteStore opens, or creates, the SQLite database at path
+func OpenSQLiteStore(path string, retention Retention) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db, retention: retention}, nil
+}
+
+// Add implements Store
+func (s *SQLiteStore) Add(ctx context.Context, r *Record) error {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO greetings (first_name, last_name, rpc, result, caller, at) VALUES (?, ?, ?, ?, ?, ?)`,
+		r.FirstName, r.LastName, r.RPC, r.Result, r.Caller, r.At.UnixNano())
+	if err != nil {
+		return err
+	}
+	if r.ID, err = res.LastInsertId(); err != nil {
+		return err
+	}
+
+	if s.retention.MaxRecords > 0 {
+		_, err = s.db.ExecContext(ctx, `DELETE FROM greetings WHERE id <= ?`, r.ID-int64(s.retention.MaxRecords))
+		if err != nil {
+			return err
+		}
+	}
+	if s.retention.MaxAge > 0 {
+		_, err = s.db.ExecContext(ctx, `DELETE FROM greetings WHERE at < ?`, time.Now().Add(-s.retention.MaxAge).UnixNano())
+	}
+	return err
+}
+
+// where returns the WHERE clause matching f with its arguments
+func where(f Filter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if f.Name != "" {
+		conds = append(conds, `(first_name = ? COLLATE NOCASE OR last_name = ? COLLATE NOCASE)`)
+		args = append(args, f.Name, f.Name)
+	}
+	if !f.From.IsZero() {
+		conds = append(conds, `at >= ?`)
+		args = append(args, f.From.UnixNano())
+	}
+	if !f.To.IsZero() {
+		conds = append(conds, `at <= ?`)
+		args = append(args, f.To.UnixNano())
+	}
+	if f.Before > 0 {
+		conds = append(conds, `id < ?`)
+		args = append(args, f.Before)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// List implements Store
+func (s *SQLiteStore) List(ctx context.Context, f Filter, limit int) ([]Record, error) {
+	clause, args := where(f)
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, first_name, last_name, rpc, result, caller, at FROM greetings`+clause+` ORDER BY id DESC LIMIT ?`,
+		append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var r Record
+		var at int64
+		if err := rows.Scan(&r.ID, &r.FirstName, &r.LastName, &r.RPC, &r.Result, &r.Caller, &at); err != nil {
+			return nil, err
+		}
+		r.At = time.Unix(0, at)
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// Stats implements Store
+func (s *SQLiteStore) Stats(ctx context.Context, f Filter) (Stats, error) {
+	stats := Stats{ByRPC: map[string]int64{}}
+	clause, args := where(f)
+
+	var first, last sql.NullInt64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT count(*), count(DISTINCT lower(first_name) || char(0) || lower(last_name)), min(at), max(at) FROM greetings`+clause,
+		args...).Scan(&stats.Total, &stats.UniqueNames, &first, &last)
+	if err != nil {
+		return stats, err
+	}
+	if first.Valid {
+		stats.First, stats.Last = time.Unix(0, first.Int64), time.Unix(0, last.Int64)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT rpc, count(*) FROM greetings`+clause+` GROUP BY rpc`, args...)
+	if err != nil {
+		return stats, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rpc string
+		var n int64
+		if err := rows.Scan(&rpc, &n); err != nil {
+			return stats, err
+		}
+		stats.ByRPC[rpc] = n
+	}
+	return stats, rows.Err()
+}
+
+// Close implements Store
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
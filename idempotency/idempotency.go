@@ -0,0 +1,307 @@
+// Package idempotency makes the retries of unary calls safe. A client sends
+// the same idempotency-key with every attempt of a call, the server runs the
+// first one and answers the others with its outcome for a while, without
+// running the handler again.
+package idempotency
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+const (
+	// Header is the metadata key of the idempotency key of a call
+	Header = "idempotency-key"
+	// ReplayedHeader is sent back as "true" by the calls answered with the
+	// outcome of an earlier one
+	ReplayedHeader = "idempotency-replayed"
+	// Reason is the ErrorInfo reason of the errors returned for bad keys
+	Reason = "INVALID_IDEMPOTENCY_KEY"
+	// MaxKeyLength is the longest key accepted
+	MaxKeyLength = 255
+)
+
+// Config is how long and how many outcomes the server remembers
+type Config struct {
+	// Window is how long the outcome of a call answers its duplicates, 0
+	// ignores the keys
+	Window time.Duration `yaml:"window"`
+	// MaxKeys is the number of outcomes remembered, the oldest ones are
+	// forgotten past it
+	MaxKeys int `yaml:"max_keys"`
+}
+
+// DefaultConfig remembers outcomes for an hour
+var DefaultConfig = Config{
+	Window:  time.Hour,
+	MaxKeys: 100000,
+}
+
+// RegisterFlags binds the fields of c to command line flags
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&c.Window, "idempotency-window", c.Window, "how long the outcome of a unary call with an idempotency-key answers its retries, 0 to ignore the keys")
+	fs.IntVar(&c.MaxKeys, "idempotency-max-keys", c.MaxKeys, "outcomes of idempotent calls remembered")
+}
+
+// Validate checks the fields of c
+func (c Config) Validate() error {
+	switch {
+	case c.Window < 0:
+		return fmt.Errorf("negative idempotency window %v", c.Window)
+	case c.Window > 0 && c.MaxKeys < 1:
+		return fmt.Errorf("idempotency max_keys %d is not positive", c.MaxKeys)
+	}
+	return nil
+}
+
+// NewKey returns a random key
+func NewKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand does not fail on the supported platforms
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithKey returns a context sending key as the idempotency key of the calls
+// made with it
+func WithKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, Header, key)
+}
+
+// HasKey reports whether the calls made with ctx send an idempotency key
+func HasKey(ctx context.Context) bool {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return len(md.Get(Header)) > 0
+}
+
+// call is a call run for a key, in progress until done is closed
+type call struct {
+	fingerprint [32]byte
+	done        chan struct{}
+	res         interface{}
+	err         error
+	expires     time.Time
+}
+
+// remembered is a completed call, in the order they expire
+type remembered struct {
+	id   string
+	call *call
+}
+
+// Store remembers the outcomes of the unary calls with an idempotency key
+type Store struct {
+	cfg Config
+
+	mu    sync.Mutex
+	calls map[string]*call
+	order *list.List
+	now   func() time.Time
+}
+
+// New returns a store applying cfg
+func New(cfg Config) (*Store, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Store{cfg: cfg, calls: map[string]*call{}, order: list.New(), now: time.Now}, nil
+}
+
+// keyOf returns the idempotency key of the call of ctx, empty for none
+func keyOf(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(Header)
+	switch {
+	case len(values) == 0:
+		return "", nil
+	case len(values) > 1:
+		return "", invalid("is set more than once")
+	case values[0] == "":
+		return "", invalid("is empty")
+	case len(values[0]) > MaxKeyLength:
+		return "", invalid(fmt.Sprintf("is longer than %d characters", MaxKeyLength))
+	}
+	return values[0], nil
+}
+
+func invalid(description string) error {
+	return rpcerror.InvalidArgument(Reason, rpcerror.Violation(Header, description))
+}
+
+// fingerprint hashes req, so a key sent again with another request is told
+// apart from a retry
+func fingerprint(req interface{}) [32]byte {
+	m, ok := req.(protov1.Message)
+	if !ok {
+		return [32]byte{}
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(protov1.MessageV2(m))
+	if err != nil {
+		return [32]byte{}
+	}
+	return sha256.Sum256(b)
+}
+
+// transient reports whether a call failing with err is left for its retries
+// to run again rather than remembered
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// begin returns the call of id, and whether the caller runs it
+func (s *Store) begin(id string, fp [32]byte) (*call, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	if c, ok := s.calls[id]; ok {
+		return c, false
+	}
+	c := &call{fingerprint: fp, done: make(chan struct{})}
+	s.calls[id] = c
+	return c, true
+}
+
+// end records the outcome of the call of id, forgetting it at once when the
+// call failed transiently
+func (s *Store) end(id string, c *call, res interface{}, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if transient(err) {
+		delete(s.calls, id)
+	} else {
+		c.res, c.err = res, err
+		if msg, ok := res.(protov1.Message); ok {
+			c.res = protov1.Clone(msg)
+		}
+		c.expires = s.now().Add(s.cfg.Window)
+		s.order.PushBack(remembered{id: id, call: c})
+		s.expire()
+	}
+	close(c.done)
+}
+
+// expire forgets the outcomes out of their window, and the oldest ones past
+// MaxKeys
+func (s *Store) expire() {
+	now := s.now()
+	for el := s.order.Front(); el != nil; el = s.order.Front() {
+		r := el.Value.(remembered)
+		if now.Before(r.call.expires) && s.order.Len() <= s.cfg.MaxKeys {
+			return
+		}
+		s.order.Remove(el)
+		if s.calls[r.id] == r.call {
+			delete(s.calls, r.id)
+		}
+	}
+}
+
+// run runs the call of id and records its outcome, an Internal error when
+// the handler panics, so its duplicates waiting for it are answered
+func (s *Store) run(ctx context.Context, req interface{}, id string, c *call, handler grpc.UnaryHandler) (res interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			s.end(id, c, nil, status.Errorf(codes.Internal, "the call panicked: %v", p))
+			panic(p)
+		}
+		s.end(id, c, res, err)
+	}()
+	return handler(ctx, req)
+}
+
+// callerOf identifies the caller of ctx: its authorization, or else its
+// client certificate or its address without port, so its retries on new
+// connections match
+func callerOf(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if auth := md.Get("authorization"); len(auth) > 0 {
+		sum := sha256.Sum256([]byte(strings.Join(auth, "\x00")))
+		return "authorization:" + hex.EncodeToString(sum[:])
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+		sum := sha256.Sum256(tlsInfo.State.PeerCertificates[0].Raw)
+		return "certificate:" + hex.EncodeToString(sum[:])
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return "address:" + host
+	}
+	return "address:" + p.Addr.String()
+}
+
+// UnaryServerInterceptor runs the calls with an idempotency key once per key,
+// method and caller. Duplicates get the outcome of the first call, waiting
+// for it when it is still running, unless it failed with a code worth
+// retrying.
+func (s *Store) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if s.cfg.Window == 0 {
+			return handler(ctx, req)
+		}
+		key, err := keyOf(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			return handler(ctx, req)
+		}
+
+		// the keys of a caller do not answer the calls of another one
+		id := callerOf(ctx) + "\x00" + info.FullMethod + "\x00" + key
+		fp := fingerprint(req)
+		for {
+			c, run := s.begin(id, fp)
+			if run {
+				return s.run(ctx, req, id, c, handler)
+			}
+			if c.fingerprint != fp {
+				return nil, invalid("was sent before with a different request")
+			}
+
+			select {
+			case <-c.done:
+			case <-ctx.Done():
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+			if c.expires.IsZero() {
+				// the first call failed transiently and was forgotten,
+				// this one runs instead
+				continue
+			}
+
+			grpc.SetHeader(ctx, metadata.Pairs(ReplayedHeader, "true"))
+			if msg, ok := c.res.(protov1.Message); ok {
+				return protov1.Clone(msg), c.err
+			}
+			return c.res, c.err
+		}
+	}
+}
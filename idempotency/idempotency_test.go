@@ -0,0 +1,330 @@
+package idempotency
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+const sumMethod = "/calculator.CalculatorService/Sum"
+
+// testStore is a store whose clock the tests move
+type testStore struct {
+	*Store
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newStore(t *testing.T, cfg Config) *testStore {
+	t.Helper()
+	s, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := &testStore{Store: s, now: time.Unix(0, 0)}
+	s.now = func() time.Time {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		return ts.now
+	}
+	return ts
+}
+
+func (ts *testStore) advance(d time.Duration) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.now = ts.now.Add(d)
+}
+
+// handler counts its calls and answers the sum of the request, or err
+type handler struct {
+	calls atomic.Int64
+	err   error
+	// release, when set, is waited for before answering
+	release chan struct{}
+	entered chan struct{}
+}
+
+func (h *handler) handle(ctx context.Context, req interface{}) (interface{}, error) {
+	n := h.calls.Add(1)
+	if h.entered != nil {
+		h.entered <- struct{}{}
+	}
+	if h.release != nil {
+		<-h.release
+	}
+	if h.err != nil {
+		return nil, h.err
+	}
+	r := req.(*calculatorpb.SumRequest)
+	// the number of the call tells the runs apart
+	return &calculatorpb.SumResponse{SumResult: r.GetFirstNumber() + r.GetSecondNumber() + int32(n)*1000}, nil
+}
+
+// caller is the context of the calls of a client
+func caller(addr string, kv ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 50000}})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(kv...))
+}
+
+func sum(ts *testStore, ctx context.Context, h *handler, method string, a, b int32) (int32, error) {
+	res, err := ts.UnaryServerInterceptor()(ctx, &calculatorpb.SumRequest{FirstNumber: a, SecondNumber: b}, &grpc.UnaryServerInfo{FullMethod: method}, h.handle)
+	if err != nil {
+		return 0, err
+	}
+	return res.(*calculatorpb.SumResponse).GetSumResult(), nil
+}
+
+func TestDuplicateGetsTheFirstOutcome(t *testing.T) {
+	ts := newStore(t, DefaultConfig)
+	var h handler
+	ctx := caller("10.0.0.1", Header, "key-1")
+
+	first, err := sum(ts, ctx, &h, sumMethod, 3, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		got, err := sum(ts, ctx, &h, sumMethod, 3, 10)
+		if err != nil || got != first {
+			t.Errorf("duplicate %d got %v, %v, want %v", i, got, err, first)
+		}
+	}
+	if n := h.calls.Load(); n != 1 {
+		t.Errorf("the handler ran %d times, want once", n)
+	}
+
+	// calls without key always run
+	if _, err := sum(ts, caller("10.0.0.1"), &h, sumMethod, 3, 10); err != nil {
+		t.Fatal(err)
+	}
+	if n := h.calls.Load(); n != 2 {
+		t.Errorf("the handler ran %d times, want the call without key too", n)
+	}
+}
+
+func TestConcurrentDuplicatesWait(t *testing.T) {
+	ts := newStore(t, DefaultConfig)
+	h := handler{release: make(chan struct{}), entered: make(chan struct{}, 10)}
+	ctx := caller("10.0.0.1", Header, "key-1")
+
+	results := make(chan int32, 5)
+	for i := 0; i < 5; i++ {
+		go func() {
+			got, err := sum(ts, ctx, &h, sumMethod, 3, 10)
+			if err != nil {
+				t.Error(err)
+			}
+			results <- got
+		}()
+	}
+	<-h.entered
+	select {
+	case got := <-results:
+		t.Fatalf("a duplicate got %v before the first call ended", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(h.release)
+
+	first := <-results
+	for i := 1; i < 5; i++ {
+		if got := <-results; got != first {
+			t.Errorf("got %v, want %v", got, first)
+		}
+	}
+	if n := h.calls.Load(); n != 1 {
+		t.Errorf("the handler ran %d times, want once", n)
+	}
+}
+
+func TestKeyReusedWithAnotherRequest(t *testing.T) {
+	ts := newStore(t, DefaultConfig)
+	var h handler
+	ctx := caller("10.0.0.1", Header, "key-1")
+
+	if _, err := sum(ts, ctx, &h, sumMethod, 3, 10); err != nil {
+		t.Fatal(err)
+	}
+	_, err := sum(ts, ctx, &h, sumMethod, 4, 10)
+	if status.Code(err) != codes.InvalidArgument || rpcerror.Reason(err) != Reason {
+		t.Errorf("got %v, want InvalidArgument %v", err, Reason)
+	}
+}
+
+func TestBadKeys(t *testing.T) {
+	ts := newStore(t, DefaultConfig)
+	var h handler
+	tests := []struct {
+		name string
+		md   []string
+	}{
+		{"empty", []string{Header, ""}},
+		{"twice", []string{Header, "a", Header, "b"}},
+		{"too long", []string{Header, string(make([]byte, MaxKeyLength+1))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sum(ts, caller("10.0.0.1", tt.md...), &h, sumMethod, 3, 10)
+			if status.Code(err) != codes.InvalidArgument || rpcerror.Reason(err) != Reason {
+				t.Errorf("got %v, want InvalidArgument %v", err, Reason)
+			}
+		})
+	}
+	if n := h.calls.Load(); n != 0 {
+		t.Errorf("the handler ran %d times", n)
+	}
+}
+
+func TestOutcomesRemembered(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		// runs is the times the handler runs for a call and its duplicate
+		runs int64
+	}{
+		{codes.InvalidArgument, 1},
+		{codes.NotFound, 1},
+		{codes.Internal, 1},
+		{codes.Canceled, 2},
+		{codes.DeadlineExceeded, 2},
+		{codes.Unavailable, 2},
+		{codes.ResourceExhausted, 2},
+		{codes.Aborted, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			ts := newStore(t, DefaultConfig)
+			h := handler{err: status.Error(tt.code, "failed")}
+			ctx := caller("10.0.0.1", Header, "key-1")
+			for i := 0; i < 2; i++ {
+				if _, err := sum(ts, ctx, &h, sumMethod, 3, 10); status.Code(err) != tt.code {
+					t.Errorf("call %d got %v, want %v", i, err, tt.code)
+				}
+			}
+			if n := h.calls.Load(); n != tt.runs {
+				t.Errorf("the handler ran %d times, want %d", n, tt.runs)
+			}
+		})
+	}
+}
+
+func TestPanicIsRemembered(t *testing.T) {
+	ts := newStore(t, DefaultConfig)
+	ctx := caller("10.0.0.1", Header, "key-1")
+	panicking := func(ctx context.Context, req interface{}) (interface{}, error) { panic("boom") }
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("the panic was swallowed")
+			}
+		}()
+		ts.UnaryServerInterceptor()(ctx, &calculatorpb.SumRequest{}, &grpc.UnaryServerInfo{FullMethod: sumMethod}, panicking)
+	}()
+
+	var h handler
+	if _, err := sum(ts, ctx, &h, sumMethod, 0, 0); status.Code(err) != codes.Internal {
+		t.Errorf("got %v, want the Internal error of the panic", err)
+	}
+	if n := h.calls.Load(); n != 0 {
+		t.Errorf("the handler ran %d times", n)
+	}
+}
+
+func TestKeysScopedByCallerAndMethod(t *testing.T) {
+	ts := newStore(t, DefaultConfig)
+	var h handler
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		// replayed tells whether the call is answered by an earlier one
+		replayed bool
+	}{
+		{"first", caller("10.0.0.1", Header, "key-1"), sumMethod, false},
+		{"same address", caller("10.0.0.1", Header, "key-1"), sumMethod, true},
+		{"other address", caller("10.0.0.2", Header, "key-1"), sumMethod, false},
+		{"other method", caller("10.0.0.1", Header, "key-1"), "/calculator.CalculatorService/Other", false},
+		{"token", caller("10.0.0.1", Header, "key-1", "authorization", "Bearer a"), sumMethod, false},
+		{"same token elsewhere", caller("10.0.0.3", Header, "key-1", "authorization", "Bearer a"), sumMethod, true},
+		{"other token", caller("10.0.0.1", Header, "key-1", "authorization", "Bearer b"), sumMethod, false},
+	}
+	for _, tt := range tests {
+		before := h.calls.Load()
+		if _, err := sum(ts, tt.ctx, &h, tt.method, 3, 10); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if replayed := h.calls.Load() == before; replayed != tt.replayed {
+			t.Errorf("%s: replayed %v, want %v", tt.name, replayed, tt.replayed)
+		}
+	}
+}
+
+func TestOutcomesExpire(t *testing.T) {
+	ts := newStore(t, Config{Window: time.Minute, MaxKeys: 2})
+	var h handler
+	call := func(key string) {
+		t.Helper()
+		if _, err := sum(ts, caller("10.0.0.1", Header, key), &h, sumMethod, 3, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runs := func(want int64) {
+		t.Helper()
+		if n := h.calls.Load(); n != want {
+			t.Errorf("the handler ran %d times, want %d", n, want)
+		}
+	}
+
+	call("a")
+	ts.advance(59 * time.Second)
+	call("a")
+	runs(1)
+	ts.advance(time.Second)
+	call("a")
+	runs(2)
+
+	// past MaxKeys the oldest outcome is forgotten
+	call("b")
+	call("c")
+	runs(4)
+	call("c")
+	call("b")
+	runs(4)
+	call("a")
+	runs(5)
+}
+
+func TestRunningCallsDoNotEvictOutcomes(t *testing.T) {
+	ts := newStore(t, Config{Window: time.Minute, MaxKeys: 1})
+	slow := handler{release: make(chan struct{}), entered: make(chan struct{}, 1)}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sum(ts, caller("10.0.0.1", Header, "slow"), &slow, sumMethod, 3, 10)
+	}()
+	<-slow.entered
+
+	// the call running counts against MaxKeys only once it is remembered
+	var h handler
+	for i := 0; i < 2; i++ {
+		if _, err := sum(ts, caller("10.0.0.1", Header, "fast"), &h, sumMethod, 3, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := h.calls.Load(); n != 1 {
+		t.Errorf("the handler ran %d times, want once", n)
+	}
+	close(slow.release)
+	<-done
+}
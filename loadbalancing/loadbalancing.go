@@ -0,0 +1,99 @@
+// Package loadbalancing spreads the calls of a client over several server
+// instances: it resolves lists of addresses and targets files, balances the
+// calls with round-robin or least-request and skips the instances whose
+// health service does not report SERVING.
+package loadbalancing
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/leastrequest"
+	"google.golang.org/grpc/balancer/roundrobin"
+	_ "google.golang.org/grpc/health" // registers the client health checking
+)
+
+// The balancing policies
+const (
+	PickFirst    = "pick_first"
+	RoundRobin   = roundrobin.Name
+	LeastRequest = "least_request"
+)
+
+// Config is the balancing of a client
+type Config struct {
+	// Policy is pick_first, round_robin or least_request
+	Policy string `yaml:"policy"`
+	// HealthCheck stops calling the instances whose HealthService is not
+	// SERVING, pick_first ignores it
+	HealthCheck   bool   `yaml:"health_check"`
+	HealthService string `yaml:"health_service"`
+	// RefreshInterval is how often targets files are checked for changes
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+}
+
+// DefaultConfig balances round-robin over the healthy instances
+var DefaultConfig = Config{
+	Policy:          RoundRobin,
+	HealthCheck:     true,
+	RefreshInterval: 2 * time.Second,
+}
+
+// RegisterFlags binds the fields of c to command line flags
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Policy, "lb", c.Policy, "balancing of the calls over the servers: pick_first, round_robin or least_request")
+	fs.BoolVar(&c.HealthCheck, "health-check", c.HealthCheck, "skip the servers not reporting SERVING on their health service")
+	fs.StringVar(&c.HealthService, "health-service", c.HealthService, "service name checked with -health-check, empty for the whole server")
+	fs.DurationVar(&c.RefreshInterval, "targets-refresh", c.RefreshInterval, "how often a file:// targets file is checked for changes")
+}
+
+// Target returns the dial target of addrs: a comma separated list of
+// host:port, or any target understood by grpc such as dns:///name, or
+// file:///path of a targets file
+func Target(addrs string) string {
+	if strings.Contains(addrs, ",") && !strings.Contains(addrs, "://") {
+		return listScheme + ":///" + addrs
+	}
+	return addrs
+}
+
+// DialOptions returns the grpc options applying c, with the resolvers of
+// the targets returned by Target
+func (c Config) DialOptions() ([]grpc.DialOption, error) {
+	sc, err := c.serviceConfig()
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.DialOption{
+		grpc.WithResolvers(listBuilder{}, &fileBuilder{interval: c.RefreshInterval}),
+		grpc.WithDefaultServiceConfig(sc),
+	}, nil
+}
+
+// serviceConfig returns the JSON service config applying c
+func (c Config) serviceConfig() (string, error) {
+	var policy map[string]interface{}
+	switch c.Policy {
+	case "", PickFirst:
+		policy = map[string]interface{}{PickFirst: struct{}{}}
+	case RoundRobin:
+		policy = map[string]interface{}{RoundRobin: struct{}{}}
+	case LeastRequest:
+		policy = map[string]interface{}{leastrequest.Name: leastrequest.LBConfig{ChoiceCount: 2}}
+	default:
+		return "", fmt.Errorf("unknown balancing policy %q, want %s, %s or %s", c.Policy, PickFirst, RoundRobin, LeastRequest)
+	}
+
+	sc := map[string]interface{}{
+		"loadBalancingConfig": []interface{}{policy},
+	}
+	if c.HealthCheck {
+		sc["healthCheckConfig"] = map[string]string{"serviceName": c.HealthService}
+	}
+	b, err := json.Marshal(sc)
+	return string(b), err
+}
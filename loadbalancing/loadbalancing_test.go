@@ -0,0 +1,193 @@
+package loadbalancing
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/calculator/calculatorservice"
+)
+
+// testServer is a calculator server counting the calls it answers
+type testServer struct {
+	addr   string
+	health *health.Server
+	calls  atomic.Int64
+}
+
+// startServers starts n calculator servers with their health service
+func startServers(t *testing.T, n int) []*testServer {
+	t.Helper()
+	// the calculator service logs every call
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	var servers []*testServer
+	for i := 0; i < n; i++ {
+		ts := &testServer{health: health.NewServer()}
+		count := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			ts.calls.Add(1)
+			return handler(ctx, req)
+		}
+		svc, err := calculatorservice.New(calculatorservice.DefaultConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := grpc.NewServer(grpc.UnaryInterceptor(count))
+		calculatorpb.RegisterCalculatorServiceServer(s, svc)
+		healthpb.RegisterHealthServer(s, ts.health)
+		go s.Serve(lis)
+		t.Cleanup(s.Stop)
+
+		ts.addr = lis.Addr().String()
+		servers = append(servers, ts)
+	}
+	return servers
+}
+
+func addrsOf(servers []*testServer) []string {
+	var addrs []string
+	for _, s := range servers {
+		addrs = append(addrs, s.addr)
+	}
+	return addrs
+}
+
+func dial(t *testing.T, target string, cfg Config) calculatorpb.CalculatorServiceClient {
+	t.Helper()
+	opts, err := cfg.DialOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc, err := grpc.Dial(Target(target), append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return calculatorpb.NewCalculatorServiceClient(cc)
+}
+
+// call makes n calls and returns the calls each server answered
+func call(t *testing.T, c calculatorpb.CalculatorServiceClient, servers []*testServer, n int) []int64 {
+	t.Helper()
+	for _, s := range servers {
+		s.calls.Store(0)
+	}
+	for i := 0; i < n; i++ {
+		if _, err := c.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: 1, SecondNumber: 2}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	counts := make([]int64, len(servers))
+	for i, s := range servers {
+		counts[i] = s.calls.Load()
+	}
+	return counts
+}
+
+// waitFor calls until every server of want answers, the balancers only pick
+// the connections ready
+func waitFor(t *testing.T, c calculatorpb.CalculatorServiceClient, servers []*testServer, want ...*testServer) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		counts := call(t, c, servers, 10*len(servers))
+		ready := true
+		for i, s := range servers {
+			for _, w := range want {
+				ready = ready && (s != w || counts[i] > 0)
+			}
+		}
+		if ready {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("some of %v never answered", addrsOf(want))
+}
+
+func TestRoundRobinSpreadsCalls(t *testing.T) {
+	servers := startServers(t, 3)
+	cfg := DefaultConfig
+	cfg.Policy = RoundRobin
+	c := dial(t, strings.Join(addrsOf(servers), ","), cfg)
+	waitFor(t, c, servers, servers...)
+
+	for i, n := range call(t, c, servers, 30) {
+		if n != 10 {
+			t.Errorf("server %d answered %d of 30 calls, want 10", i, n)
+		}
+	}
+}
+
+func TestLeastRequestSpreadsCalls(t *testing.T) {
+	servers := startServers(t, 3)
+	cfg := DefaultConfig
+	cfg.Policy = LeastRequest
+	c := dial(t, strings.Join(addrsOf(servers), ","), cfg)
+	waitFor(t, c, servers, servers...)
+
+	// sequential calls leave no request outstanding, so the picks are random
+	for i, n := range call(t, c, servers, 300) {
+		if n < 50 {
+			t.Errorf("server %d answered %d of 300 calls", i, n)
+		}
+	}
+}
+
+func TestHealthCheckSkipsServersNotServing(t *testing.T) {
+	servers := startServers(t, 3)
+	servers[1].health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	c := dial(t, strings.Join(addrsOf(servers), ","), DefaultConfig)
+	waitFor(t, c, servers, servers[0], servers[2])
+
+	if n := call(t, c, servers, 30)[1]; n != 0 {
+		t.Errorf("the server not serving answered %d of 30 calls", n)
+	}
+
+	servers[1].health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	waitFor(t, c, servers, servers[1])
+}
+
+func TestFileResolverFollowsEdits(t *testing.T) {
+	servers := startServers(t, 2)
+	path := filepath.Join(t.TempDir(), "targets")
+	write := func(addrs ...string) {
+		if err := os.WriteFile(path, []byte("# calculator servers\n"+strings.Join(addrs, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(servers[0].addr)
+
+	cfg := DefaultConfig
+	cfg.RefreshInterval = 10 * time.Millisecond
+	c := dial(t, "file://"+path, cfg)
+	waitFor(t, c, servers, servers[0])
+	if n := call(t, c, servers, 10)[1]; n != 0 {
+		t.Errorf("the server missing from the file answered %d of 10 calls", n)
+	}
+
+	write(servers[1].addr)
+	waitFor(t, c, servers, servers[1])
+	// the connection to the removed server is closed once the file is read
+	if n := call(t, c, servers, 10)[0]; n != 0 {
+		t.Errorf("the server removed from the file answered %d of 10 calls", n)
+	}
+}
@@ -0,0 +1,168 @@
+package loadbalancing
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	listScheme = "list"
+	fileScheme = "file"
+)
+
+// listBuilder resolves list:///host:port,host:port to its addresses
+type listBuilder struct{}
+
+func (listBuilder) Scheme() string {
+	return listScheme
+}
+
+func (listBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	addrs := splitAddrs(strings.Split(target.Endpoint(), ","))
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no address in %q", target.URL.String())
+	}
+	if err := cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+		return nil, err
+	}
+	return nopResolver{}, nil
+}
+
+// OverrideAuthority is the first address, so TLS checks the name of the
+// servers, all of them sharing it
+func (listBuilder) OverrideAuthority(target resolver.Target) string {
+	first, _, _ := strings.Cut(target.Endpoint(), ",")
+	return strings.TrimSpace(first)
+}
+
+type nopResolver struct{}
+
+func (nopResolver) ResolveNow(resolver.ResolveNowOptions) {}
+func (nopResolver) Close()                                {}
+
+// fileBuilder resolves file:///path to the addresses listed in the file, one
+// per line, and follows its changes
+type fileBuilder struct {
+	interval time.Duration
+}
+
+func (b *fileBuilder) Scheme() string {
+	return fileScheme
+}
+
+func (b *fileBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	path := target.URL.Path
+	if path == "" {
+		// file:relative/path
+		path = target.URL.Opaque
+	}
+	r := &fileResolver{
+		path:  path,
+		cc:    cc,
+		now:   make(chan struct{}, 1),
+		done:  make(chan struct{}),
+		close: make(chan struct{}),
+	}
+	if err := r.resolve(); err != nil {
+		return nil, err
+	}
+	interval := b.interval
+	if interval <= 0 {
+		interval = DefaultConfig.RefreshInterval
+	}
+	go r.watch(interval)
+	return r, nil
+}
+
+// OverrideAuthority is the host of file://host/path, localhost when empty
+func (b *fileBuilder) OverrideAuthority(target resolver.Target) string {
+	if target.URL.Host != "" {
+		return target.URL.Host
+	}
+	return "localhost"
+}
+
+type fileResolver struct {
+	path string
+	cc   resolver.ClientConn
+	// last is the content of the file last resolved
+	last []byte
+
+	now       chan struct{}
+	done      chan struct{}
+	close     chan struct{}
+	closeOnce sync.Once
+}
+
+// resolve reads the file and updates the addresses when it changed
+func (r *fileResolver) resolve() error {
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	if r.last != nil && bytes.Equal(b, r.last) {
+		return nil
+	}
+	r.last = b
+
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		lines = append(lines, line)
+	}
+	addrs := splitAddrs(lines)
+	if len(addrs) == 0 {
+		return fmt.Errorf("no address in %s", r.path)
+	}
+	return r.cc.UpdateState(resolver.State{Addresses: addrs})
+}
+
+// watch resolves the file again every interval, or when asked by grpc
+func (r *fileResolver) watch(interval time.Duration) {
+	defer close(r.done)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-r.close:
+			return
+		case <-t.C:
+		case <-r.now:
+		}
+		if err := r.resolve(); err != nil {
+			// the addresses resolved last are kept
+			r.cc.ReportError(err)
+		}
+	}
+}
+
+func (r *fileResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.now <- struct{}{}:
+	default:
+	}
+}
+
+func (r *fileResolver) Close() {
+	r.closeOnce.Do(func() { close(r.close) })
+	<-r.done
+}
+
+// splitAddrs returns the resolver addresses of the non empty items
+func splitAddrs(items []string) []resolver.Address {
+	var addrs []resolver.Address
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			addrs = append(addrs, resolver.Address{Addr: item})
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+// GRPCLogger writes the logs of the grpc library at the current level, to
+// be installed with grpclog.SetLoggerV2. Its info messages are written at
+// the Debug level since grpc is chatty.
+type GRPCLogger struct{}
+
+func (GRPCLogger) Info(args ...interface{})                 { output(Debug, "%s", fmt.Sprint(args...)) }
+func (GRPCLogger) Infoln(args ...interface{})               { output(Debug, "%s", fmt.Sprintln(args...)) }
+func (GRPCLogger) Infof(format string, args ...interface{}) { output(Debug, format, args...) }
+
+func (GRPCLogger) Warning(args ...interface{})                 { output(Warning, "%s", fmt.Sprint(args...)) }
+func (GRPCLogger) Warningln(args ...interface{})               { output(Warning, "%s", fmt.Sprintln(args...)) }
+func (GRPCLogger) Warningf(format string, args ...interface{}) { output(Warning, format, args...) }
+
+func (GRPCLogger) Error(args ...interface{})                 { output(Error, "%s", fmt.Sprint(args...)) }
+func (GRPCLogger) Errorln(args ...interface{})               { output(Error, "%s", fmt.Sprintln(args...)) }
+func (GRPCLogger) Errorf(format string, args ...interface{}) { output(Error, format, args...) }
+
+// Fatal, Fatalln and Fatalf are always written, then exit
+func (GRPCLogger) Fatal(args ...interface{}) {
+	log.Output(2, fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+func (GRPCLogger) Fatalln(args ...interface{}) {
+	log.Output(2, fmt.Sprintln(args...))
+	os.Exit(1)
+}
+
+func (GRPCLogger) Fatalf(format string, args ...interface{}) {
+	log.Output(2, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+// V reports whether the grpc verbosity level l is written, any verbosity
+// needs the Debug level
+func (GRPCLogger) V(l int) bool {
+	return l <= 0 || Enabled(Debug)
+}
@@ -0,0 +1,94 @@
+// Package logging adds levels to the standard logger so the verbosity of a
+// running server can be changed, for instance from the admin service.
+package logging
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+)
+
+// Level is the minimum severity of the messages written
+type Level int32
+
+// The levels, from the most verbose
+const (
+	Debug Level = iota + 1
+	Info
+	Warning
+	Error
+)
+
+var names = map[Level]string{
+	Debug:   "debug",
+	Info:    "info",
+	Warning: "warning",
+	Error:   "error",
+}
+
+func (l Level) String() string {
+	if name, ok := names[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("Level(%d)", int32(l))
+}
+
+// ParseLevel parses the name of a level, case insensitive
+func ParseLevel(s string) (Level, error) {
+	for l, name := range names {
+		if strings.EqualFold(s, name) {
+			return l, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q, want debug, info, warning or error", s)
+}
+
+// UnmarshalText lets levels be read from configuration files
+func (l *Level) UnmarshalText(b []byte) error {
+	level, err := ParseLevel(string(b))
+	if err == nil {
+		*l = level
+	}
+	return err
+}
+
+// MarshalText writes the name of the level
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+var current int32 = int32(Info)
+
+// SetLevel changes the level and returns the previous one
+func SetLevel(l Level) Level {
+	return Level(atomic.SwapInt32(&current, int32(l)))
+}
+
+// GetLevel returns the current level
+func GetLevel() Level {
+	return Level(atomic.LoadInt32(&current))
+}
+
+// Enabled reports whether messages of level l are written
+func Enabled(l Level) bool {
+	return l >= GetLevel()
+}
+
+func output(l Level, format string, a ...interface{}) {
+	if Enabled(l) {
+		log.Output(3, strings.ToUpper(l.String())+": "+fmt.Sprintf(format, a...))
+	}
+}
+
+// Debugf logs at the Debug level
+func Debugf(format string, a ...interface{}) { output(Debug, format, a...) }
+
+// Infof logs at the Info level
+func Infof(format string, a ...interface{}) { output(Info, format, a...) }
+
+// Warningf logs at the Warning level
+func Warningf(format string, a ...interface{}) { output(Warning, format, a...) }
+
+// Errorf logs at the Error level
+func Errorf(format string, a ...interface{}) { output(Error, format, a...) }
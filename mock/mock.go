@@ -0,0 +1,345 @@
+// Package mock serves any method of our services from scripted responses,
+// so the code calling them can be tested without the real servers. Each
+// call of a method plays the next Step of its script, the last one being
+// repeated, and every call is recorded with its requests.
+//
+//	m, _ := mock.New(nil)
+//	m.On("greet.GreetService/Greet", mock.Reply(&greetpb.GreetResponse{Result: "Hi"}))
+//	m.On("calculator.CalculatorService/PrimeNumberDecomposition",
+//		mock.Reply(&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: 2}).Then(codes.Unavailable, "gone"))
+//	addr, stop, _ := m.Start()
+//	defer stop()
+//	c, _ := greetclient.Dial(addr)
+//
+// Scripts can also be loaded from a scenario file, see Load.
+package mock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionpbalpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	protov1 "github.com/golang/protobuf/proto"
+
+	"github.com/christiangda/grpc-go-course/descriptors"
+	"github.com/christiangda/grpc-go-course/transport"
+)
+
+// Call is a call received by the server
+type Call struct {
+	// Method is the full method name, as /greet.GreetService/Greet
+	Method   string
+	Metadata metadata.MD
+	// Requests are the messages received, in order
+	Requests []proto.Message
+}
+
+// Decode unmarshals the request i into a generated message
+func (c Call) Decode(i int, into protov1.Message) error {
+	if i >= len(c.Requests) {
+		return fmt.Errorf("%v received %d requests", c.Method, len(c.Requests))
+	}
+	b, err := proto.Marshal(c.Requests[i])
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(b, protov1.MessageV2(into))
+}
+
+// Server answers the calls of the methods described by its files from
+// their scripts
+type Server struct {
+	files *protoregistry.Files
+
+	mu      sync.Mutex
+	scripts map[string]*script
+	calls   []*Call
+}
+
+type script struct {
+	steps []Step
+	next  int
+}
+
+// memoryListeners numbers the listeners of Start
+var memoryListeners atomic.Int64
+
+// New returns a server for the methods described by files, the embedded
+// descriptors of our services when nil
+func New(files *protoregistry.Files) (*Server, error) {
+	if files == nil {
+		var err error
+		if files, err = descriptors.Files(); err != nil {
+			return nil, err
+		}
+	}
+	return &Server{files: files, scripts: map[string]*script{}}, nil
+}
+
+// fullMethod returns method as /package.Service/Method
+func fullMethod(method string) string {
+	return "/" + strings.TrimPrefix(method, "/")
+}
+
+// On replaces the script of method, given as package.Service/Method. The
+// calls of a method without script fail with Unimplemented.
+func (m *Server) On(method string, steps ...Step) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scripts[fullMethod(method)] = &script{steps: steps}
+}
+
+// Calls returns the calls received by method, every call when empty
+func (m *Server) Calls(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, c := range m.calls {
+		if method == "" || c.Method == fullMethod(method) {
+			call := *c
+			call.Requests = append([]proto.Message(nil), c.Requests...)
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the scripts and the calls
+func (m *Server) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scripts = map[string]*script{}
+	m.calls = nil
+}
+
+// ServerOptions returns the grpc options routing every call to m
+func (m *Server) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{grpc.UnknownServiceHandler(m.handle)}
+}
+
+// RegisterReflection serves reflection on s for the services of m, as the
+// calls of s are not routed to registered services
+func (m *Server) RegisterReflection(s *grpc.Server) {
+	opts := reflection.ServerOptions{Services: serviceInfo{m.files}, DescriptorResolver: m.files}
+	reflectionpb.RegisterServerReflectionServer(s, reflection.NewServerV1(opts))
+	reflectionpbalpha.RegisterServerReflectionServer(s, reflection.NewServer(opts))
+}
+
+// serviceInfo lists the services of files for reflection
+type serviceInfo struct {
+	files *protoregistry.Files
+}
+
+func (si serviceInfo) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := map[string]grpc.ServiceInfo{}
+	si.files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			sd := services.Get(i)
+			var methods []grpc.MethodInfo
+			for j := 0; j < sd.Methods().Len(); j++ {
+				md := sd.Methods().Get(j)
+				methods = append(methods, grpc.MethodInfo{
+					Name:           string(md.Name()),
+					IsClientStream: md.IsStreamingClient(),
+					IsServerStream: md.IsStreamingServer(),
+				})
+			}
+			info[string(sd.FullName())] = grpc.ServiceInfo{Methods: methods, Metadata: fd.Path()}
+		}
+		return true
+	})
+	return info
+}
+
+// NewServer returns a grpc server answering every call from m, serving the
+// health service like our servers
+func (m *Server) NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(append(m.ServerOptions(), opts...)...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	return s
+}
+
+// Start serves m on an in-process listener and returns its address, to dial
+// with the client packages or with transport.DialOptions
+func (m *Server) Start() (addr string, stop func(), err error) {
+	lis, err := transport.Listen(fmt.Sprintf("memory://mock-%d", memoryListeners.Add(1)), 0)
+	if err != nil {
+		return "", nil, err
+	}
+	s := m.NewServer()
+	go s.Serve(lis)
+	return transport.Addr(lis), s.Stop, nil
+}
+
+// next returns the step of the next call of method
+func (m *Server) next(method string) (Step, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sc, ok := m.scripts[method]
+	if !ok || len(sc.steps) == 0 {
+		return Step{}, false
+	}
+	step := sc.steps[sc.next]
+	if sc.next < len(sc.steps)-1 {
+		sc.next++
+	}
+	return step, true
+}
+
+// record adds a call of method
+func (m *Server) record(ctx context.Context, method string) *Call {
+	md, _ := metadata.FromIncomingContext(ctx)
+	call := &Call{Method: method, Metadata: md}
+	m.mu.Lock()
+	m.calls = append(m.calls, call)
+	m.mu.Unlock()
+	return call
+}
+
+func (m *Server) addRequest(call *Call, req proto.Message) {
+	m.mu.Lock()
+	call.Requests = append(call.Requests, req)
+	m.mu.Unlock()
+}
+
+// method returns the descriptor of the full method name
+func (m *Server) method(fullMethod string) (protoreflect.MethodDescriptor, error) {
+	name := strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1)
+	d, err := m.files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %v", fullMethod)
+	}
+	md, ok := d.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %v", fullMethod)
+	}
+	return md, nil
+}
+
+func (m *Server) handle(srv interface{}, stream grpc.ServerStream) error {
+	ctx := stream.Context()
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	md, err := m.method(fullMethod)
+	if err != nil {
+		return err
+	}
+	call := m.record(ctx, fullMethod)
+
+	// requests are received along the responses, received counts them and
+	// is closed once the client is done sending
+	received := make(chan struct{}, 1)
+	go func() {
+		defer close(received)
+		for {
+			req := dynamicpb.NewMessage(md.Input())
+			if err := stream.RecvMsg(req); err != nil {
+				return
+			}
+			m.addRequest(call, req)
+			// nobody waits for the requests once the call ended
+			select {
+			case received <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			if !md.IsStreamingClient() {
+				return
+			}
+		}
+	}()
+
+	bidi := md.IsStreamingClient() && md.IsStreamingServer()
+	if !bidi {
+		if err := waitRequests(ctx, received, true); err != nil {
+			return err
+		}
+	}
+
+	step, ok := m.next(fullMethod)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "no script for %v", fullMethod)
+	}
+	responses := step.Responses
+	if !md.IsStreamingServer() {
+		if len(responses) == 0 && step.Status == nil {
+			return status.Errorf(codes.Internal, "the script of %v has no response", fullMethod)
+		}
+		if len(responses) > 1 {
+			responses = responses[:1]
+		}
+	}
+
+	if err := sleep(ctx, step.Delay); err != nil {
+		return err
+	}
+	for i, res := range responses {
+		if i > 0 {
+			if err := sleep(ctx, step.Interval); err != nil {
+				return err
+			}
+		}
+		if bidi {
+			if err := waitRequests(ctx, received, false); err != nil {
+				return err
+			}
+		}
+		if err := stream.SendMsg(res); err != nil {
+			return err
+		}
+	}
+	if bidi && step.Status == nil {
+		// the requests sent after the last response are recorded too
+		if err := waitRequests(ctx, received, true); err != nil {
+			return err
+		}
+	}
+	if step.Status != nil {
+		return step.Status.Err()
+	}
+	return nil
+}
+
+// waitRequests waits for the next request, or for the client to stop
+// sending when all is set
+func waitRequests(ctx context.Context, received <-chan struct{}, all bool) error {
+	for {
+		select {
+		case _, ok := <-received:
+			if !ok || !all {
+				return nil
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
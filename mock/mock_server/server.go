@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/christiangda/grpc-go-course/mock"
+	"github.com/christiangda/grpc-go-course/transport"
+)
+
+func main() {
+	listen := flag.String("listen", "0.0.0.0:50051", "comma separated addresses served: host:port, unix:///path or unix:relative/path")
+	scenario := flag.String("scenario", "", "YAML scenario file scripting the responses, reloaded on SIGHUP")
+	protoset := flag.String("protoset", "", "FileDescriptorSet of the services to mock, by default the ones of this repository")
+	reflectionOn := flag.Bool("reflection", true, "serve the gRPC reflection service for the mocked services")
+	flag.Parse()
+
+	fmt.Println("Mock Server")
+
+	var files *protoregistry.Files
+	if *protoset != "" {
+		var err error
+		if files, err = loadProtoset(*protoset); err != nil {
+			log.Fatalf("Failed loading %v: %v", *protoset, err)
+		}
+	}
+	m, err := mock.New(files)
+	if err != nil {
+		log.Fatalf("Failed loading the descriptors: %v", err)
+	}
+	if *scenario != "" {
+		if err := m.LoadFile(*scenario); err != nil {
+			log.Fatalf("Failed loading the scenario: %v", err)
+		}
+	}
+
+	s := m.NewServer(grpc.ChainStreamInterceptor(logCall))
+	if *reflectionOn {
+		m.RegisterReflection(s)
+	}
+
+	listeners, err := transport.ListenAll(transport.SplitList(*listen), transport.DefaultSocketMode)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+	for _, lis := range listeners {
+		log.Printf("Serving on %v", transport.Addr(lis))
+		go func(lis net.Listener) {
+			if err := s.Serve(lis); err != nil {
+				log.Fatalf("failed to serve: %v", err)
+			}
+		}(lis)
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range ch {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		if *scenario == "" {
+			continue
+		}
+		// a broken file keeps the scripts loaded before
+		if err := m.LoadFile(*scenario); err != nil {
+			log.Printf("Failed reloading the scenario: %v", err)
+			continue
+		}
+		log.Printf("Reloaded %v", *scenario)
+	}
+
+	fmt.Println("Stopping the server")
+	s.GracefulStop()
+}
+
+// logCall logs every mocked call, all of them are streams for the mock
+func logCall(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	if strings.HasPrefix(info.FullMethod, "/grpc.reflection.") {
+		return err
+	}
+	log.Printf("%v: %v", info.FullMethod, errString(err))
+	return err
+}
+
+func errString(err error) string {
+	if err == nil {
+		return "OK"
+	}
+	return err.Error()
+}
+
+// loadProtoset reads a FileDescriptorSet built with its imports
+func loadProtoset(path string) (*protoregistry.Files, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, set); err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(set)
+}
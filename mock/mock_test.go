@@ -0,0 +1,257 @@
+package mock
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/transport"
+)
+
+// start serves m and returns a connection to it
+func start(t *testing.T, m *Server) *grpc.ClientConn {
+	t.Helper()
+	addr, stop, err := m.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+	cc, err := grpc.Dial(addr, append(transport.DialOptions(addr), grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return cc
+}
+
+func newServer(t *testing.T) *Server {
+	t.Helper()
+	m, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func greet(c greetpb.GreetServiceClient, name string) (string, error) {
+	res, err := c.Greet(context.Background(), &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: name}})
+	return res.GetResult(), err
+}
+
+// primeFactors returns the factors received before the stream ended with err
+func primeFactors(ctx context.Context, c calculatorpb.CalculatorServiceClient, number int64) ([]int64, error) {
+	stream, err := c.PrimeNumberDecomposition(ctx, &calculatorpb.PrimeNumberDecompositionRequest{Number: number})
+	if err != nil {
+		return nil, err
+	}
+	var factors []int64
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return factors, nil
+		}
+		if err != nil {
+			return factors, err
+		}
+		factors = append(factors, res.GetPrimeFactor())
+	}
+}
+
+func TestLoadScenario(t *testing.T) {
+	m := newServer(t)
+	if err := m.LoadFile("scenario.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	cc := start(t, m)
+	greetClient := greetpb.NewGreetServiceClient(cc)
+	calculatorClient := calculatorpb.NewCalculatorServiceClient(cc)
+
+	if result, err := greet(greetClient, "Ada"); err != nil || result != "Hello from the mock" {
+		t.Errorf("got %q, %v from the first Greet", result, err)
+	}
+	begin := time.Now()
+	if _, err := greet(greetClient, "Ada"); status.Code(err) != codes.Unavailable || status.Convert(err).Message() != "the mock is down" {
+		t.Errorf("got %v from the second Greet, want Unavailable", err)
+	}
+	if elapsed := time.Since(begin); elapsed < 500*time.Millisecond {
+		t.Errorf("the second Greet failed after %v, want a delay of 500ms", elapsed)
+	}
+
+	factors, err := primeFactors(context.Background(), calculatorClient, 10)
+	if status.Code(err) != codes.Unavailable || len(factors) != 1 || factors[0] != 2 {
+		t.Errorf("got %v, %v from the first stream, want 2 then Unavailable", factors, err)
+	}
+	factors, err = primeFactors(context.Background(), calculatorClient, 10)
+	if err != nil || len(factors) != 1 || factors[0] != 5 {
+		t.Errorf("got %v, %v from the resumed stream, want 5", factors, err)
+	}
+}
+
+func TestLoadRejectsBadScenarios(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		err      string
+	}{
+		{"unknown method", "methods: {greet.GreetService/Unknown: [{}]}", "unknown method"},
+		{"unknown field", "methods: {greet.GreetService/Greet: [{responses: [{nope: 1}]}]}", "step 0 response 0"},
+		{"unknown code", "methods: {greet.GreetService/Greet: [{status: {code: NOPE}}]}", "step 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newServer(t).Load(strings.NewReader(tt.scenario))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, want an error with %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestBidiSendsEachResponseAfterItsRequest(t *testing.T) {
+	m := newServer(t)
+	m.On("calculator.CalculatorService/FindMaximum", Reply(
+		&calculatorpb.FindMaximumResponse{Maximum: 1},
+		&calculatorpb.FindMaximumResponse{Maximum: 5},
+		&calculatorpb.FindMaximumResponse{Maximum: 5},
+		&calculatorpb.FindMaximumResponse{Maximum: 7},
+	))
+	c := calculatorpb.NewCalculatorServiceClient(start(t, m))
+
+	stream, err := c.FindMaximum(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, number := range []int32{1, 5, 3} {
+		if err := stream.Send(&calculatorpb.FindMaximumRequest{Number: number}); err != nil {
+			t.Fatal(err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if received := len(m.Calls("calculator.CalculatorService/FindMaximum")[0].Requests); received != i+1 {
+			t.Errorf("response %d (%v) sent after %d requests", i, res.GetMaximum(), received)
+		}
+	}
+	// the responses left are sent once the client is done
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	res, err := stream.Recv()
+	if err != nil || res.GetMaximum() != 7 {
+		t.Errorf("got %v, %v after closing, want the last response", res, err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("got %v, want the end of the stream", err)
+	}
+}
+
+func TestDelaysStopWithTheCall(t *testing.T) {
+	m := newServer(t)
+	m.On("greet.GreetService/Greet", Reply(&greetpb.GreetResponse{Result: "late"}).After(time.Hour))
+	m.On("calculator.CalculatorService/PrimeNumberDecomposition", Reply(
+		&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: 2},
+		&calculatorpb.PrimeNumberDecompositionResponse{PrimeFactor: 5},
+	).Every(time.Hour))
+	cc := start(t, m)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := greetpb.NewGreetServiceClient(cc).Greet(ctx, &greetpb.GreetRequest{})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("got %v from the delayed Greet, want DeadlineExceeded", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	factors, err := primeFactors(ctx, calculatorpb.NewCalculatorServiceClient(cc), 10)
+	if status.Code(err) != codes.DeadlineExceeded || len(factors) != 1 {
+		t.Errorf("got %v, %v from the paced stream, want one factor then DeadlineExceeded", factors, err)
+	}
+
+	// the server gave up waiting too, so the next calls are served
+	m.On("greet.GreetService/Greet", Reply(&greetpb.GreetResponse{Result: "Hi"}))
+	if result, err := greet(greetpb.NewGreetServiceClient(cc), "Ada"); err != nil || result != "Hi" {
+		t.Errorf("got %q, %v after the cancelled calls", result, err)
+	}
+}
+
+func TestLastStepRepeats(t *testing.T) {
+	m := newServer(t)
+	m.On("greet.GreetService/Greet",
+		Reply(&greetpb.GreetResponse{Result: "first"}),
+		Reply(&greetpb.GreetResponse{Result: "last"}),
+	)
+	c := greetpb.NewGreetServiceClient(start(t, m))
+
+	for i, want := range []string{"first", "last", "last", "last"} {
+		if result, err := greet(c, "Ada"); err != nil || result != want {
+			t.Errorf("call %d got %q, %v, want %q", i, result, err, want)
+		}
+	}
+
+	_, err := c.GreetWithDeadLine(context.Background(), &greetpb.GreetWithDeadLineRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("got %v from a method without script, want Unimplemented", err)
+	}
+	m.On("greet.GreetService/GreetWithDeadLine")
+	_, err = c.GreetWithDeadLine(context.Background(), &greetpb.GreetWithDeadLineRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("got %v from an empty script, want Unimplemented", err)
+	}
+}
+
+func TestCallsAfterReset(t *testing.T) {
+	m := newServer(t)
+	m.On("greet.GreetService/Greet", Reply(&greetpb.GreetResponse{Result: "Hi"}))
+	c := greetpb.NewGreetServiceClient(start(t, m))
+
+	if _, err := greet(c, "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := greet(c, "Grace"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := m.Calls(""); len(calls) != 2 {
+		t.Fatalf("recorded %d calls, want 2", len(calls))
+	}
+
+	m.Reset()
+	if calls := m.Calls(""); len(calls) != 0 {
+		t.Errorf("recorded %d calls after Reset", len(calls))
+	}
+	// the script is forgotten too, the call is still recorded
+	if _, err := greet(c, "Alan"); status.Code(err) != codes.Unimplemented {
+		t.Errorf("got %v after Reset, want Unimplemented", err)
+	}
+	m.On("greet.GreetService/Greet", Reply(&greetpb.GreetResponse{Result: "Hi"}))
+	if _, err := greet(c, "Barbara"); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := m.Calls("greet.GreetService/Greet")
+	if len(calls) != 2 {
+		t.Fatalf("recorded %d calls after Reset, want 2", len(calls))
+	}
+	for i, want := range []string{"Alan", "Barbara"} {
+		var req greetpb.GreetRequest
+		if err := calls[i].Decode(0, &req); err != nil {
+			t.Fatal(err)
+		}
+		if got := req.GetGreeting().GetFirstName(); got != want {
+			t.Errorf("call %d greeted %q, want %q", i, got, want)
+		}
+	}
+	if calls := m.Calls("calculator.CalculatorService/Sum"); len(calls) != 0 {
+		t.Errorf("recorded %d calls of a method never called", len(calls))
+	}
+}
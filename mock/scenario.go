@@ -0,0 +1,125 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"gopkg.in/yaml.v3"
+)
+
+// scenario is a scenario file, see Load
+type scenario struct {
+	Methods map[string][]scenarioStep `yaml:"methods"`
+}
+
+type scenarioStep struct {
+	Delay     time.Duration `yaml:"delay"`
+	Responses []interface{} `yaml:"responses"`
+	Interval  time.Duration `yaml:"interval"`
+	Status    *struct {
+		Code    string `yaml:"code"`
+		Message string `yaml:"message"`
+	} `yaml:"status"`
+}
+
+// LoadFile loads the scenario file at path, see Load
+func (m *Server) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := m.Load(f); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+// Load replaces the scripts of the methods of the YAML scenario read from r:
+//
+//	methods:
+//	  greet.GreetService/Greet:
+//	    - responses: [{result: Hi}]
+//	    - delay: 2s
+//	      status: {code: UNAVAILABLE, message: try again}
+//	  calculator.CalculatorService/PrimeNumberDecomposition:
+//	    - responses: [{primeFactor: 2}, {primeFactor: 5}]
+//	      interval: 100ms
+//
+// Steps have the fields of Step, responses in the JSON mapping of their
+// message and status codes by name or number.
+func (m *Server) Load(r io.Reader) error {
+	var sc scenario
+	if err := yaml.NewDecoder(r).Decode(&sc); err != nil && err != io.EOF {
+		return err
+	}
+
+	scripts := map[string][]Step{}
+	for method, fileSteps := range sc.Methods {
+		md, err := m.method(fullMethod(method))
+		if err != nil {
+			return err
+		}
+		var steps []Step
+		for i, fs := range fileSteps {
+			step := Step{Delay: fs.Delay, Interval: fs.Interval}
+			for j, res := range fs.Responses {
+				msg, err := m.response(md.Output(), res)
+				if err != nil {
+					return fmt.Errorf("%v step %d response %d: %v", method, i, j, err)
+				}
+				step.Responses = append(step.Responses, msg)
+			}
+			if fs.Status != nil {
+				code, err := parseCode(fs.Status.Code)
+				if err != nil {
+					return fmt.Errorf("%v step %d: %v", method, i, err)
+				}
+				step.Status = status.New(code, fs.Status.Message)
+			}
+			steps = append(steps, step)
+		}
+		scripts[method] = steps
+	}
+
+	for method, steps := range scripts {
+		m.On(method, steps...)
+	}
+	return nil
+}
+
+// response converts the YAML value of a response to a message of md
+func (m *Server) response(md protoreflect.MessageDescriptor, v interface{}) (proto.Message, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	msg := dynamicpb.NewMessage(md)
+	opts := protojson.UnmarshalOptions{Resolver: dynamicpb.NewTypes(m.files)}
+	if err := opts.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// parseCode parses a status code given by name, as UNAVAILABLE, or number
+func parseCode(s string) (codes.Code, error) {
+	var code codes.Code
+	if _, err := strconv.Atoi(s); err != nil {
+		s = strconv.Quote(s)
+	}
+	if err := code.UnmarshalJSON([]byte(s)); err != nil {
+		return 0, err
+	}
+	return code, nil
+}
@@ -0,0 +1,64 @@
+package mock
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	protov1 "github.com/golang/protobuf/proto"
+)
+
+// Step is how the server answers one call of a method
+type Step struct {
+	// Delay is waited before the first response
+	Delay time.Duration
+	// Responses are sent in order, unary and client streaming methods only
+	// send the first one. Bidi streams send the response i once they
+	// received the request i, the ones left once the client closed.
+	Responses []proto.Message
+	// Interval is waited between stream responses
+	Interval time.Duration
+	// Status ends the call after the responses when not nil
+	Status *status.Status
+}
+
+// Reply returns a step sending responses, which may be generated messages of
+// either protobuf API
+func Reply(responses ...protov1.Message) Step {
+	s := Step{}
+	for _, res := range responses {
+		s.Responses = append(s.Responses, protov1.MessageV2(res))
+	}
+	return s
+}
+
+// Fail returns a step failing with code
+func Fail(code codes.Code, format string, a ...interface{}) Step {
+	return Step{Status: status.New(code, fmt.Sprintf(format, a...))}
+}
+
+// FailWith returns a step failing with err, such as the errors of rpcerror
+func FailWith(err error) Step {
+	return Step{Status: status.Convert(err)}
+}
+
+// After waits d before the first response
+func (s Step) After(d time.Duration) Step {
+	s.Delay = d
+	return s
+}
+
+// Every waits d between stream responses
+func (s Step) Every(d time.Duration) Step {
+	s.Interval = d
+	return s
+}
+
+// Then fails with code after the responses, to break a stream midway
+func (s Step) Then(code codes.Code, format string, a ...interface{}) Step {
+	s.Status = status.New(code, fmt.Sprintf(format, a...))
+	return s
+}
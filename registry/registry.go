@@ -0,0 +1,237 @@
+// Package registry lets a single server binary run any subset of our services.
+// Services and interceptors are registered by name, and a configuration picks
+// the services to run and the interceptors applied to the calls of each one.
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// Service is a service built by a Factory
+type Service struct {
+	// Register registers the gRPC services on s
+	Register func(s *grpc.Server)
+	// Ready, when set, is called once every service is registered
+	Ready func(s *grpc.Server)
+	// Close, when set, releases the resources of the service
+	Close func() error
+}
+
+// Factory builds a service, decode unmarshals its configuration section and
+// leaves v untouched when the section is missing
+type Factory func(decode func(v interface{}) error) (*Service, error)
+
+// Interceptor is applied to the calls of the services configured with it,
+// either field may be nil
+type Interceptor struct {
+	Unary  grpc.UnaryServerInterceptor
+	Stream grpc.StreamServerInterceptor
+}
+
+// Spec selects a service to run
+type Spec struct {
+	Name string
+	// Interceptors are applied in order to the calls of the service
+	Interceptors []string
+	// Decode unmarshals the configuration section of the service, nil when
+	// there is none
+	Decode func(v interface{}) error
+}
+
+// Registry holds the services and interceptors known by a server binary
+type Registry struct {
+	factories    map[string]Factory
+	interceptors map[string]Interceptor
+}
+
+// New returns an empty Registry
+func New() *Registry {
+	return &Registry{
+		factories:    map[string]Factory{},
+		interceptors: map[string]Interceptor{},
+	}
+}
+
+// Service adds a service named name
+func (r *Registry) Service(name string, f Factory) {
+	r.factories[name] = f
+}
+
+// Interceptor adds an interceptor named name
+func (r *Registry) Interceptor(name string, i Interceptor) {
+	r.interceptors[name] = i
+}
+
+// Services returns the names of the known services, sorted
+func (r *Registry) Services() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build creates the services selected by specs
+func (r *Registry) Build(specs []Spec) (*Set, error) {
+	set := &Set{chains: map[string]*chain{}}
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		if seen[spec.Name] {
+			set.Close()
+			return nil, fmt.Errorf("service %q selected twice", spec.Name)
+		}
+		seen[spec.Name] = true
+
+		f, ok := r.factories[spec.Name]
+		if !ok {
+			set.Close()
+			return nil, fmt.Errorf("unknown service %q, want one of %s", spec.Name, strings.Join(r.Services(), ", "))
+		}
+		c := &chain{}
+		for _, name := range spec.Interceptors {
+			i, ok := r.interceptors[name]
+			if !ok {
+				set.Close()
+				return nil, fmt.Errorf("service %q: unknown interceptor %q", spec.Name, name)
+			}
+			if i.Unary != nil {
+				c.unary = append(c.unary, i.Unary)
+			}
+			if i.Stream != nil {
+				c.stream = append(c.stream, i.Stream)
+			}
+		}
+
+		decode := spec.Decode
+		if decode == nil {
+			decode = func(interface{}) error { return nil }
+		}
+		svc, err := f(decode)
+		if err != nil {
+			set.Close()
+			return nil, fmt.Errorf("service %q: %v", spec.Name, err)
+		}
+		set.entries = append(set.entries, entry{name: spec.Name, service: svc, chain: c})
+	}
+	return set, nil
+}
+
+type entry struct {
+	name    string
+	service *Service
+	chain   *chain
+}
+
+// Set is the services built from a configuration
+type Set struct {
+	entries []entry
+	// chains maps the full gRPC service names, like "greet.GreetService",
+	// to the interceptors of the service that registered them. It is
+	// written by Register before the server starts and only read after.
+	chains map[string]*chain
+}
+
+// ServerOptions returns the options routing every call through the
+// interceptors of its service, they must be given to grpc.NewServer
+func (set *Set) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(set.unary),
+		grpc.ChainStreamInterceptor(set.stream),
+	}
+}
+
+// Register registers every service on s, then calls their Ready hooks
+func (set *Set) Register(s *grpc.Server) {
+	for _, e := range set.entries {
+		before := s.GetServiceInfo()
+		e.service.Register(s)
+		for name := range s.GetServiceInfo() {
+			if _, ok := before[name]; !ok {
+				set.chains[name] = e.chain
+			}
+		}
+	}
+	for _, e := range set.entries {
+		if e.service.Ready != nil {
+			e.service.Ready(s)
+		}
+	}
+}
+
+// Names returns the names of the services in the set, in configuration order
+func (set *Set) Names() []string {
+	names := make([]string, 0, len(set.entries))
+	for _, e := range set.entries {
+		names = append(names, e.name)
+	}
+	return names
+}
+
+// Close closes every service, returning the first error
+func (set *Set) Close() error {
+	var errs []error
+	for _, e := range set.entries {
+		if e.service.Close != nil {
+			if err := e.service.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("closing %s: %v", e.name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (set *Set) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	c := set.chains[serviceOf(info.FullMethod)]
+	if c == nil {
+		return handler(ctx, req)
+	}
+	return c.runUnary(ctx, 0, req, info, handler)
+}
+
+func (set *Set) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	c := set.chains[serviceOf(info.FullMethod)]
+	if c == nil {
+		return handler(srv, ss)
+	}
+	return c.runStream(0, srv, ss, info, handler)
+}
+
+// serviceOf returns the service of a full method name "/service/method"
+func serviceOf(fullMethod string) string {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// chain is the interceptors of a service
+type chain struct {
+	unary  []grpc.UnaryServerInterceptor
+	stream []grpc.StreamServerInterceptor
+}
+
+func (c *chain) runUnary(ctx context.Context, i int, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if i == len(c.unary) {
+		return handler(ctx, req)
+	}
+	return c.unary[i](ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return c.runUnary(ctx, i+1, req, info, handler)
+	})
+}
+
+func (c *chain) runStream(i int, srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if i == len(c.stream) {
+		return handler(srv, ss)
+	}
+	return c.stream[i](srv, ss, info, func(srv interface{}, ss grpc.ServerStream) error {
+		return c.runStream(i+1, srv, ss, info, handler)
+	})
+}
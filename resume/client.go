@@ -0,0 +1,98 @@
+package resume
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Message is a streamed response carrying a resume token
+type Message interface {
+	GetResumeToken() string
+}
+
+// Stream is the receiving side of a server stream
+type Stream[T Message] interface {
+	Recv() (T, error)
+}
+
+// Backoff is how long Receive waits before reopening a broken stream
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	// MaxAttempts bounds the reconnections in a row without receiving a
+	// message, 0 for no bound
+	MaxAttempts int
+}
+
+// DefaultBackoff gives up after about 20 seconds without progress
+var DefaultBackoff = Backoff{
+	Initial:     100 * time.Millisecond,
+	Max:         5 * time.Second,
+	Multiplier:  2,
+	MaxAttempts: 8,
+}
+
+// Delay returns the wait before the given attempt, with 20% jitter
+func (b Backoff) Delay(attempt int) time.Duration {
+	d := float64(b.Initial)
+	for i := 1; i < attempt && d < float64(b.Max); i++ {
+		d *= b.Multiplier
+	}
+	if d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	return time.Duration(d * (0.8 + 0.4*rand.Float64()))
+}
+
+// Receive opens a stream with open and passes every message to handle until
+// the stream ends. When the stream breaks because the server is unavailable it
+// is reopened with the last token received, so no message is handled twice.
+func Receive[T Message](ctx context.Context, b Backoff, open func(ctx context.Context, token string) (Stream[T], error), handle func(T) error) error {
+	token := ""
+	attempt := 0
+	for {
+		broken, err := receive(ctx, open, handle, &token, &attempt)
+		if !broken || status.Code(err) != codes.Unavailable || ctx.Err() != nil {
+			return err
+		}
+
+		attempt++
+		if b.MaxAttempts > 0 && attempt > b.MaxAttempts {
+			return err
+		}
+		select {
+		case <-time.After(b.Delay(attempt)):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// receive runs one attempt, updating token and resetting attempt on
+// progress. broken reports whether err came from the stream, not handle.
+func receive[T Message](ctx context.Context, open func(ctx context.Context, token string) (Stream[T], error), handle func(T) error, token *string, attempt *int) (broken bool, err error) {
+	stream, err := open(ctx, *token)
+	if err != nil {
+		return true, err
+	}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return true, err
+		}
+		if err := handle(msg); err != nil {
+			return false, err
+		}
+		*token = msg.GetResumeToken()
+		*attempt = 0
+	}
+}
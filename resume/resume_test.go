@@ -0,0 +1,386 @@
+package resume_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/calculator/calculatorservice"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/greetservice"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// testBackoff retries quickly, the connections are in-process
+var testBackoff = resume.Backoff{
+	Initial:     10 * time.Millisecond,
+	Max:         100 * time.Millisecond,
+	Multiplier:  2,
+	MaxAttempts: 20,
+}
+
+// killer closes the server side of the connections it accepted partway
+// through the streams, as a server dropping its clients
+type killer struct {
+	net.Listener
+	// killAfter[n] is the number of messages received by the client on the
+	// n-th stream before its connection is killed
+	killAfter []int
+	streams   atomic.Int64
+
+	mu    sync.Mutex
+	conns []net.Conn
+	// received is closed once the client receives the message of a token
+	received map[string]chan struct{}
+}
+
+func (k *killer) Accept() (net.Conn, error) {
+	conn, err := k.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.conns = append(k.conns, conn)
+	return conn, nil
+}
+
+func (k *killer) kill() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for _, conn := range k.conns {
+		conn.Close()
+	}
+	k.conns = nil
+}
+
+func (k *killer) receivedChan(token string) chan struct{} {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.received == nil {
+		k.received = make(map[string]chan struct{})
+	}
+	if k.received[token] == nil {
+		k.received[token] = make(chan struct{})
+	}
+	return k.received[token]
+}
+
+// receive is called by the client for every message received
+func (k *killer) receive(msg resume.Message) {
+	c := k.receivedChan(msg.GetResumeToken())
+	select {
+	case <-c:
+	default:
+		close(c)
+	}
+}
+
+func (k *killer) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ks := &killingStream{ServerStream: ss, k: k}
+	if n := int(k.streams.Add(1)) - 1; n < len(k.killAfter) {
+		ks.kill = k.killAfter[n]
+	}
+	return handler(srv, ks)
+}
+
+// killingStream kills the connections once the client has received kill
+// messages of it, 0 to never kill them
+type killingStream struct {
+	grpc.ServerStream
+	k    *killer
+	sent int
+	kill int
+}
+
+func (s *killingStream) SendMsg(m interface{}) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.sent++
+	if s.sent != s.kill {
+		return nil
+	}
+	// the messages sent but still in flight would be lost without
+	// being resumed
+	select {
+	case <-s.k.receivedChan(m.(resume.Message).GetResumeToken()):
+	case <-s.Context().Done():
+	}
+	s.k.kill()
+	return status.Error(codes.Unavailable, "connection killed")
+}
+
+// serve starts a server registered by register, the connection of its n-th
+// stream is killed once the client received killAfter[n] messages of it
+func serve(t *testing.T, register func(*grpc.Server), killAfter ...int) (*grpc.ClientConn, *killer) {
+	t.Helper()
+	// the services log every call
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	l := bufconn.Listen(1 << 16)
+	k := &killer{Listener: l, killAfter: killAfter}
+	s := grpc.NewServer(grpc.StreamInterceptor(k.StreamServerInterceptor))
+	register(s)
+	go s.Serve(k)
+	t.Cleanup(s.Stop)
+
+	dial := func(ctx context.Context, _ string) (net.Conn, error) {
+		return l.DialContext(ctx)
+	}
+	cc, err := grpc.Dial("bufconn", grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return cc, k
+}
+
+func greetClient(t *testing.T, killAfter ...int) (greetpb.GreetServiceClient, *killer) {
+	t.Helper()
+	svc, err := greetservice.New(greetservice.DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { svc.Close() })
+	cc, k := serve(t, func(s *grpc.Server) { greetpb.RegisterGreetServiceServer(s, svc) }, killAfter...)
+	return greetpb.NewGreetServiceClient(cc), k
+}
+
+func calculatorClient(t *testing.T, killAfter ...int) (calculatorpb.CalculatorServiceClient, *killer) {
+	t.Helper()
+	svc, err := calculatorservice.New(calculatorservice.DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc, k := serve(t, func(s *grpc.Server) { calculatorpb.RegisterCalculatorServiceServer(s, svc) }, killAfter...)
+	return calculatorpb.NewCalculatorServiceClient(cc), k
+}
+
+func greetManyTimesRequest(firstName string) *greetpb.GreetManyTimesRequest {
+	return &greetpb.GreetManyTimesRequest{
+		Greeting: &greetpb.Greeting{FirstName: firstName, LastName: "Lovelace"},
+		Count:    10,
+		Interval: durationpb.New(time.Millisecond),
+	}
+}
+
+// opens records the tokens the streams were opened with
+type opens struct {
+	mu     sync.Mutex
+	tokens []string
+}
+
+func (o *opens) add(token string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.tokens = append(o.tokens, token)
+}
+
+func TestReceiveResumesGreetManyTimes(t *testing.T) {
+	c, k := greetClient(t, 3, 4)
+	var o opens
+	open := func(ctx context.Context, token string) (resume.Stream[*greetpb.GreetManyTimesResponse], error) {
+		o.add(token)
+		req := greetManyTimesRequest("Ada")
+		req.ResumeToken = token
+		return c.GreetManyTimes(ctx, req)
+	}
+	var got []string
+	handle := func(res *greetpb.GreetManyTimesResponse) error {
+		got = append(got, res.GetResult())
+		k.receive(res)
+		return nil
+	}
+	if err := resume.Receive(context.Background(), testBackoff, open, handle); err != nil {
+		t.Fatal(err)
+	}
+
+	var want []string
+	for i := 0; i < 10; i++ {
+		want = append(want, "Hello Ada Lovelace number "+strconv.Itoa(i))
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got greetings %q, want %q", got, want)
+	}
+	checkResumed(t, o.tokens)
+}
+
+func TestReceiveResumesPrimeNumberDecomposition(t *testing.T) {
+	c, k := calculatorClient(t, 2, 3)
+	var o opens
+	open := func(ctx context.Context, token string) (resume.Stream[*calculatorpb.PrimeNumberDecompositionResponse], error) {
+		o.add(token)
+		return c.PrimeNumberDecomposition(ctx, &calculatorpb.PrimeNumberDecompositionRequest{Number: 120120, ResumeToken: token})
+	}
+	var got []int64
+	handle := func(res *calculatorpb.PrimeNumberDecompositionResponse) error {
+		got = append(got, res.GetPrimeFactor())
+		k.receive(res)
+		return nil
+	}
+	if err := resume.Receive(context.Background(), testBackoff, open, handle); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int64{2, 2, 2, 3, 5, 7, 11, 13}; !slices.Equal(got, want) {
+		t.Errorf("got factors %v, want %v", got, want)
+	}
+	checkResumed(t, o.tokens)
+}
+
+// checkResumed asserts the stream was reopened after each kill, every time
+// from a token
+func checkResumed(t *testing.T, tokens []string) {
+	t.Helper()
+	if len(tokens) < 3 {
+		t.Fatalf("the stream was opened %d times, want at least 3", len(tokens))
+	}
+	if tokens[0] != "" {
+		t.Errorf("the first stream was opened with token %q", tokens[0])
+	}
+	for i, token := range tokens[1:] {
+		if token == "" {
+			t.Errorf("the stream was reopened from the start on attempt %d", i+1)
+		}
+	}
+}
+
+func TestReceiveGivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	open := func(ctx context.Context, token string) (resume.Stream[*greetpb.GreetManyTimesResponse], error) {
+		attempts++
+		return nil, status.Error(codes.Unavailable, "server down")
+	}
+	b := testBackoff
+	b.MaxAttempts = 3
+	start := time.Now()
+	err := resume.Receive(context.Background(), b, open, func(*greetpb.GreetManyTimesResponse) error { return nil })
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("got %v, want Unavailable", err)
+	}
+	if attempts != 4 {
+		t.Errorf("opened %d times, want the first attempt and 3 retries", attempts)
+	}
+	// the delays double from 10ms, less 20% of jitter
+	if elapsed, want := time.Since(start), 56*time.Millisecond; elapsed < want {
+		t.Errorf("retried within %v, want a backoff of at least %v", elapsed, want)
+	}
+}
+
+// firstToken returns the token of the first message streamed for req
+func firstToken(t *testing.T, c greetpb.GreetServiceClient, req *greetpb.GreetManyTimesRequest) string {
+	t.Helper()
+	stream, err := c.GreetManyTimes(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.GetResumeToken()
+}
+
+// checkRejected asserts err rejects the resume token with description
+func checkRejected(t *testing.T, err error, description string) {
+	t.Helper()
+	if status.Code(err) != codes.InvalidArgument || rpcerror.Reason(err) != resume.Reason {
+		t.Fatalf("got %v, want InvalidArgument %v", err, resume.Reason)
+	}
+	violations := rpcerror.Violations(err)
+	if len(violations) != 1 || violations[0].GetField() != "resume_token" || violations[0].GetDescription() != description {
+		t.Errorf("got violations %v, want resume_token %v", violations, description)
+	}
+}
+
+func TestGreetManyTimesRejectsBadTokens(t *testing.T) {
+	c, _ := greetClient(t)
+	token := firstToken(t, c, greetManyTimesRequest("Ada"))
+	payload, mac, _ := strings.Cut(token, ".")
+	// flip changes the last character of a base64 string
+	flip := func(s string) string {
+		if strings.HasSuffix(s, "A") {
+			return s[:len(s)-1] + "B"
+		}
+		return s[:len(s)-1] + "A"
+	}
+
+	tests := []struct {
+		name        string
+		req         *greetpb.GreetManyTimesRequest
+		token       string
+		description string
+	}{
+		{"malformed", greetManyTimesRequest("Ada"), "garbage", "is malformed"},
+		{"tampered payload", greetManyTimesRequest("Ada"), flip(payload) + "." + mac, "was not issued by this server"},
+		{"tampered mac", greetManyTimesRequest("Ada"), payload + "." + flip(mac), "was not issued by this server"},
+		{"other request", greetManyTimesRequest("Grace"), token, "was issued for a different request"},
+		{"state beyond count", greetManyTimesRequest("Ada"), resume.Token(greetManyTimesRequest("Ada"), 11), "has an unexpected state"},
+		{"negative state", greetManyTimesRequest("Ada"), resume.Token(greetManyTimesRequest("Ada"), -1), "has an unexpected state"},
+		{"state of another length", greetManyTimesRequest("Ada"), resume.Token(greetManyTimesRequest("Ada"), 1, 2), "has an unexpected state"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.ResumeToken = tt.token
+			stream, err := c.GreetManyTimes(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = stream.Recv()
+			checkRejected(t, err, tt.description)
+		})
+	}
+}
+
+func TestPrimeNumberDecompositionRejectsBadTokens(t *testing.T) {
+	c, _ := calculatorClient(t)
+	req := func(number int64) *calculatorpb.PrimeNumberDecompositionRequest {
+		return &calculatorpb.PrimeNumberDecompositionRequest{Number: number}
+	}
+
+	tests := []struct {
+		name        string
+		number      int64
+		token       string
+		description string
+	}{
+		{"other request", 120, resume.Token(req(60), 30, 2), "was issued for a different request"},
+		{"not a divisor", 120, resume.Token(req(120), 7, 2), "has an unexpected state"},
+		{"divisor too small", 120, resume.Token(req(120), 60, 1), "has an unexpected state"},
+		{"divisor beyond the square root", 120, resume.Token(req(120), 15, 11), "has an unexpected state"},
+		{"number left negative", 120, resume.Token(req(120), -60, 2), "has an unexpected state"},
+		{"state of another length", 120, resume.Token(req(120), 60), "has an unexpected state"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := req(tt.number)
+			r.ResumeToken = tt.token
+			stream, err := c.PrimeNumberDecomposition(context.Background(), r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = stream.Recv()
+			checkRejected(t, err, tt.description)
+		})
+	}
+}
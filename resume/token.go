@@ -0,0 +1,136 @@
+// Package resume lets server streams continue where they stopped after the
+// connection drops. Every streamed message carries a resume token holding the
+// stream state, a new call sent with the last token received picks up from
+// that state instead of starting over.
+package resume
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+// Reason is the ErrorInfo reason of the errors returned for bad tokens
+const Reason = "INVALID_RESUME_TOKEN"
+
+// tokenField is the request field carrying the token, it is left out of the
+// request fingerprint so the resumed call matches the original one
+const tokenField = "resume_token"
+
+// key signs the tokens, a random one unless SetKey is called, so the tokens
+// of a process are only accepted by it
+var (
+	keyMu sync.RWMutex
+	key   = randomKey()
+)
+
+func randomKey() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand does not fail on the supported platforms
+	}
+	return b
+}
+
+// SetKey sets the key signing the tokens, the replicas of a service and its
+// restarts accept each other tokens when they share it
+func SetKey(k []byte) {
+	keyMu.Lock()
+	defer keyMu.Unlock()
+	key = append([]byte(nil), k...)
+}
+
+// sign returns the MAC of payload
+func sign(payload string) []byte {
+	keyMu.RLock()
+	defer keyMu.RUnlock()
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+type token struct {
+	Request     string  `json:"r"`
+	Fingerprint string  `json:"f"`
+	State       []int64 `json:"s"`
+}
+
+// Token returns the token resuming the stream opened by req once it has
+// reached state
+func Token(req proto.Message, state ...int64) string {
+	b, err := json.Marshal(token{
+		Request:     proto.MessageName(req),
+		Fingerprint: fingerprint(req),
+		State:       state,
+	})
+	if err != nil {
+		panic(err) // plain strings and numbers always marshal
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(sign(payload))
+}
+
+// State returns the n values of state carried by the token of req, or nil
+// when req has no token and the stream starts from the beginning
+func State(req proto.Message, n int) ([]int64, error) {
+	fd := proto.MessageReflect(req).Descriptor().Fields().ByName(tokenField)
+	if fd == nil {
+		return nil, fmt.Errorf("%s has no %s field", proto.MessageName(req), tokenField)
+	}
+	raw := proto.MessageReflect(req).Get(fd).String()
+	if raw == "" {
+		return nil, nil
+	}
+
+	payload, encodedMAC, ok := strings.Cut(raw, ".")
+	if !ok {
+		return nil, invalid("is malformed")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, sign(payload)) {
+		return nil, invalid("was not issued by this server")
+	}
+	var t token
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err == nil {
+		err = json.Unmarshal(b, &t)
+	}
+	switch {
+	case err != nil:
+		return nil, invalid("is malformed")
+	case t.Request != proto.MessageName(req) || t.Fingerprint != fingerprint(req):
+		return nil, invalid("was issued for a different request")
+	case len(t.State) != n:
+		return nil, invalid("has an unexpected state")
+	}
+	return t.State, nil
+}
+
+func invalid(description string) error {
+	return rpcerror.InvalidArgument(Reason, rpcerror.Violation(tokenField, description))
+}
+
+// fingerprint hashes req without its token
+func fingerprint(req proto.Message) string {
+	m := protov2.Clone(proto.MessageV2(req))
+	if fd := m.ProtoReflect().Descriptor().Fields().ByName(tokenField); fd != nil {
+		m.ProtoReflect().Clear(fd)
+	}
+	b, err := protov2.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		panic(err) // req was just unmarshalled by the server
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,75 @@
+package rpcerror
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// Reason returns the ErrorInfo reason carried by err, if any
+func Reason(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+// Violations returns the BadRequest field violations carried by err
+func Violations(err error) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			violations = append(violations, br.GetFieldViolations()...)
+		}
+	}
+	return violations
+}
+
+// RetryDelay returns how long the server asked to wait before retrying
+func RetryDelay(err error) (time.Duration, bool) {
+	for _, d := range status.Convert(err).Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			if err := ri.GetRetryDelay().CheckValid(); err != nil {
+				return 0, false
+			}
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// Describe renders err and every error detail it carries in a readable way
+func Describe(err error) string {
+	st := status.Convert(err)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v: %v", st.Code(), st.Message())
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			fmt.Fprintf(&b, "\n  reason: %v (domain %v)", d.GetReason(), d.GetDomain())
+			for k, v := range d.GetMetadata() {
+				fmt.Fprintf(&b, "\n    %v: %v", k, v)
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				fmt.Fprintf(&b, "\n  field %v: %v", v.GetField(), v.GetDescription())
+			}
+		case *errdetails.RetryInfo:
+			if d.GetRetryDelay().CheckValid() == nil {
+				fmt.Fprintf(&b, "\n  retry after: %v", d.GetRetryDelay().AsDuration())
+			}
+		case error:
+			// details the client does not know how to decode
+			fmt.Fprintf(&b, "\n  undecodable detail: %v", d)
+		default:
+			fmt.Fprintf(&b, "\n  detail: %v", d)
+		}
+	}
+	return b.String()
+}
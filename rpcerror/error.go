@@ -0,0 +1,88 @@
+package rpcerror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is a gRPC error with its details unpacked, as returned by our client
+// packages. errors.Is matches it with the sentinel errors below by code, and
+// by reason when the target has one.
+type Error struct {
+	Code    codes.Code
+	Message string
+	// Reason is the ErrorInfo reason, empty when the server sent none
+	Reason     string
+	Violations []*errdetails.BadRequest_FieldViolation
+	// RetryDelay is how long the server asked to wait before retrying, 0
+	// when it did not
+	RetryDelay time.Duration
+
+	status *status.Status
+}
+
+// The sentinel errors matched by errors.Is
+var (
+	ErrCanceled          = &Error{Code: codes.Canceled}
+	ErrInvalidArgument   = &Error{Code: codes.InvalidArgument}
+	ErrDeadlineExceeded  = &Error{Code: codes.DeadlineExceeded}
+	ErrNotFound          = &Error{Code: codes.NotFound}
+	ErrPermissionDenied  = &Error{Code: codes.PermissionDenied}
+	ErrResourceExhausted = &Error{Code: codes.ResourceExhausted}
+	ErrUnimplemented     = &Error{Code: codes.Unimplemented}
+	ErrUnavailable       = &Error{Code: codes.Unavailable}
+	ErrUnauthenticated   = &Error{Code: codes.Unauthenticated}
+)
+
+// FromError returns err as an *Error, nil stays nil. Context errors become
+// Canceled or DeadlineExceeded.
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	st, ok := status.FromError(err)
+	if !ok && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		st = status.FromContextError(err)
+	}
+	e = &Error{
+		Code:       st.Code(),
+		Message:    st.Message(),
+		Reason:     Reason(err),
+		Violations: Violations(err),
+		status:     st,
+	}
+	e.RetryDelay, _ = RetryDelay(err)
+	return e
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %v", e.Code, e.Message)
+}
+
+// GRPCStatus lets status.FromError and status.Code read e
+func (e *Error) GRPCStatus() *status.Status {
+	if e.status == nil {
+		return status.New(e.Code, e.Message)
+	}
+	return e.status
+}
+
+// Is reports whether target is a sentinel matching e
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code && (t.Reason == "" || t.Reason == e.Reason)
+}
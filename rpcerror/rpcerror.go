@@ -0,0 +1,70 @@
+// Package rpcerror builds the gRPC errors returned by our services with
+// structured google.rpc error details, and unpacks them on the client side.
+package rpcerror
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain is the ErrorInfo domain of every error raised by our services
+const Domain = "grpc-go-course.christiangda.github.com"
+
+// Violation describes why the value of a request field is not valid
+func Violation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	}
+}
+
+// New returns an error with code c carrying an ErrorInfo with reason
+func New(c codes.Code, reason string, format string, a ...interface{}) error {
+	st := status.New(c, fmt.Sprintf(format, a...))
+
+	return detailedErr(st)(st.WithDetails(errorInfo(reason)))
+}
+
+// InvalidArgument returns an InvalidArgument error carrying a BadRequest with
+// violations and an ErrorInfo with reason
+func InvalidArgument(reason string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	msgs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		msgs = append(msgs, v.GetField()+" "+v.GetDescription())
+	}
+	st := status.New(codes.InvalidArgument, "Invalid argument: "+strings.Join(msgs, ", "))
+
+	return detailedErr(st)(st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}, errorInfo(reason)))
+}
+
+// Throttled returns a ResourceExhausted error carrying a RetryInfo that asks
+// the client to wait for delay before retrying, and an ErrorInfo with reason
+func Throttled(reason string, delay time.Duration, format string, a ...interface{}) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf(format, a...))
+
+	return detailedErr(st)(st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}, errorInfo(reason)))
+}
+
+func errorInfo(reason string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Domain: Domain,
+		Reason: reason,
+	}
+}
+
+// detailedErr returns the error of the status built by st.WithDetails,
+// falling back to the bare st when the details can't be marshaled
+func detailedErr(st *status.Status) func(*status.Status, error) error {
+	return func(detailed *status.Status, err error) error {
+		if err != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	}
+}
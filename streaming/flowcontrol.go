@@ -0,0 +1,66 @@
+package streaming
+
+import (
+	"flag"
+	"strconv"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+)
+
+// FlowControl tunes the HTTP/2 flow control of a server, zero fields keep the
+// gRPC defaults
+type FlowControl struct {
+	// InitialWindowSize is the bytes a client may send on a stream before the
+	// server reads them, values below 64KiB are ignored by gRPC
+	InitialWindowSize int32 `yaml:"initial_window_size"`
+	// InitialConnWindowSize is the same bound for all the streams of a
+	// connection
+	InitialConnWindowSize int32 `yaml:"initial_conn_window_size"`
+	// MaxConcurrentStreams bounds the streams open at once on a connection
+	MaxConcurrentStreams uint32 `yaml:"max_concurrent_streams"`
+}
+
+// RegisterFlags binds the fields of fc to command line flags
+func (fc *FlowControl) RegisterFlags(fs *flag.FlagSet) {
+	fs.Func("initial-window-size", "HTTP/2 stream window size in bytes, 0 for the gRPC default", intFlag(func(v int64) { fc.InitialWindowSize = int32(v) }, 31))
+	fs.Func("initial-conn-window-size", "HTTP/2 connection window size in bytes, 0 for the gRPC default", intFlag(func(v int64) { fc.InitialConnWindowSize = int32(v) }, 31))
+	fs.Func("max-concurrent-streams", "streams open at once on a connection, 0 for no limit", intFlag(func(v int64) { fc.MaxConcurrentStreams = uint32(v) }, 32))
+}
+
+// ServerOptions returns the grpc options applying fc
+func (fc FlowControl) ServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if fc.InitialWindowSize > 0 {
+		opts = append(opts, grpc.InitialWindowSize(fc.InitialWindowSize))
+	}
+	if fc.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.InitialConnWindowSize(fc.InitialConnWindowSize))
+	}
+	if fc.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(fc.MaxConcurrentStreams))
+	}
+	return opts
+}
+
+// HTTP2Server returns the net/http HTTP/2 settings applying fc, used when
+// gRPC is served through net/http as in the web mode
+func (fc FlowControl) HTTP2Server() *http2.Server {
+	return &http2.Server{
+		MaxConcurrentStreams:         fc.MaxConcurrentStreams,
+		MaxUploadBufferPerStream:     fc.InitialWindowSize,
+		MaxUploadBufferPerConnection: fc.InitialConnWindowSize,
+	}
+}
+
+// intFlag parses a non negative flag value of at most bits bits into set
+func intFlag(set func(int64), bits int) func(string) error {
+	return func(s string) error {
+		v, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return err
+		}
+		set(int64(v))
+		return nil
+	}
+}
@@ -0,0 +1,117 @@
+package streaming
+
+import (
+	"flag"
+	"time"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Keepalive is the connection lifecycle policy of a server, zero fields keep
+// the gRPC defaults
+type Keepalive struct {
+	// Time is how long a connection may stay silent before the server pings
+	// the client, and Timeout how long it waits for the ack before closing
+	// the connection, so dead peers are noticed even during a stream
+	Time    time.Duration `yaml:"time"`
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxConnectionIdle closes connections without RPCs for this long
+	MaxConnectionIdle time.Duration `yaml:"max_connection_idle"`
+	// MaxConnectionAge closes connections this old, after letting their
+	// RPCs run for MaxConnectionAgeGrace more, so clients rebalance
+	MaxConnectionAge      time.Duration `yaml:"max_connection_age"`
+	MaxConnectionAgeGrace time.Duration `yaml:"max_connection_age_grace"`
+	// Enforcement is what the server accepts from the client keepalives,
+	// connections pinging too often are closed
+	Enforcement struct {
+		MinTime             time.Duration `yaml:"min_time"`
+		PermitWithoutStream bool          `yaml:"permit_without_stream"`
+	} `yaml:"enforcement"`
+}
+
+// RegisterFlags binds the fields of k to command line flags
+func (k *Keepalive) RegisterFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&k.Time, "keepalive-time", k.Time, "ping clients silent for this long, 0 for the gRPC default of 2h")
+	fs.DurationVar(&k.Timeout, "keepalive-timeout", k.Timeout, "close connections not answering a ping within this time, 0 for the gRPC default of 20s")
+	fs.DurationVar(&k.MaxConnectionIdle, "max-connection-idle", k.MaxConnectionIdle, "close connections without RPCs for this long, 0 for never")
+	fs.DurationVar(&k.MaxConnectionAge, "max-connection-age", k.MaxConnectionAge, "close connections this old, 0 for never")
+	fs.DurationVar(&k.MaxConnectionAgeGrace, "max-connection-age-grace", k.MaxConnectionAgeGrace, "time given to the RPCs of a connection closed by its age, 0 for no limit")
+	fs.DurationVar(&k.Enforcement.MinTime, "keepalive-min-time", k.Enforcement.MinTime, "close connections pinging more often than this, 0 for the gRPC default of 5m")
+	fs.BoolVar(&k.Enforcement.PermitWithoutStream, "keepalive-permit-without-stream", k.Enforcement.PermitWithoutStream, "accept client pings on connections without RPCs")
+}
+
+// ServerOptions returns the grpc options applying k
+func (k Keepalive) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     k.MaxConnectionIdle,
+			MaxConnectionAge:      k.MaxConnectionAge,
+			MaxConnectionAgeGrace: k.MaxConnectionAgeGrace,
+			Time:                  k.Time,
+			Timeout:               k.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             k.Enforcement.MinTime,
+			PermitWithoutStream: k.Enforcement.PermitWithoutStream,
+		}),
+	}
+}
+
+// ApplyHTTP2 applies k to the net/http HTTP/2 settings of the web mode, which
+// only closes idle connections: it has no pings, connection age nor
+// enforcement policy
+func (k Keepalive) ApplyHTTP2(s *http2.Server) *http2.Server {
+	s.IdleTimeout = k.MaxConnectionIdle
+	return s
+}
+
+// ClientKeepalive is the keepalive of a client, the server closes the
+// connections pinging more often than its enforcement policy allows
+type ClientKeepalive struct {
+	// Time is how long the connection may stay silent before the client
+	// pings the server, 0 disables the pings
+	Time time.Duration `yaml:"time"`
+	// Timeout is how long the client waits for the ack before closing the
+	// connection
+	Timeout time.Duration `yaml:"timeout"`
+	// PermitWithoutStream also pings connections without RPCs
+	PermitWithoutStream bool `yaml:"permit_without_stream"`
+}
+
+// DefaultClientKeepalive pings every 30s, within the 10s minimum accepted by
+// DefaultKeepalive
+var DefaultClientKeepalive = ClientKeepalive{Time: 30 * time.Second, Timeout: 10 * time.Second}
+
+// DefaultKeepalive reaps dead peers within a minute and accepts
+// DefaultClientKeepalive, connections are not closed by their age
+var DefaultKeepalive = func() Keepalive {
+	k := Keepalive{
+		Time:              30 * time.Second,
+		Timeout:           20 * time.Second,
+		MaxConnectionIdle: 15 * time.Minute,
+	}
+	k.Enforcement.MinTime = 10 * time.Second
+	k.Enforcement.PermitWithoutStream = true
+	return k
+}()
+
+// RegisterFlags binds the fields of k to command line flags
+func (k *ClientKeepalive) RegisterFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&k.Time, "keepalive-time", k.Time, "ping the server after this long without activity, 0 to disable")
+	fs.DurationVar(&k.Timeout, "keepalive-timeout", k.Timeout, "close the connection when a ping is not answered within this time")
+	fs.BoolVar(&k.PermitWithoutStream, "keepalive-permit-without-stream", k.PermitWithoutStream, "also ping while no RPC is running")
+}
+
+// DialOptions returns the grpc options applying k
+func (k ClientKeepalive) DialOptions() []grpc.DialOption {
+	if k.Time <= 0 {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                k.Time,
+		Timeout:             k.Timeout,
+		PermitWithoutStream: k.PermitWithoutStream,
+	})}
+}
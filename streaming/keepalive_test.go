@@ -0,0 +1,104 @@
+package streaming
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// blackholeConn stops delivering the data of a connection both ways once
+// dropped, as a peer gone without closing it
+type blackholeConn struct {
+	net.Conn
+	dropped atomic.Bool
+}
+
+func (c *blackholeConn) Read(p []byte) (int, error) {
+	for {
+		n, err := c.Conn.Read(p)
+		if err != nil || !c.dropped.Load() {
+			return n, err
+		}
+	}
+}
+
+func (c *blackholeConn) Write(p []byte) (int, error) {
+	if c.dropped.Load() {
+		return len(p), nil
+	}
+	return c.Conn.Write(p)
+}
+
+// closeListener reports when the server closes a connection it accepted
+type closeListener struct {
+	net.Listener
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (l *closeListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &closeConn{Conn: conn, l: l}, nil
+}
+
+type closeConn struct {
+	net.Conn
+	l *closeListener
+}
+
+func (c *closeConn) Close() error {
+	c.l.once.Do(func() { close(c.l.closed) })
+	return c.Conn.Close()
+}
+
+func TestKeepaliveReapsDeadPeers(t *testing.T) {
+	// gRPC pings no sooner than after a second of silence
+	k := Keepalive{Time: time.Second, Timeout: 100 * time.Millisecond}
+	k.Enforcement.PermitWithoutStream = true
+
+	l := bufconn.Listen(1 << 16)
+	lis := &closeListener{Listener: l, closed: make(chan struct{})}
+	s := grpc.NewServer(k.ServerOptions()...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	// the first connection is the one blackholed
+	var peer atomic.Pointer[blackholeConn]
+	dial := func(ctx context.Context, _ string) (net.Conn, error) {
+		conn, err := l.DialContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c := &blackholeConn{Conn: conn}
+		peer.CompareAndSwap(nil, c)
+		return c, nil
+	}
+	cc, err := grpc.Dial("bufconn", grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	if _, err := healthpb.NewHealthClient(cc).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	peer.Load().dropped.Store(true)
+	select {
+	case <-lis.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server kept the connection of the dead peer")
+	}
+}
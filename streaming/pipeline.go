@@ -0,0 +1,102 @@
+// Package streaming holds the flow control helpers shared by our servers: an
+// ordered worker pipeline for bidirectional streams and the HTTP/2 window and
+// concurrency settings.
+package streaming
+
+import (
+	"context"
+	"io"
+)
+
+// Pipeline processes the messages of a stream with bounded parallelism
+type Pipeline struct {
+	// Workers is the number of messages processed at once, 1 when unset
+	Workers int `yaml:"workers"`
+	// Depth is the number of messages received ahead of the one being sent,
+	// at least Workers. Once reached, receiving stops until the client reads
+	// and the HTTP/2 window pushes back on the client.
+	Depth int `yaml:"depth"`
+}
+
+// Run receives messages with recv until io.EOF, processes them with process
+// and sends the results with send in the order the messages were received.
+// Results with ok set to false are not sent. The first error stops the
+// pipeline and is returned.
+func Run[Req, Res any](ctx context.Context, p Pipeline, recv func() (Req, error), process func(context.Context, Req) (res Res, ok bool, err error), send func(Res) error) error {
+	workers := p.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	depth := p.Depth
+	if depth < workers {
+		depth = workers
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		res Res
+		ok  bool
+		err error
+	}
+	// every message gets a slot, slots are queued in receiving order
+	slots := make(chan chan result, depth)
+	sem := make(chan struct{}, workers)
+	recvErr := make(chan error, 1)
+
+	go func() {
+		defer close(slots)
+		for {
+			req, err := recv()
+			if err != nil {
+				if err != io.EOF {
+					recvErr <- err
+				}
+				return
+			}
+
+			slot := make(chan result, 1)
+			select {
+			case slots <- slot:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func() {
+				defer func() { <-sem }()
+				res, ok, err := process(ctx, req)
+				slot <- result{res, ok, err}
+			}()
+		}
+	}()
+
+	for slot := range slots {
+		var r result
+		select {
+		case r = <-slot:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if r.err != nil {
+			return r.err
+		}
+		if !r.ok {
+			continue
+		}
+		if err := send(r.res); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case err := <-recvErr:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/christiangda/grpc-go-course/cache"
+	"github.com/christiangda/grpc-go-course/compression"
+	"github.com/christiangda/grpc-go-course/fault"
+	"github.com/christiangda/grpc-go-course/idempotency"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/registry"
+	"github.com/christiangda/grpc-go-course/streaming"
+	"github.com/christiangda/grpc-go-course/transport"
+)
+
+// Config is the configuration file of the suite server
+type Config struct {
+	// Listen is the address of the gRPC port, host:port, unix:///path or
+	// unix:relative/path
+	Listen string `yaml:"listen"`
+	// Listeners are more addresses served along Listen
+	Listeners []string `yaml:"listeners"`
+	// SocketMode is the permissions of the unix sockets
+	SocketMode os.FileMode `yaml:"socket_mode"`
+	// TLS is disabled when CertFile is empty
+	TLS struct {
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+	} `yaml:"tls"`
+	// Web also serves the gRPC-Web and Connect protocols on the gRPC port
+	Web struct {
+		Enabled     bool     `yaml:"enabled"`
+		CORSOrigins []string `yaml:"cors_origins"`
+	} `yaml:"web"`
+	FlowControl streaming.FlowControl `yaml:"flow_control"`
+	// Keepalive reaps dead peers and recycles old connections, the fields
+	// missing from the file keep their defaults
+	Keepalive streaming.Keepalive `yaml:"keepalive"`
+	// Compression of the responses, negotiated with each client
+	Compression compression.Config `yaml:"compression"`
+	// Faults are injected into the calls of every service, they can be
+	// replaced at runtime through the admin port
+	Faults fault.Config `yaml:"faults"`
+	// Cache answers repeated requests of deterministic methods with the
+	// responses of the first one, the fields missing from the file keep
+	// their defaults
+	Cache cache.Config `yaml:"cache"`
+	// Idempotency answers the retries of unary calls sending an
+	// idempotency-key with the outcome of their first attempt
+	Idempotency idempotency.Config `yaml:"idempotency"`
+	// LogLevel is the initial log level, it can be changed at runtime
+	// through the admin port
+	LogLevel logging.Level `yaml:"log_level"`
+	// Admin serves channelz and the AdminService on a port of its own,
+	// disabled when Listen is empty
+	Admin struct {
+		Listen string `yaml:"listen"`
+		// Listen may also be a unix socket, created with SocketMode
+		// Token authenticates the admin calls, it can also be read from
+		// TokenFile or the SUITE_ADMIN_TOKEN environment variable
+		Token     string `yaml:"token"`
+		TokenFile string `yaml:"token_file"`
+		// TLS secures the admin port with the certificates of the gRPC port,
+		// it may only be turned off on a local address
+		TLS bool `yaml:"tls"`
+	} `yaml:"admin"`
+	// Services are the services to run, in registration order
+	Services []ServiceConfig `yaml:"services"`
+}
+
+// ServiceConfig selects a service and configures it
+type ServiceConfig struct {
+	Name string `yaml:"name"`
+	// Interceptors are applied in order to the calls of the service
+	Interceptors []string `yaml:"interceptors"`
+	// Config is the configuration of the service itself, the defaults of
+	// its own server are used for the missing fields
+	Config yaml.Node `yaml:"config"`
+}
+
+// defaultConfig runs every service without TLS nor admin port, the admin
+// port requires TLS once enabled
+func defaultConfig() *Config {
+	cfg := &Config{Listen: "0.0.0.0:50051", LogLevel: logging.Info, SocketMode: transport.DefaultSocketMode, Keepalive: streaming.DefaultKeepalive, Cache: cache.DefaultConfig, Idempotency: idempotency.DefaultConfig}
+	cfg.Admin.TLS = true
+	cfg.Services = []ServiceConfig{
+		{Name: "greet", Interceptors: []string{"validate"}},
+		{Name: "calculator", Interceptors: []string{"validate"}},
+		{Name: "health"},
+		{Name: "reflection"},
+	}
+	return cfg
+}
+
+// loadConfig reads the configuration file at path, the default configuration
+// is used when path is empty
+func loadConfig(path string) (*Config, error) {
+	cfg := defaultConfig()
+	if path == "" {
+		return cfg, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Services = nil
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+	if err := cfg.Compression.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := cfg.Faults.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := cfg.Cache.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := cfg.Idempotency.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := cfg.validateAdmin(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// validateAdmin rejects an admin port whose token would cross the network in
+// plain text
+func (cfg *Config) validateAdmin() error {
+	switch {
+	case cfg.Admin.Listen == "":
+		return nil
+	case cfg.Admin.TLS && cfg.TLS.CertFile == "":
+		return errors.New("admin.tls needs the certificates of tls.cert_file and tls.key_file")
+	case !cfg.Admin.TLS && !transport.IsLocal(cfg.Admin.Listen):
+		return fmt.Errorf("admin.tls may only be false on a loopback address or a unix socket, not %s", cfg.Admin.Listen)
+	}
+	return nil
+}
+
+// specs returns the registry specs of the configured services
+func (cfg *Config) specs() []registry.Spec {
+	specs := make([]registry.Spec, 0, len(cfg.Services))
+	for i := range cfg.Services {
+		sc := &cfg.Services[i]
+		spec := registry.Spec{Name: sc.Name, Interceptors: sc.Interceptors}
+		if !sc.Config.IsZero() {
+			spec.Decode = sc.Config.Decode
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// adminToken returns the token of the admin port from the configuration, its
+// file or the environment, in this order
+func (cfg *Config) adminToken() (string, error) {
+	if cfg.Admin.Token != "" {
+		return cfg.Admin.Token, nil
+	}
+	if cfg.Admin.TokenFile != "" {
+		b, err := os.ReadFile(cfg.Admin.TokenFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	if token := os.Getenv("SUITE_ADMIN_TOKEN"); token != "" {
+		return token, nil
+	}
+	return "", errors.New("admin.token, admin.token_file or SUITE_ADMIN_TOKEN must be set")
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/grpclog"
+
+	"github.com/christiangda/grpc-go-course/admin"
+	"github.com/christiangda/grpc-go-course/bridge"
+	"github.com/christiangda/grpc-go-course/cache"
+	"github.com/christiangda/grpc-go-course/fault"
+	"github.com/christiangda/grpc-go-course/idempotency"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/resume"
+	"github.com/christiangda/grpc-go-course/transport"
+)
+
+func main() {
+	configFile := flag.String("config", "", "YAML configuration file, by default every service but admin runs without TLS")
+	flag.Parse()
+	// the servers sharing the key resume the streams of each other, and
+	// their own streams across restarts
+	if key := os.Getenv("SUITE_RESUME_KEY"); key != "" {
+		resume.SetKey([]byte(key))
+	}
+
+	fmt.Println("Suite Server")
+
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed loading the configuration: %v", err)
+	}
+	logging.SetLevel(cfg.LogLevel)
+	grpclog.SetLoggerV2(logging.GRPCLogger{})
+
+	set, err := newRegistry().Build(cfg.specs())
+	if err != nil {
+		log.Fatalf("Failed creating the services: %v", err)
+	}
+
+	faults, err := fault.New(cfg.Faults)
+	if err != nil {
+		log.Fatalf("Failed creating the fault injector: %v", err)
+	}
+
+	responseCache, err := cache.New(cfg.Cache)
+	if err != nil {
+		log.Fatalf("Failed creating the response cache: %v", err)
+	}
+
+	idempotent, err := idempotency.New(cfg.Idempotency)
+	if err != nil {
+		log.Fatalf("Failed creating the idempotency store: %v", err)
+	}
+
+	// faults are injected inside the interceptors of the services, which
+	// see them as the errors of the calls, and before the cache, so cached
+	// responses are delayed or failed too
+	opts := set.ServerOptions()
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(faults.UnaryServerInterceptor(), idempotent.UnaryServerInterceptor(), responseCache.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(faults.StreamServerInterceptor(), responseCache.StreamServerInterceptor()),
+	)
+	opts = append(opts, cfg.FlowControl.ServerOptions()...)
+	opts = append(opts, cfg.Keepalive.ServerOptions()...)
+	opts = append(opts, cfg.Compression.ServerOptions()...)
+	var creds credentials.TransportCredentials
+	if cfg.TLS.CertFile != "" {
+		var sslErr error
+		creds, sslErr = credentials.NewServerTLSFromFile(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if sslErr != nil {
+			log.Fatalf("Failed loading certificates: %v\n", sslErr)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	var adminServer *grpc.Server
+	if cfg.Admin.Listen != "" {
+		adminServer = newAdminServer(cfg, creds, faults, responseCache, &opts)
+	}
+
+	s := grpc.NewServer(opts...)
+	set.Register(s)
+
+	listeners, err := transport.ListenAll(append([]string{cfg.Listen}, cfg.Listeners...), cfg.SocketMode)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	serve := s.Serve
+	var hs *http.Server
+	if cfg.Web.Enabled {
+		hs = bridge.NewServer(s, bridge.Options{AllowedOrigins: cfg.Web.CORSOrigins}, cfg.Keepalive.ApplyHTTP2(cfg.FlowControl.HTTP2Server()))
+		serve = func(lis net.Listener) error {
+			if cfg.TLS.CertFile != "" {
+				return hs.ServeTLS(lis, cfg.TLS.CertFile, cfg.TLS.KeyFile)
+			}
+			return hs.Serve(lis)
+		}
+	}
+	for _, lis := range listeners {
+		log.Printf("Serving %v on %v", set.Names(), transport.Addr(lis))
+		go func(lis net.Listener) {
+			if err := serve(lis); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("failed to serve: %v", err)
+			}
+		}(lis)
+	}
+
+	if adminServer != nil {
+		adminLis, err := transport.Listen(cfg.Admin.Listen, cfg.SocketMode)
+		if err != nil {
+			log.Fatalf("Failed to listen on the admin port: %v", err)
+		}
+		log.Printf("Serving admin on %v", transport.Addr(adminLis))
+		go func() {
+			if err := adminServer.Serve(adminLis); err != nil {
+				log.Fatalf("failed to serve admin: %v", err)
+			}
+		}()
+	}
+
+	// Wait for Control C to exit
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	<-ch
+
+	fmt.Println("Stopping the server")
+	if hs != nil {
+		hs.Close()
+	}
+	if adminServer != nil {
+		adminServer.Stop()
+	}
+	s.GracefulStop()
+	if err := set.Close(); err != nil {
+		log.Printf("Failed closing the services: %v", err)
+	}
+}
+
+// newAdminServer returns the admin server of cfg and adds the stats handler
+// feeding it to the options of the gRPC port
+func newAdminServer(cfg *Config, creds credentials.TransportCredentials, faults *fault.Injector, responseCache *cache.Cache, opts *[]grpc.ServerOption) *grpc.Server {
+	token, err := cfg.adminToken()
+	if err != nil {
+		log.Fatalf("Failed reading the admin token: %v", err)
+	}
+	adminOpts := admin.Options{
+		Token:  token,
+		Config: cfg,
+		Faults: faults,
+		Cache:  responseCache,
+	}
+	if cfg.Admin.TLS {
+		adminOpts.Creds = creds
+	}
+	adminServer, err := admin.Attach(adminOpts, opts)
+	if err != nil {
+		log.Fatalf("Failed creating the admin server: %v", err)
+	}
+	return adminServer
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/calculator/calculatorservice"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/greet/greetservice"
+	"github.com/christiangda/grpc-go-course/logging"
+	"github.com/christiangda/grpc-go-course/registry"
+	"github.com/christiangda/grpc-go-course/validate"
+)
+
+// newRegistry returns the registry of every service and interceptor the suite
+// server can run
+func newRegistry() *registry.Registry {
+	r := registry.New()
+
+	r.Service("greet", func(decode func(interface{}) error) (*registry.Service, error) {
+		cfg := greetservice.DefaultConfig
+		if err := decode(&cfg); err != nil {
+			return nil, err
+		}
+		srv, err := greetservice.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &registry.Service{
+			Register: func(s *grpc.Server) { greetpb.RegisterGreetServiceServer(s, srv) },
+			Close:    srv.Close,
+		}, nil
+	})
+
+	r.Service("calculator", func(decode func(interface{}) error) (*registry.Service, error) {
+		cfg := calculatorservice.DefaultConfig
+		if err := decode(&cfg); err != nil {
+			return nil, err
+		}
+		srv, err := calculatorservice.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &registry.Service{
+			Register: func(s *grpc.Server) { calculatorpb.RegisterCalculatorServiceServer(s, srv) },
+		}, nil
+	})
+
+	// health reports every registered service as serving
+	r.Service("health", func(decode func(interface{}) error) (*registry.Service, error) {
+		hs := health.NewServer()
+		return &registry.Service{
+			Register: func(s *grpc.Server) { healthpb.RegisterHealthServer(s, hs) },
+			Ready: func(s *grpc.Server) {
+				for name := range s.GetServiceInfo() {
+					hs.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+				}
+			},
+			Close: func() error {
+				hs.Shutdown()
+				return nil
+			},
+		}, nil
+	})
+
+	// reflection serves both v1 and v1alpha, leave it out of the
+	// configuration to turn it off, descriptors.Protoset still describes
+	// the services
+	r.Service("reflection", func(decode func(interface{}) error) (*registry.Service, error) {
+		return &registry.Service{Register: func(s *grpc.Server) { reflection.Register(s) }}, nil
+	})
+
+	r.Interceptor("validate", registry.Interceptor{
+		Unary:  validate.UnaryServerInterceptor(),
+		Stream: validate.StreamServerInterceptor(),
+	})
+	r.Interceptor("logging", registry.Interceptor{
+		Unary:  logUnary,
+		Stream: logStream,
+	})
+
+	return r
+}
+
+// logUnary logs every unary call with its outcome
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	logging.Infof("%s %v in %v", info.FullMethod, status.Code(err), time.Since(start))
+	return res, err
+}
+
+// logStream logs every stream with its outcome
+func logStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logging.Infof("%s %v in %v", info.FullMethod, status.Code(err), time.Since(start))
+	return err
+}
@@ -0,0 +1,241 @@
+// Package transport opens the listeners of our servers from addresses such
+// as 0.0.0.0:50051, unix:///run/greet.sock or memory://greet, and dials the
+// same addresses from the clients.
+package transport
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// The address schemes, addresses without one are TCP
+const (
+	TCP    = "tcp"
+	Unix   = "unix"
+	Memory = "memory"
+)
+
+// DefaultSocketMode lets the owner and the group of the server call it
+const DefaultSocketMode os.FileMode = 0o660
+
+// memoryBufferSize is the bytes buffered by each side of a memory connection
+const memoryBufferSize = 1 << 20
+
+// Parse splits addr into its scheme and the address within it: the path of
+// unix sockets, the name of memory listeners or the host:port of TCP
+func Parse(addr string) (scheme, address string) {
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		return Unix, strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "unix:"):
+		return Unix, strings.TrimPrefix(addr, "unix:")
+	case strings.HasPrefix(addr, "memory://"):
+		return Memory, strings.TrimPrefix(addr, "memory://")
+	case strings.HasPrefix(addr, "tcp://"):
+		return TCP, strings.TrimPrefix(addr, "tcp://")
+	}
+	return TCP, addr
+}
+
+// IsLocal reports whether addr can only be reached from this host: a unix
+// socket, a memory listener or a loopback TCP address
+func IsLocal(addr string) bool {
+	scheme, address := Parse(addr)
+	if scheme != TCP {
+		return true
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Listen opens the listener of addr, unix sockets are created with mode
+func Listen(addr string, mode os.FileMode) (net.Listener, error) {
+	scheme, address := Parse(addr)
+	switch scheme {
+	case Unix:
+		return listenUnix(address, mode)
+	case Memory:
+		return listenMemory(address)
+	}
+	return net.Listen("tcp", address)
+}
+
+// ListenAll opens the listeners of addrs, closing them all if one fails
+func ListenAll(addrs []string, mode os.FileMode) ([]net.Listener, error) {
+	var listeners []net.Listener
+	for _, addr := range addrs {
+		lis, err := Listen(addr, mode)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, fmt.Errorf("listening on %s: %v", addr, err)
+		}
+		listeners = append(listeners, lis)
+	}
+	return listeners, nil
+}
+
+// SplitList splits a comma separated list of addresses, as the -listen flag
+// of the servers, ignoring empty items
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Addr returns the address of lis in the form accepted by Listen and Dial
+func Addr(lis net.Listener) string {
+	switch a := lis.Addr().(type) {
+	case *net.UnixAddr:
+		if strings.HasPrefix(a.Name, "/") {
+			return "unix://" + a.Name
+		}
+		return "unix:" + a.Name
+	case memoryAddr:
+		return "memory://" + string(a)
+	}
+	return lis.Addr().String()
+}
+
+// listenUnix listens on the socket at path, removing the socket left behind
+// by a server that did not stop cleanly
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
+
+// removeStaleSocket removes the socket at path when no server accepts
+// connections on it, other files are never removed
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("%s is in use by another server", path)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// memoryListeners are the memory listeners of the process by name
+var memoryListeners = struct {
+	sync.Mutex
+	m map[string]*memoryListener
+}{m: map[string]*memoryListener{}}
+
+type memoryAddr string
+
+func (a memoryAddr) Network() string { return Memory }
+func (a memoryAddr) String() string  { return string(a) }
+
+type memoryListener struct {
+	*bufconn.Listener
+	name string
+}
+
+func (l *memoryListener) Addr() net.Addr {
+	return memoryAddr(l.name)
+}
+
+// Close also frees the name of the listener
+func (l *memoryListener) Close() error {
+	memoryListeners.Lock()
+	if memoryListeners.m[l.name] == l {
+		delete(memoryListeners.m, l.name)
+	}
+	memoryListeners.Unlock()
+	return l.Listener.Close()
+}
+
+func listenMemory(name string) (net.Listener, error) {
+	memoryListeners.Lock()
+	defer memoryListeners.Unlock()
+	if _, ok := memoryListeners.m[name]; ok {
+		return nil, fmt.Errorf("memory listener %q already exists", name)
+	}
+	l := &memoryListener{Listener: bufconn.Listen(memoryBufferSize), name: name}
+	memoryListeners.m[name] = l
+	return l, nil
+}
+
+// Dial connects to addr, memory addresses only within the process
+func Dial(ctx context.Context, addr string) (net.Conn, error) {
+	scheme, address := Parse(addr)
+	if scheme == Memory {
+		memoryListeners.Lock()
+		l, ok := memoryListeners.m[address]
+		memoryListeners.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("no memory listener %q", address)
+		}
+		return l.DialContext(ctx)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, scheme, address)
+}
+
+// DialOptions returns the grpc options needed to dial target, grpc itself
+// dials TCP and unix targets
+func DialOptions(target string) []grpc.DialOption {
+	if scheme, _ := Parse(target); scheme != Memory {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithContextDialer(Dial)}
+}
+
+// SocketModeFlag binds mode to the -socket-mode flag, in octal
+func SocketModeFlag(fs *flag.FlagSet, mode *os.FileMode) {
+	fs.Func("socket-mode", "permissions of the unix sockets, in octal (default "+strconv.FormatUint(uint64(*mode), 8)+")", func(s string) error {
+		m, err := strconv.ParseUint(s, 8, 32)
+		if err != nil {
+			return err
+		}
+		*mode = os.FileMode(m)
+		return nil
+	})
+}
@@ -0,0 +1,177 @@
+// Package validate enforces the (validate.rules) field options declared in
+// our .proto files on every request received by a server.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/christiangda/grpc-go-course/rpcerror"
+	"github.com/christiangda/grpc-go-course/validate/validatepb"
+)
+
+// Reason is the ErrorInfo reason of the errors returned on rule violations
+const Reason = "VALIDATION_FAILED"
+
+// Message checks msg and its nested messages against their rules and returns
+// an InvalidArgument error listing every violation
+func Message(msg proto.Message) error {
+	violations := check(proto.MessageReflect(msg), "")
+	if len(violations) > 0 {
+		return rpcerror.InvalidArgument(Reason, violations...)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor validates the request of every unary RPC
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := Message(msg); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every message received on a stream
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		return Message(msg)
+	}
+	return nil
+}
+
+func check(m protoreflect.Message, prefix string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := prefix + string(fd.Name())
+		rules := rulesOf(fd)
+
+		if rules.GetRequired() && !m.Has(fd) {
+			violations = append(violations, rpcerror.Violation(name, "is required"))
+			continue
+		}
+		if fd.IsList() || fd.IsMap() {
+			if fd.IsList() && fd.Message() != nil && !rules.GetSkip() {
+				list := m.Get(fd).List()
+				for j := 0; j < list.Len(); j++ {
+					violations = append(violations, check(list.Get(j).Message(), fmt.Sprintf("%v[%v].", name, j))...)
+				}
+			}
+			continue
+		}
+
+		switch fd.Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind:
+			if m.Has(fd) && !rules.GetSkip() {
+				violations = append(violations, check(m.Get(fd).Message(), name+".")...)
+			}
+		case protoreflect.StringKind:
+			violations = append(violations, checkString(name, m.Get(fd).String(), rules.GetString_())...)
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+			violations = append(violations, checkInt32(name, int32(m.Get(fd).Int()), rules.GetInt32())...)
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			violations = append(violations, checkInt64(name, m.Get(fd).Int(), rules.GetInt64())...)
+		}
+	}
+
+	return violations
+}
+
+func rulesOf(fd protoreflect.FieldDescriptor) *validatepb.FieldRules {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil || !proto.HasExtension(opts, validatepb.E_Rules) {
+		return nil
+	}
+	ext, err := proto.GetExtension(opts, validatepb.E_Rules)
+	if err != nil {
+		return nil
+	}
+	rules, _ := ext.(*validatepb.FieldRules)
+	return rules
+}
+
+func checkString(name, value string, rules *validatepb.StringRules) []*errdetails.BadRequest_FieldViolation {
+	if rules == nil {
+		return nil
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	length := uint64(utf8.RuneCountInString(value))
+	if rules.MinLen != nil && length < rules.GetMinLen() {
+		if rules.GetMinLen() == 1 {
+			violations = append(violations, rpcerror.Violation(name, "must not be empty"))
+		} else {
+			violations = append(violations, rpcerror.Violation(name, fmt.Sprintf("must be at least %v characters", rules.GetMinLen())))
+		}
+	}
+	if rules.MaxLen != nil && length > rules.GetMaxLen() {
+		violations = append(violations, rpcerror.Violation(name, fmt.Sprintf("must be at most %v characters", rules.GetMaxLen())))
+	}
+	return violations
+}
+
+func checkInt32(name string, value int32, rules *validatepb.Int32Rules) []*errdetails.BadRequest_FieldViolation {
+	if rules == nil {
+		return nil
+	}
+	return checkRange(name, int64(value),
+		optInt64(rules.Gt), optInt64(rules.Gte), optInt64(rules.Lt), optInt64(rules.Lte))
+}
+
+func checkInt64(name string, value int64, rules *validatepb.Int64Rules) []*errdetails.BadRequest_FieldViolation {
+	if rules == nil {
+		return nil
+	}
+	return checkRange(name, value, rules.Gt, rules.Gte, rules.Lt, rules.Lte)
+}
+
+func checkRange(name string, value int64, gt, gte, lt, lte *int64) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if gt != nil && !(value > *gt) {
+		violations = append(violations, rpcerror.Violation(name, fmt.Sprintf("must be > %v, received %v", *gt, value)))
+	}
+	if gte != nil && !(value >= *gte) {
+		violations = append(violations, rpcerror.Violation(name, fmt.Sprintf("must be >= %v, received %v", *gte, value)))
+	}
+	if lt != nil && !(value < *lt) {
+		violations = append(violations, rpcerror.Violation(name, fmt.Sprintf("must be < %v, received %v", *lt, value)))
+	}
+	if lte != nil && !(value <= *lte) {
+		violations = append(violations, rpcerror.Violation(name, fmt.Sprintf("must be <= %v, received %v", *lte, value)))
+	}
+	return violations
+}
+
+func optInt64(v *int32) *int64 {
+	if v == nil {
+		return nil
+	}
+	n := int64(*v)
+	return &n
+}
@@ -0,0 +1,130 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/christiangda/grpc-go-course/calculator/calculatorpb"
+	"github.com/christiangda/grpc-go-course/greet/greetpb"
+	"github.com/christiangda/grpc-go-course/rpcerror"
+)
+
+var validationTests = []struct {
+	name string
+	req  proto.Message
+	// violations maps the fields violated to their description, none for a
+	// valid request
+	violations map[string]string
+}{
+	{
+		name: "valid greeting",
+		req:  &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Ada"}},
+	},
+	{
+		name:       "missing greeting",
+		req:        &greetpb.GreetRequest{},
+		violations: map[string]string{"greeting": "is required"},
+	},
+	{
+		name:       "empty first_name",
+		req:        &greetpb.GreetRequest{Greeting: &greetpb.Greeting{LastName: "Lovelace"}},
+		violations: map[string]string{"greeting.first_name": "must not be empty"},
+	},
+	{
+		name: "valid square root",
+		req:  &calculatorpb.SquareRootRequest{Number: 16},
+	},
+	{
+		name:       "negative square root",
+		req:        &calculatorpb.SquareRootRequest{Number: -4},
+		violations: map[string]string{"number": "must be >= 0, received -4"},
+	},
+	{
+		name: "valid prime number",
+		req:  &calculatorpb.PrimeNumberDecompositionRequest{Number: 120},
+	},
+	{
+		name:       "prime number too small",
+		req:        &calculatorpb.PrimeNumberDecompositionRequest{Number: 0},
+		violations: map[string]string{"number": "must be >= 1, received 0"},
+	},
+	{
+		name:       "prime number too large",
+		req:        &calculatorpb.PrimeNumberDecompositionRequest{Number: 1000000000001},
+		violations: map[string]string{"number": "must be <= 1000000000000, received 1000000000001"},
+	},
+}
+
+// checkViolations asserts err is the InvalidArgument listing want, or nil
+// when want is empty
+func checkViolations(t *testing.T, err error, want map[string]string) {
+	t.Helper()
+	if len(want) == 0 {
+		if err != nil {
+			t.Fatalf("valid request rejected: %v", err)
+		}
+		return
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument", err)
+	}
+	violations := rpcerror.Violations(err)
+	if len(violations) != len(want) {
+		t.Errorf("got violations %v, want %v", violations, want)
+	}
+	for _, v := range violations {
+		if description, ok := want[v.GetField()]; !ok || description != v.GetDescription() {
+			t.Errorf("unexpected violation of %v: %v", v.GetField(), v.GetDescription())
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	for _, tt := range validationTests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return req, nil
+			}
+			_, err := interceptor(context.Background(), tt.req, &grpc.UnaryServerInfo{FullMethod: "/test/Unary"}, handler)
+			checkViolations(t, err, tt.violations)
+			if called != (len(tt.violations) == 0) {
+				t.Errorf("handler called: %v", called)
+			}
+		})
+	}
+}
+
+// recvStream delivers req to the first RecvMsg
+type recvStream struct {
+	grpc.ServerStream
+	req proto.Message
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor()
+	for _, tt := range validationTests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				// as the generated handlers, receive into a new message
+				m := proto.Clone(tt.req)
+				m.Reset()
+				return stream.RecvMsg(m)
+			}
+			err := interceptor(nil, &recvStream{req: tt.req}, &grpc.StreamServerInfo{FullMethod: "/test/Stream", IsClientStream: true}, handler)
+			checkViolations(t, err, tt.violations)
+		})
+	}
+}
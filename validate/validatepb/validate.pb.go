@@ -0,0 +1,318 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: validate/validatepb/validate.proto
+
+package validatepb // import "github.com/christiangda/grpc-go-course/validate/validatepb"
+
+/*
+Declarative validation rules for request fields, enforced by the
+interceptors of the validate package.
+
+  string first_name = 1 [(validate.rules).string.min_len = 1];
+*/
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type FieldRules struct {
+	// message fields must be set
+	Required *bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	// do not validate the fields of this message, the handler does it
+	Skip                 *bool        `protobuf:"varint,2,opt,name=skip" json:"skip,omitempty"`
+	String_              *StringRules `protobuf:"bytes,3,opt,name=string" json:"string,omitempty"`
+	Int32                *Int32Rules  `protobuf:"bytes,4,opt,name=int32" json:"int32,omitempty"`
+	Int64                *Int64Rules  `protobuf:"bytes,5,opt,name=int64" json:"int64,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *FieldRules) Reset()         { *m = FieldRules{} }
+func (m *FieldRules) String() string { return proto.CompactTextString(m) }
+func (*FieldRules) ProtoMessage()    {}
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_validate_ecdda15ce150eab2, []int{0}
+}
+func (m *FieldRules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldRules.Unmarshal(m, b)
+}
+func (m *FieldRules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldRules.Marshal(b, m, deterministic)
+}
+func (dst *FieldRules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldRules.Merge(dst, src)
+}
+func (m *FieldRules) XXX_Size() int {
+	return xxx_messageInfo_FieldRules.Size(m)
+}
+func (m *FieldRules) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldRules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldRules proto.InternalMessageInfo
+
+func (m *FieldRules) GetRequired() bool {
+	if m != nil && m.Required != nil {
+		return *m.Required
+	}
+	return false
+}
+
+func (m *FieldRules) GetSkip() bool {
+	if m != nil && m.Skip != nil {
+		return *m.Skip
+	}
+	return false
+}
+
+func (m *FieldRules) GetString_() *StringRules {
+	if m != nil {
+		return m.String_
+	}
+	return nil
+}
+
+func (m *FieldRules) GetInt32() *Int32Rules {
+	if m != nil {
+		return m.Int32
+	}
+	return nil
+}
+
+func (m *FieldRules) GetInt64() *Int64Rules {
+	if m != nil {
+		return m.Int64
+	}
+	return nil
+}
+
+type StringRules struct {
+	// length in characters
+	MinLen               *uint64  `protobuf:"varint,1,opt,name=min_len,json=minLen" json:"min_len,omitempty"`
+	MaxLen               *uint64  `protobuf:"varint,2,opt,name=max_len,json=maxLen" json:"max_len,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StringRules) Reset()         { *m = StringRules{} }
+func (m *StringRules) String() string { return proto.CompactTextString(m) }
+func (*StringRules) ProtoMessage()    {}
+func (*StringRules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_validate_ecdda15ce150eab2, []int{1}
+}
+func (m *StringRules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StringRules.Unmarshal(m, b)
+}
+func (m *StringRules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StringRules.Marshal(b, m, deterministic)
+}
+func (dst *StringRules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StringRules.Merge(dst, src)
+}
+func (m *StringRules) XXX_Size() int {
+	return xxx_messageInfo_StringRules.Size(m)
+}
+func (m *StringRules) XXX_DiscardUnknown() {
+	xxx_messageInfo_StringRules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StringRules proto.InternalMessageInfo
+
+func (m *StringRules) GetMinLen() uint64 {
+	if m != nil && m.MinLen != nil {
+		return *m.MinLen
+	}
+	return 0
+}
+
+func (m *StringRules) GetMaxLen() uint64 {
+	if m != nil && m.MaxLen != nil {
+		return *m.MaxLen
+	}
+	return 0
+}
+
+type Int32Rules struct {
+	Gt                   *int32   `protobuf:"varint,1,opt,name=gt" json:"gt,omitempty"`
+	Gte                  *int32   `protobuf:"varint,2,opt,name=gte" json:"gte,omitempty"`
+	Lt                   *int32   `protobuf:"varint,3,opt,name=lt" json:"lt,omitempty"`
+	Lte                  *int32   `protobuf:"varint,4,opt,name=lte" json:"lte,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Int32Rules) Reset()         { *m = Int32Rules{} }
+func (m *Int32Rules) String() string { return proto.CompactTextString(m) }
+func (*Int32Rules) ProtoMessage()    {}
+func (*Int32Rules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_validate_ecdda15ce150eab2, []int{2}
+}
+func (m *Int32Rules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Int32Rules.Unmarshal(m, b)
+}
+func (m *Int32Rules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Int32Rules.Marshal(b, m, deterministic)
+}
+func (dst *Int32Rules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Int32Rules.Merge(dst, src)
+}
+func (m *Int32Rules) XXX_Size() int {
+	return xxx_messageInfo_Int32Rules.Size(m)
+}
+func (m *Int32Rules) XXX_DiscardUnknown() {
+	xxx_messageInfo_Int32Rules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Int32Rules proto.InternalMessageInfo
+
+func (m *Int32Rules) GetGt() int32 {
+	if m != nil && m.Gt != nil {
+		return *m.Gt
+	}
+	return 0
+}
+
+func (m *Int32Rules) GetGte() int32 {
+	if m != nil && m.Gte != nil {
+		return *m.Gte
+	}
+	return 0
+}
+
+func (m *Int32Rules) GetLt() int32 {
+	if m != nil && m.Lt != nil {
+		return *m.Lt
+	}
+	return 0
+}
+
+func (m *Int32Rules) GetLte() int32 {
+	if m != nil && m.Lte != nil {
+		return *m.Lte
+	}
+	return 0
+}
+
+type Int64Rules struct {
+	Gt                   *int64   `protobuf:"varint,1,opt,name=gt" json:"gt,omitempty"`
+	Gte                  *int64   `protobuf:"varint,2,opt,name=gte" json:"gte,omitempty"`
+	Lt                   *int64   `protobuf:"varint,3,opt,name=lt" json:"lt,omitempty"`
+	Lte                  *int64   `protobuf:"varint,4,opt,name=lte" json:"lte,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Int64Rules) Reset()         { *m = Int64Rules{} }
+func (m *Int64Rules) String() string { return proto.CompactTextString(m) }
+func (*Int64Rules) ProtoMessage()    {}
+func (*Int64Rules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_validate_ecdda15ce150eab2, []int{3}
+}
+func (m *Int64Rules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Int64Rules.Unmarshal(m, b)
+}
+func (m *Int64Rules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Int64Rules.Marshal(b, m, deterministic)
+}
+func (dst *Int64Rules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Int64Rules.Merge(dst, src)
+}
+func (m *Int64Rules) XXX_Size() int {
+	return xxx_messageInfo_Int64Rules.Size(m)
+}
+func (m *Int64Rules) XXX_DiscardUnknown() {
+	xxx_messageInfo_Int64Rules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Int64Rules proto.InternalMessageInfo
+
+func (m *Int64Rules) GetGt() int64 {
+	if m != nil && m.Gt != nil {
+		return *m.Gt
+	}
+	return 0
+}
+
+func (m *Int64Rules) GetGte() int64 {
+	if m != nil && m.Gte != nil {
+		return *m.Gte
+	}
+	return 0
+}
+
+func (m *Int64Rules) GetLt() int64 {
+	if m != nil && m.Lt != nil {
+		return *m.Lt
+	}
+	return 0
+}
+
+func (m *Int64Rules) GetLte() int64 {
+	if m != nil && m.Lte != nil {
+		return *m.Lte
+	}
+	return 0
+}
+
+var E_Rules = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+	ExtensionType: (*FieldRules)(nil),
+	Field:         51071,
+	Name:          "validate.rules",
+	Tag:           "bytes,51071,opt,name=rules",
+	Filename:      "validate/validatepb/validate.proto",
+}
+
+func init() {
+	proto.RegisterType((*FieldRules)(nil), "validate.FieldRules")
+	proto.RegisterType((*StringRules)(nil), "validate.StringRules")
+	proto.RegisterType((*Int32Rules)(nil), "validate.Int32Rules")
+	proto.RegisterType((*Int64Rules)(nil), "validate.Int64Rules")
+	proto.RegisterExtension(E_Rules)
+}
+
+func init() {
+	proto.RegisterFile("validate/validatepb/validate.proto", fileDescriptor_validate_ecdda15ce150eab2)
+}
+
+var fileDescriptor_validate_ecdda15ce150eab2 = []byte{
+	// 362 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x51, 0x4b, 0xfb, 0x30,
+	0x10, 0xc0, 0x69, 0xbb, 0xee, 0x3f, 0x6e, 0xf0, 0x47, 0x82, 0x62, 0x19, 0x08, 0xa3, 0x4f, 0x43,
+	0x58, 0x0b, 0x73, 0xec, 0x61, 0x08, 0x82, 0x0f, 0x82, 0x38, 0x50, 0xe2, 0x9b, 0x2f, 0xd2, 0xb5,
+	0x31, 0x0b, 0x66, 0x49, 0x4d, 0x53, 0xd9, 0xa7, 0xf0, 0x4b, 0xf9, 0xc1, 0x94, 0x5e, 0xbb, 0x75,
+	0x14, 0xdf, 0x2e, 0x77, 0xbf, 0xfc, 0x7a, 0xd7, 0x0b, 0x84, 0x9f, 0x89, 0x14, 0x59, 0x62, 0x59,
+	0xbc, 0x0f, 0xf2, 0xf5, 0x21, 0x8c, 0x72, 0xa3, 0xad, 0x26, 0x83, 0xfd, 0x79, 0x34, 0xe6, 0x5a,
+	0x73, 0xc9, 0x62, 0xcc, 0xaf, 0xcb, 0xb7, 0x38, 0x63, 0x45, 0x6a, 0x44, 0x6e, 0xb5, 0xa9, 0xd9,
+	0xf0, 0xdb, 0x01, 0xb8, 0x13, 0x4c, 0x66, 0xb4, 0x94, 0xac, 0x20, 0x23, 0x18, 0x18, 0xf6, 0x51,
+	0x0a, 0xc3, 0xb2, 0xc0, 0x19, 0x3b, 0x93, 0x01, 0x3d, 0x9c, 0x09, 0x81, 0x5e, 0xf1, 0x2e, 0xf2,
+	0xc0, 0xc5, 0x3c, 0xc6, 0x64, 0x0a, 0xfd, 0xc2, 0x1a, 0xa1, 0x78, 0xe0, 0x8d, 0x9d, 0xc9, 0x70,
+	0x76, 0x16, 0x1d, 0x7a, 0x79, 0xc6, 0x3c, 0x6a, 0x69, 0x03, 0x91, 0x4b, 0xf0, 0x85, 0xb2, 0x57,
+	0xb3, 0xa0, 0x87, 0xf4, 0x69, 0x4b, 0xdf, 0x57, 0xe9, 0x1a, 0xae, 0x91, 0x86, 0x5d, 0xcc, 0x03,
+	0xff, 0x0f, 0x76, 0x31, 0x6f, 0xd9, 0xc5, 0x3c, 0xbc, 0x81, 0xe1, 0xd1, 0xe7, 0xc8, 0x39, 0xfc,
+	0xdb, 0x0a, 0xf5, 0x2a, 0x99, 0xc2, 0x21, 0x7a, 0xb4, 0xbf, 0x15, 0x6a, 0xc5, 0x14, 0x16, 0x92,
+	0x1d, 0x16, 0xdc, 0xa6, 0x90, 0xec, 0x56, 0x4c, 0x85, 0x4f, 0x00, 0x6d, 0x07, 0xe4, 0x3f, 0xb8,
+	0xdc, 0xe2, 0x55, 0x9f, 0xba, 0xdc, 0x92, 0x13, 0xf0, 0xb8, 0x65, 0x78, 0xc5, 0xa7, 0x55, 0x58,
+	0x11, 0xd2, 0xe2, 0xcc, 0x3e, 0x75, 0x25, 0x12, 0xd2, 0x32, 0x1c, 0xcb, 0xa7, 0x55, 0xd8, 0x18,
+	0x9b, 0x3e, 0x8f, 0x8c, 0x5e, 0xd7, 0xe8, 0x75, 0x8d, 0x5e, 0xd7, 0xe8, 0xa1, 0x71, 0xf9, 0x00,
+	0xbe, 0x41, 0xd9, 0x45, 0x54, 0xaf, 0x35, 0xda, 0xaf, 0x35, 0xc2, 0x0d, 0x3e, 0xe6, 0x56, 0x68,
+	0x55, 0x04, 0x3f, 0x5f, 0x5e, 0xf7, 0x8f, 0xb5, 0x1b, 0xa6, 0xb5, 0xe3, 0xf6, 0xfa, 0x65, 0xc9,
+	0x85, 0xdd, 0x94, 0xeb, 0x28, 0xd5, 0xdb, 0x38, 0xdd, 0x18, 0x51, 0x58, 0x91, 0x28, 0x9e, 0x25,
+	0x31, 0x37, 0x79, 0x3a, 0xe5, 0x7a, 0x9a, 0xea, 0xd2, 0x14, 0xed, 0x3b, 0x3b, 0x7a, 0x70, 0xbf,
+	0x03, 0x00, 0xf1, 0xf6, 0x42, 0x93, 0x86, 0x02, 0x00, 0x00,
+}